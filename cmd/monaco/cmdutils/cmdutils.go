@@ -17,7 +17,10 @@
 package cmdutils
 
 import (
+	"context"
+	"fmt"
 	"github.com/spf13/cobra"
+	"time"
 )
 
 // SilenceUsageCommand gives back a command that is just configured to skip printing of usage info.
@@ -28,3 +31,12 @@ func SilenceUsageCommand() func(cmd *cobra.Command, args []string) {
 		cmd.SilenceUsage = true
 	}
 }
+
+// ContextWithTimeout returns a copy of ctx that is canceled once the given timeout passed. If the timeout is 0 or less,
+// the returned context is only canceled with ctx.
+func ContextWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, timeout, fmt.Errorf("timeout of %v exceeded", timeout))
+}
@@ -0,0 +1,47 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmdutils
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/notification"
+)
+
+// Notify sends the summary to all notification webhooks of the manifest. Failed notifications are logged as warnings,
+// but do not fail the command. Notifications are sent even if ctx was canceled, to report canceled operations.
+func Notify(ctx context.Context, notifications []manifest.Notification, s notification.Summary) {
+	if len(notifications) == 0 {
+		return
+	}
+
+	webhooks := make([]notification.Webhook, 0, len(notifications))
+	for _, n := range notifications {
+		w, err := notification.NewWebhook(n.URL.Value, n.Format, n.Template, n.Headers)
+		if err != nil {
+			log.WithFields(field.Error(err)).Warn("Skipping invalid notification webhook: %v", err)
+			continue
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	if err := notification.Send(context.WithoutCancel(ctx), webhooks, s); err == nil {
+		log.Info("Sent %s summary to %d notification webhook(s)", s.Operation, len(webhooks))
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmdutils
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/spf13/afero"
+	"path/filepath"
+)
+
+// SkipProtectionFlag is the name of the flag that deletes objects without protecting the configs marked with
+// 'preventDestroy', e.g. to delete objects using a manifest whose projects can not be loaded
+const SkipProtectionFlag = "skip-protection"
+
+// LoadProtectedConfigs loads the projects of the manifest, and returns the configs marked with 'preventDestroy' per
+// environment. If the projects can not be loaded, an error is returned, as objects of protected configs could be deleted
+// otherwise.
+func LoadProtectedConfigs(fs afero.Fs, manifestPath string, m manifest.Manifest) (map[string]pointer.Protected, error) {
+	projects, errs := project.LoadProjects(fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(manifestPath),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return nil, fmt.Errorf("failed to load the projects of manifest %q to protect configurations marked with 'preventDestroy' from deletion - use --%s to delete without protection", manifestPath, SkipProtectionFlag)
+	}
+
+	protected := make(map[string]pointer.Protected, len(m.Environments))
+	for name := range m.Environments {
+		p, err := delete.ProtectedConfigs(projects, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to protect configurations marked with 'preventDestroy' on environment %q - use --%s to delete without protection: %w", name, SkipProtectionFlag, err)
+		}
+		protected[name] = p
+	}
+	return protected, nil
+}
@@ -0,0 +1,37 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmdutils
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLoadProtectedConfigs_FailsIfProjectsCanNotBeLoaded(t *testing.T) {
+	m := manifest.Manifest{
+		Projects:     manifest.ProjectDefinitionByProjectID{"missing": {Name: "missing", Path: "missing"}},
+		Environments: manifest.Environments{"env": {Name: "env"}},
+	}
+
+	protected, err := LoadProtectedConfigs(afero.NewMemMapFs(), "manifest.yaml", m)
+	assert.ErrorContains(t, err, "--skip-protection")
+	assert.Nil(t, protected)
+}
@@ -0,0 +1,84 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmdutils
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"strings"
+)
+
+// RestoreSnapshot restores the objects captured in the snapshot on the given environments. Environments of the
+// snapshot that are not given are skipped.
+// If a state is given, the restored objects are updated in it: created objects are removed, and the payload hash of
+// restored objects is reset, as they no longer match their configs. Objects that failed to be restored are kept as they
+// are, so that they can still be found by their recorded ID.
+func RestoreSnapshot(ctx context.Context, environments manifest.Environments, snap *snapshot.Snapshot, s *state.State) error {
+	var envsWithErrs []string
+	for _, name := range snap.Environments() {
+		env, found := environments[name]
+		if !found {
+			log.Warn("Skipping rollback of environment %q, as it is not defined in the manifest or not selected", name)
+			continue
+		}
+
+		ctx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+		objects := snap.Objects(name)
+		log.WithCtxFields(ctx).Info("Rolling back %d objects on environment %q...", len(objects), name)
+
+		clientSet, err := dynatrace.CreateClientSet(env.URL.Value, env.Auth)
+		if err != nil {
+			return fmt.Errorf("failed to create API client for environment %q: %w", env.Name, err)
+		}
+		clients := snapshot.Clients{
+			Classic:    clientSet.Classic(),
+			Settings:   clientSet.Settings(),
+			Automation: clientSet.Automation(),
+			Bucket:     clientSet.Bucket(),
+		}
+
+		restored, err := snapshot.Restore(ctx, clients, api.NewAPIs(), objects)
+		if err != nil {
+			log.WithCtxFields(ctx).Error("Failed to roll back all objects on environment %q - check log for details", name)
+			envsWithErrs = append(envsWithErrs, name)
+		} else {
+			log.WithCtxFields(ctx).Info("Rollback successful for environment %q", name)
+		}
+
+		if s != nil {
+			for _, o := range restored {
+				if o.Created {
+					s.Delete(name, o.Coordinate)
+				} else if entry, found := s.Get(name, o.Coordinate); found {
+					entry.PayloadHash = ""
+					s.Put(name, o.Coordinate, entry)
+				}
+			}
+		}
+	}
+
+	if len(envsWithErrs) > 0 {
+		return fmt.Errorf("encountered rollback errors for the following environments: %v", strings.Join(envsWithErrs, ", "))
+	}
+	return nil
+}
@@ -25,16 +25,21 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/notification"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"path/filepath"
+	"time"
 )
 
 func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 	var environments, groups []string
 	var manifestName string
 	var deleteFile string
+	var timeout time.Duration
+	var skipProtection bool
 
 	deleteCmd = &cobra.Command{
 		Use:     "delete --manifest <manifest.yaml> --file <delete.yaml>",
@@ -80,7 +85,19 @@ func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 				return fmt.Errorf("encountered errors while parsing delete.yaml: %s", errs)
 			}
 
-			return Delete(manifest.Environments, entriesToDelete)
+			ctx, cancel := cmdutils.ContextWithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			var protected map[string]pointer.Protected
+			if !skipProtection {
+				if protected, err = cmdutils.LoadProtectedConfigs(fs, absManifestFilePath, manifest); err != nil {
+					return err
+				}
+			}
+
+			err = Delete(ctx, manifest.Environments, entriesToDelete, protected)
+			cmdutils.Notify(ctx, manifest.Notifications, notification.NewSummary("delete", false, manifest.Environments.Names(), err, nil))
+			return err
 		},
 		ValidArgsFunction: completion.DeleteCompletion,
 	}
@@ -101,6 +118,10 @@ func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 			"If this flag is specified, configuration will be deleted from all specified environments. "+
 			"If neither --groups nor --environment is present, all environments will be used for deletion")
 
+	deleteCmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum duration of the deletion, e.g. '30m'. Once it passed, or the deletion is interrupted, no further configurations are deleted. By default, there is no timeout.")
+
+	deleteCmd.Flags().BoolVar(&skipProtection, cmdutils.SkipProtectionFlag, false, "Delete configurations without loading the projects of the manifest to protect configurations marked with 'preventDestroy'. Use this flag if the projects can not be loaded.")
+
 	if err := deleteCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByArg0); err != nil {
 		log.Fatal("failed to setup CLI %v", err)
 	}
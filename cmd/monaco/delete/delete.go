@@ -16,12 +16,15 @@ package delete
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"strings"
 )
@@ -29,16 +32,24 @@ import (
 // Delete removes configurations from multiple Dynatrace environments based on the specified deletion entries.
 //
 // Parameters:
+//   - ctx: The context of the deletion. Once it is canceled, no deletions are started anymore.
 //   - environments: A list of Dynatrace environments to perform the deletion on.
 //   - entriesToDelete: Deletion entries specifying what configurations to remove.
+//   - protected: The configurations marked with 'preventDestroy' per environment. Their objects are not deleted.
 //
 // Returns:
 //   - error: If an error occurs during the deletion process, an error is returned, describing the issue.
 //     If no errors occur, nil is returned.
-func Delete(environments manifest.Environments, entriesToDelete delete.DeleteEntries) error {
-	var envsWithDeleteErrs []string
+func Delete(ctx context.Context, environments manifest.Environments, entriesToDelete delete.DeleteEntries, protected map[string]pointer.Protected) error {
+	var canceledEnvs []string
+	deleteErrs := make(deployErrors.EnvironmentDeploymentErrors)
 	for _, env := range environments {
-		ctx := context.WithValue(context.TODO(), log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+		if ctx.Err() != nil {
+			canceledEnvs = append(canceledEnvs, env.Name)
+			continue
+		}
+
+		ctx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
 		if containsPlatformTypes(entriesToDelete) && env.Auth.OAuth == nil {
 			log.WithCtxFields(ctx).Warn("Delete file contains Dynatrace Platform specific types, but no oAuth credentials are defined for environment %q - Dynatrace Platform configurations won't be deleted.", env.Name)
 		}
@@ -64,14 +75,26 @@ func Delete(environments manifest.Environments, entriesToDelete delete.DeleteEnt
 			Buckets:    clientSet.Bucket(),
 		}
 
-		if err := delete.Configs(ctx, deleteClients, classicAPIs, automationAPIs, entriesToDelete); err != nil {
+		entries := delete.WithoutProtected(ctx, entriesToDelete, classicAPIs, protected[env.Name])
+		if err := delete.Configs(ctx, deleteClients, classicAPIs, automationAPIs, entries); err != nil {
 			log.Error("Failed to delete all configurations from environment %q - check log for details", env.Name)
-			envsWithDeleteErrs = append(envsWithDeleteErrs, env.Name)
+			deleteErrs = deleteErrs.Append(env.Name, err)
+		}
+	}
+
+	if len(canceledEnvs) > 0 {
+		log.Warn("Deletion was canceled - no configurations were deleted from the following environments: %v", strings.Join(canceledEnvs, ", "))
+	}
+	if ctx.Err() != nil {
+		err := fmt.Errorf("deletion was canceled: %w", context.Cause(ctx))
+		if len(deleteErrs) > 0 {
+			return errors.Join(err, deleteErrs)
 		}
+		return err
 	}
 
-	if len(envsWithDeleteErrs) > 0 {
-		return fmt.Errorf("encountered deletion errors for the following environments: %v", strings.Join(envsWithDeleteErrs, ", "))
+	if len(deleteErrs) > 0 {
+		return fmt.Errorf("encountered deletion errors: %w", deleteErrs)
 	}
 	return nil
 }
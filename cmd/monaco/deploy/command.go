@@ -22,14 +22,18 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"time"
 )
 
 func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
-	var dryRun, continueOnError bool
-	var manifestName string
-	var environment, project, groups []string
+	var dryRun, continueOnError, plan, prune, autoApprove, rollbackOnFailure, withDependents, force, sendEvent bool
+	var manifestName, stateFile, snapshotDir, verify string
+	var environment, project, groups, reportFiles, configs, types []string
+	var maxConcurrentDeployments, parallelEnvironments int
+	var timeout time.Duration
 
 	deployCmd = &cobra.Command{
 		Use:               "deploy <manifest.yaml>",
@@ -47,7 +51,51 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 				return err
 			}
 
-			return deployConfigs(fs, manifestName, groups, environment, project, continueOnError, dryRun)
+			if maxConcurrentDeployments < 0 || parallelEnvironments < 1 {
+				return fmt.Errorf("'--max-concurrent-deployments' must not be negative, and '--parallel-environments' must be at least 1")
+			}
+
+			selection := configSelection{configs: configs, types: types, withDependents: withDependents}
+			if withDependents && selection.isEmpty() {
+				return fmt.Errorf("'--with-dependents' requires '--config' or '--type'")
+			}
+
+			if force && stateFile == "" {
+				return fmt.Errorf("'--force' requires '--state'")
+			}
+
+			verification := deploy.Verification(verify)
+			if verification != deploy.NoVerification && verification != deploy.VerifyWarn && verification != deploy.VerifyError {
+				return fmt.Errorf("'--verify' must be %q or %q, but is %q", deploy.VerifyWarn, deploy.VerifyError, verify)
+			}
+
+			ctx, cancel := cmdutils.ContextWithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			if plan {
+				return planDeployment(ctx, fs, cmd.OutOrStdout(), manifestName, groups, environment, project, selection, stateFile)
+			}
+
+			return deployConfigs(ctx, fs, cmd.InOrStdin(), cmd.OutOrStdout(), deployCmdOptions{
+				manifestPath:             manifestName,
+				environmentGroups:        groups,
+				specificEnvironments:     environment,
+				specificProjects:         project,
+				continueOnErr:            continueOnError,
+				dryRun:                   dryRun,
+				stateFile:                stateFile,
+				prune:                    prune,
+				autoApprove:              autoApprove,
+				snapshotDir:              snapshotDir,
+				rollbackOnFailure:        rollbackOnFailure,
+				reportFiles:              reportFiles,
+				maxConcurrentDeployments: maxConcurrentDeployments,
+				parallelEnvironments:     parallelEnvironments,
+				selection:                selection,
+				force:                    force,
+				verify:                   verification,
+				sendEvent:                sendEvent,
+			})
 		},
 	}
 
@@ -61,8 +109,25 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 			"If this flag is specified, all environments within this group will be used for deployment. "+
 			"This flag is mutually exclusive with '--environment'")
 	deployCmd.Flags().StringSliceVarP(&project, "project", "p", make([]string, 0), "Project configuration to deploy (also deploys any dependent configurations)")
+	deployCmd.Flags().StringSliceVar(&configs, "config", []string{}, "Only deploy the configurations with the given coordinate in the form 'project:type:id' (also deploys the configurations they depend on). Each part may be a glob pattern, e.g. 'my-project:dashboard:*'. To set multiple configurations either repeat this flag, or separate them using a comma (,).")
+	deployCmd.Flags().StringSliceVar(&types, "type", []string{}, "Only deploy the configurations of the given type (also deploys the configurations they depend on). The type may be a glob pattern, e.g. 'builtin:alerting.*'. To set multiple types either repeat this flag, or separate them using a comma (,).")
+	deployCmd.Flags().BoolVar(&withDependents, "with-dependents", false, "Also deploy all configurations depending on the configurations selected by '--config' or '--type'.")
 	deployCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Validate the structure of your manifest, projects and configurations. Dry-run will resolve all configuration parameters and render JSON templates, but can not validate the content of JSON payloads. After a successful dry-run, deployments may still fail with Dynatrace API errors if the content of JSONs is not valid.")
 	deployCmd.Flags().BoolVarP(&continueOnError, "continue-on-error", "c", false, "Proceed deployment even if individual configuration deployments fail.")
+	deployCmd.Flags().StringVar(&stateFile, "state", "", "Path of a local deployment state file. Objects recorded in the state are updated by their ID, and the objects configurations are deployed to are recorded after the deployment. The file is created if it does not exist. The state is not used in dry-run mode. With '--plan', objects recorded in the state are looked up, but the state is not written.")
+	deployCmd.Flags().BoolVar(&force, "force", false, "Deploy all configurations, including the ones that are unchanged since their last deployment recorded in the '--state' file. By default, configurations whose rendered JSON payload and parameter values did not change are not deployed again.")
+	deployCmd.Flags().BoolVar(&prune, "prune", false, "After a successful deployment, delete objects monaco deployed for configurations that were removed from the deployed projects. Settings are found by their monaco-generated external IDs, all other objects only if they are recorded in the '--state' file. The objects to delete are listed and need to be confirmed. In dry-run mode, the objects are only listed.")
+	deployCmd.Flags().BoolVar(&autoApprove, "auto-approve", false, "Prune objects and deploy rollout stages without asking for confirmation.")
+	deployCmd.Flags().StringVar(&snapshotDir, "snapshot", "", "Directory to write a snapshot of all objects the deployment changes to. Objects are captured before they are updated, and created objects are recorded. The snapshot can be restored using 'monaco rollback'. The directory must be new or empty. No snapshot is taken in dry-run mode.")
+	deployCmd.Flags().BoolVar(&rollbackOnFailure, "rollback-on-failure", false, "Restore the '--snapshot' if the deployment fails.")
+	deployCmd.Flags().StringSliceVar(&reportFiles, "report", []string{}, "Write a report of the deployment result of each configuration to the given file. Files with an '.xml' extension are written as JUnit XML, all other files as JSON. To write several reports either repeat this flag, or separate the files using a comma (,).")
+	deployCmd.Flags().IntVar(&maxConcurrentDeployments, "max-concurrent-deployments", 0, "Maximum number of configurations deployed to an environment at once. By default, all configurations that do not depend on each other are deployed at once. The number of concurrent API requests per environment is further limited by the MONACO_CONCURRENT_REQUESTS environment variable.")
+	deployCmd.Flags().IntVar(&parallelEnvironments, "parallel-environments", 1, "Number of environments deployed at once. By default, environments are deployed one after another. Without '--continue-on-error', no further environments are started once a deployment to an environment failed.")
+	deployCmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum duration of the deployment, e.g. '30m'. Once it passed, or the deployment is interrupted, no further configurations are deployed, while configurations that are already being deployed finish. By default, there is no timeout.")
+	deployCmd.Flags().StringVar(&verify, "verify", "", "Read each deployed object back from the environment and compare it to its rendered JSON template, to find fields the API silently dropped or changed. Fields that are only present on the environment, like defaults, are ignored. With '--verify' or '--verify=warn', differences are logged as warnings and listed in the '--report'. With '--verify=error', configurations whose object differs fail to deploy. Nothing is verified in dry-run mode.")
+	deployCmd.Flags().Lookup("verify").NoOptDefVal = string(deploy.VerifyWarn)
+	deployCmd.Flags().BoolVar(&sendEvent, "send-event", false, "After deploying to an environment, send a CUSTOM_CONFIGURATION event listing the changed configurations, the git revision of the manifest directory and the user running the deployment to it. Events can also be enabled using 'deploymentEvents' in the manifest. No event is sent if no configuration was changed, or in dry-run mode.")
+	deployCmd.Flags().BoolVar(&plan, "plan", false, "Show which configurations would be created, updated (including a diff of the changes) or left unchanged on the environments, without deploying anything.")
 
 	err := deployCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag)
 	if err != nil {
@@ -75,6 +140,15 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 	}
 
 	deployCmd.MarkFlagsMutuallyExclusive("environment", "group")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "dry-run")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "prune")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "snapshot")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "report")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "verify")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "send-event")
+	deployCmd.MarkFlagsMutuallyExclusive("prune", "config")
+	deployCmd.MarkFlagsMutuallyExclusive("prune", "type")
+	deployCmd.MarkFlagsRequiredTogether("rollback-on-failure", "snapshot")
 
 	return deployCmd
 }
@@ -15,18 +15,27 @@
 package deploy
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy/internal/clientset"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy/internal/logging"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/internal/clientset"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/report"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/notification"
+	"io"
+	"os/exec"
+	"os/user"
 	"path/filepath"
-	"slices"
 	"strings"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
@@ -36,50 +45,246 @@ import (
 	"github.com/spf13/afero"
 )
 
-func deployConfigs(fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, specificProjects []string, continueOnErr bool, dryRun bool) error {
+type deployCmdOptions struct {
+	manifestPath         string
+	environmentGroups    []string
+	specificEnvironments []string
+	specificProjects     []string
+	continueOnErr        bool
+	dryRun               bool
+	// stateFile is the path of the deployment state file. If empty, no state is used.
+	stateFile string
+	// prune states that objects deployed for configs that no longer exist are deleted after the deployment
+	prune bool
+	// autoApprove states that pruning and rollout stages do not need to be confirmed
+	autoApprove bool
+	// snapshotDir is the directory the snapshot of changed objects is written to. If empty, no snapshot is taken.
+	snapshotDir string
+	// rollbackOnFailure states that the snapshot is restored if the deployment fails
+	rollbackOnFailure bool
+	// reportFiles are the files the deployment report is written to, see report.Report.Write
+	reportFiles []string
+	// maxConcurrentDeployments limits how many configs are deployed to an environment at once. 0 means no limit.
+	maxConcurrentDeployments int
+	// parallelEnvironments is the number of environments deployed at once
+	parallelEnvironments int
+	// selection restricts the deployment to single configs
+	selection configSelection
+	// force states that configs are deployed even if they are unchanged since their last deployment recorded in the state
+	force bool
+	// verify states how deployed objects are verified by reading them back
+	verify deploy.Verification
+	// sendEvent states that a deployment event is sent to each environment, in addition to the environments the manifest
+	// enables deployment events for
+	sendEvent bool
+}
+
+func deployConfigs(ctx context.Context, fs afero.Fs, in io.Reader, out io.Writer, opts deployCmdOptions) error {
+	loadedManifest, filteredProjects, err := loadDeployment(fs, opts.manifestPath, opts.environmentGroups, opts.specificEnvironments, opts.specificProjects, opts.selection, opts.dryRun)
+	if err != nil {
+		return err
+	}
+
+	clientSets, err := clientset.NewEnvironmentClients(loadedManifest.Environments, opts.dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to create API clients: %w", err)
+	}
+
+	deployOpts := deploy.DeployConfigsOptions{
+		ContinueOnErr:            opts.continueOnErr,
+		DryRun:                   opts.dryRun,
+		MaxConcurrentDeployments: opts.maxConcurrentDeployments,
+		MaxParallelEnvironments:  opts.parallelEnvironments,
+		Force:                    opts.force,
+		Verify:                   opts.verify,
+		Hooks:                    environmentHooks(loadedManifest.Environments),
+	}
+	if opts.sendEvent || loadedManifest.DeploymentEvents.Enabled {
+		deployOpts.DeploymentEvent = deploymentEventOptions(opts.manifestPath, loadedManifest.DeploymentEvents)
+	}
+	if opts.stateFile != "" && !opts.dryRun {
+		if deployOpts.State, err = state.Load(fs, opts.stateFile); err != nil {
+			return err
+		}
+	}
+
+	if opts.snapshotDir != "" && !opts.dryRun {
+		if err := verifySnapshotDir(fs, opts.snapshotDir); err != nil {
+			return err
+		}
+		deployOpts.Snapshot = snapshot.New()
+	}
+
+	if len(opts.reportFiles) > 0 || len(loadedManifest.Notifications) > 0 {
+		deployOpts.Report = report.New()
+	}
+
+	var deployErr error
+	if len(loadedManifest.Stages) > 0 {
+		deployErr = deployStages(ctx, in, out, loadedManifest.Stages, filteredProjects, clientSets, deployOpts, opts.autoApprove)
+	} else {
+		deployErr = deploy.Deploy(ctx, filteredProjects, clientSets, deployOpts)
+	}
+
+	var reportErr error
+	for _, file := range opts.reportFiles {
+		if err := deployOpts.Report.Write(fs, file); err != nil {
+			log.WithFields(field.Error(err)).Error("Failed to write deployment report: %v", err)
+			reportErr = err
+		} else {
+			log.Info("Deployment report written to %q", file)
+		}
+	}
+
+	var rollbackErr error
+	if deployOpts.Snapshot != nil && !deployOpts.Snapshot.IsEmpty() {
+		if err := deployOpts.Snapshot.Write(fs, opts.snapshotDir); err != nil {
+			log.WithFields(field.Error(err)).Error("Failed to write snapshot: %v", err)
+		} else {
+			log.Info("Snapshot of changed objects written to %q", opts.snapshotDir)
+		}
+
+		if deployErr != nil && opts.rollbackOnFailure {
+			log.Warn("Deployment failed - rolling back changed objects...")
+			// the rollback is not canceled together with the deployment, so that it restores all changed objects
+			rollbackErr = cmdutils.RestoreSnapshot(context.WithoutCancel(ctx), loadedManifest.Environments, deployOpts.Snapshot, deployOpts.State)
+		} else if deployErr != nil {
+			log.Warn("Deployment failed - changed objects can be restored using 'monaco rollback %s --manifest %s'", opts.snapshotDir, opts.manifestPath)
+		}
+	}
+
+	var pruneErr error
+	if opts.prune {
+		if deployErr != nil {
+			log.Warn("Skipping pruning, as the %s failed", strings.ToLower(logging.GetOperationNounForLogging(opts.dryRun)))
+		} else {
+			pruneErr = pruneEnvironments(ctx, in, out, loadedManifest.Environments, filteredProjects, deployOpts.State, opts.dryRun, opts.autoApprove)
+		}
+	}
+
+	// the notification is sent once rollback and pruning finished, so that it reports their errors as well
+	cmdutils.Notify(ctx, loadedManifest.Notifications, notification.NewSummary("deploy", opts.dryRun, loadedManifest.Environments.Names(), errors.Join(deployErr, rollbackErr, pruneErr), deployOpts.Report))
+
+	// the state is written even if the deployment failed, to record all configs that were deployed successfully
+	if deployOpts.State != nil {
+		if err := deployOpts.State.Write(fs, opts.stateFile); err != nil {
+			log.WithFields(field.Error(err)).Error("Failed to write deployment state: %v", err)
+			if deployErr == nil && pruneErr == nil && rollbackErr == nil {
+				return err
+			}
+		} else {
+			log.Info("Deployment state written to %q", opts.stateFile)
+		}
+	}
+
+	if rollbackErr != nil {
+		return fmt.Errorf("deployment and rollback failed - check logs for details: %w", errors.Join(deployErr, rollbackErr))
+	}
+	if deployErr != nil {
+		return fmt.Errorf("%v failed - check logs for details: %w", logging.GetOperationNounForLogging(opts.dryRun), deployErr)
+	}
+	if pruneErr != nil {
+		return fmt.Errorf("pruning failed - check logs for details: %w", pruneErr)
+	}
+
+	if reportErr != nil {
+		return reportErr
+	}
+
+	log.Info("%s finished without errors", logging.GetOperationNounForLogging(opts.dryRun))
+	return nil
+}
+
+// environmentHooks returns the hooks defined in the manifest for each environment
+func environmentHooks(environments manifest.Environments) map[string]hook.Hooks {
+	hooks := make(map[string]hook.Hooks, len(environments))
+	for name, env := range environments {
+		if !env.Hooks.IsEmpty() {
+			hooks[name] = env.Hooks
+		}
+	}
+	return hooks
+}
+
+// deploymentEventOptions returns the options of the deployment events. The revision is the git commit of the manifest
+// directory, and the operator is the current user. Both are left empty if they can not be determined.
+func deploymentEventOptions(manifestPath string, events manifest.DeploymentEvents) *deploy.EventOptions {
+	opts := deploy.EventOptions{EntitySelector: events.EntitySelector}
+
+	if out, err := exec.Command("git", "-C", filepath.Dir(manifestPath), "rev-parse", "HEAD").Output(); err == nil {
+		opts.Revision = strings.TrimSpace(string(out))
+	} else {
+		log.Debug("Failed to determine git revision of %q for deployment events: %v", manifestPath, err)
+	}
+
+	if u, err := user.Current(); err == nil {
+		opts.Operator = u.Username
+	} else {
+		log.Debug("Failed to determine current user for deployment events: %v", err)
+	}
+
+	return &opts
+}
+
+// verifySnapshotDir ensures that a snapshot does not mix objects of several deployments, by only allowing to write it
+// to a new or empty directory
+func verifySnapshotDir(fs afero.Fs, dir string) error {
+	exists, err := afero.DirExists(fs, dir)
+	if err != nil || !exists {
+		return err
+	}
+
+	empty, err := afero.IsEmpty(fs, dir)
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return fmt.Errorf("snapshot directory %q is not empty - use a new directory for every deployment", dir)
+	}
+	return nil
+}
+
+// loadDeployment loads the manifest and all projects to deploy, and verifies that they can be deployed to the
+// environments defined in the manifest. If the selection is not empty, only the selected configs are returned.
+func loadDeployment(fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, specificProjects []string, selection configSelection, dryRun bool) (*manifest.Manifest, []project.Project, error) {
 	absManifestPath, err := absPath(manifestPath)
 	if err != nil {
-		return fmt.Errorf("error while finding absolute path for `%s`: %w", manifestPath, err)
+		return nil, nil, fmt.Errorf("error while finding absolute path for `%s`: %w", manifestPath, err)
 	}
 	loadedManifest, err := loadManifest(fs, absManifestPath, environmentGroups, specificEnvironments)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	ok := verifyEnvironmentGen(loadedManifest.Environments, dryRun)
 	if !ok {
-		return fmt.Errorf("unable to verify Dynatrace environment generation")
+		return nil, nil, fmt.Errorf("unable to verify Dynatrace environment generation")
 	}
 
 	loadedProjects, err := loadProjects(fs, absManifestPath, loadedManifest)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	filteredProjects, err := filterProjects(loadedProjects, specificProjects, loadedManifest.Environments.Names())
+	filteredProjects, err := cmdutils.FilterProjects(loadedProjects, specificProjects, loadedManifest.Environments.Names())
 	if err != nil {
-		return fmt.Errorf("error while loading relevant projects to deploy: %w", err)
+		return nil, nil, fmt.Errorf("error while loading relevant projects to deploy: %w", err)
 	}
 
-	if err := checkEnvironments(filteredProjects, loadedManifest.Environments); err != nil {
-		return err
+	if !selection.isEmpty() {
+		if filteredProjects, err = selectConfigs(filteredProjects, loadedManifest.Environments.Names(), selection); err != nil {
+			return nil, nil, fmt.Errorf("error while selecting configurations to deploy: %w", err)
+		}
 	}
 
-	logging.LogProjectsInfo(filteredProjects)
-	logging.LogEnvironmentsInfo(loadedManifest.Environments)
-
-	clientSets, err := clientset.NewEnvironmentClients(loadedManifest.Environments, dryRun)
-	if err != nil {
-		return fmt.Errorf("failed to create API clients: %w", err)
+	if err := cmdutils.CheckEnvironments(filteredProjects, loadedManifest.Environments); err != nil {
+		return nil, nil, err
 	}
 
-	err = deploy.Deploy(filteredProjects, clientSets, deploy.DeployConfigsOptions{ContinueOnErr: continueOnErr, DryRun: dryRun})
-	if err != nil {
-		return fmt.Errorf("%v failed - check logs for details: %w", logging.GetOperationNounForLogging(dryRun), err)
-	}
+	logging.LogProjectsInfo(filteredProjects)
+	logging.LogEnvironmentsInfo(loadedManifest.Environments)
 
-	log.Info("%s finished without errors", logging.GetOperationNounForLogging(dryRun))
-	return nil
+	return loadedManifest, filteredProjects, nil
 }
 
 func absPath(manifestPath string) (string, error) {
@@ -130,137 +335,3 @@ func loadProjects(fs afero.Fs, manifestPath string, man *manifest.Manifest) ([]p
 
 	return projects, nil
 }
-
-func filterProjects(projects []project.Project, specificProjects []string, specificEnvironments []string) ([]project.Project, error) {
-
-	if len(specificProjects) > 0 {
-		filtered, err := filterProjectsByName(projects, specificProjects)
-
-		if err != nil {
-			return nil, err
-		}
-
-		projectsWithDependencies, err := loadProjectsWithDependencies(projects, filtered, specificEnvironments)
-
-		if err != nil {
-			return nil, err
-		}
-
-		projects = projectsWithDependencies
-	}
-
-	return projects, nil
-}
-
-func filterProjectsByName(projects []project.Project, names []string) ([]string, error) {
-	var result []string
-
-	foundProjects := map[string]struct{}{}
-
-	for _, p := range projects {
-		if slices.Contains(names, p.Id) {
-			foundProjects[p.Id] = struct{}{}
-			result = append(result, p.Id)
-		} else if slices.Contains(names, p.GroupId) {
-			foundProjects[p.GroupId] = struct{}{}
-			result = append(result, p.Id)
-		}
-	}
-
-	var notFoundProjects []string
-
-	for _, name := range names {
-		if _, found := foundProjects[name]; !found {
-			notFoundProjects = append(notFoundProjects, name)
-		}
-	}
-
-	if notFoundProjects != nil {
-		return nil, fmt.Errorf("no project with names `%s` found", strings.Join(names, ", "))
-	}
-
-	return result, nil
-}
-
-func loadProjectsWithDependencies(projects []project.Project, projectIdsToLoad []string, environments []string) ([]project.Project, error) {
-	lookupMap := toProjectMap(projects)
-	alreadyChecked := map[string]struct{}{}
-	toCheck := append(make([]string, 0, len(projectIdsToLoad)), projectIdsToLoad...)
-
-	var result []project.Project
-	var unknownProjects []string
-
-	for len(toCheck) > 0 {
-		current := toCheck[0]
-		toCheck = toCheck[1:]
-
-		if _, found := alreadyChecked[current]; found {
-			continue
-		}
-
-		if project, found := lookupMap[current]; found {
-			alreadyChecked[current] = struct{}{}
-			result = append(result, project)
-
-			// we need to load only the dependencies of environments we are going to deploy
-			for _, env := range environments {
-				toCheck = append(toCheck, project.Dependencies[env]...)
-			}
-		} else {
-			unknownProjects = append(unknownProjects, current)
-		}
-	}
-
-	if unknownProjects != nil {
-		return nil, fmt.Errorf("error while gathering dependencies. no projects with name `%s` found", unknownProjects)
-	}
-
-	return result, nil
-}
-
-func toProjectMap(projects []project.Project) map[string]project.Project {
-	result := make(map[string]project.Project)
-
-	for _, p := range projects {
-		result[p.Id] = p
-	}
-
-	return result
-}
-
-func checkEnvironments(projects []project.Project, envs manifest.Environments) error {
-	for _, p := range projects {
-		for envName, cfgPerType := range p.Configs {
-			if _, found := envs[envName]; !found {
-				return fmt.Errorf("cannot find environment `%s`", envName)
-			}
-			for _, cfgs := range cfgPerType {
-				if err := checkConfigsForEnvironment(envs[envName], cfgs); err != nil {
-					return err
-				}
-			}
-		}
-	}
-	return nil
-}
-
-func checkConfigsForEnvironment(env manifest.EnvironmentDefinition, cfgs []config.Config) error {
-	for i := range cfgs {
-		if !cfgs[i].Skip && onlyAvailableOnPlatform(&cfgs[i]) && !platformEnvironment(env) {
-			return fmt.Errorf("enviroment %q is not specified as platform, but at least one of configurations (e.g. %q) is platform exclusive", env.Name, cfgs[i].Coordinate)
-		}
-	}
-	return nil
-}
-
-func platformEnvironment(e manifest.EnvironmentDefinition) bool {
-	return e.Auth.OAuth != nil
-}
-
-func onlyAvailableOnPlatform(c *config.Config) bool {
-	if _, ok := c.Type.(config.AutomationType); ok {
-		return true
-	}
-	_, ok := c.Type.(config.BucketType)
-	return ok
-}
@@ -0,0 +1,62 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/internal/clientset"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	"github.com/spf13/afero"
+	"io"
+)
+
+// planDeployment prints which configurations a deployment would create, update or leave unchanged, without deploying anything.
+// If a stateFile is given, objects recorded in it are looked up like in a deployment using the same state.
+func planDeployment(ctx context.Context, fs afero.Fs, out io.Writer, manifestPath string, environmentGroups []string, specificEnvironments []string, specificProjects []string, selection configSelection, stateFile string) error {
+	loadedManifest, filteredProjects, err := loadDeployment(fs, manifestPath, environmentGroups, specificEnvironments, specificProjects, selection, false)
+	if err != nil {
+		return err
+	}
+
+	clientSets, err := clientset.NewEnvironmentClients(loadedManifest.Environments, false)
+	if err != nil {
+		return fmt.Errorf("failed to create API clients: %w", err)
+	}
+
+	var st *state.State
+	if stateFile != "" {
+		if st, err = state.Load(fs, stateFile); err != nil {
+			return err
+		}
+	}
+
+	p, err := plan.New(ctx, filteredProjects, clientSets, st)
+	if p != nil {
+		if printErr := plan.Print(out, p); printErr != nil {
+			return fmt.Errorf("failed to print deployment plan: %w", printErr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("planning deployment failed - check logs for details: %w", err)
+	}
+
+	log.Info("Planning deployment finished without errors")
+	return nil
+}
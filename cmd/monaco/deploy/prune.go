@@ -0,0 +1,140 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/prune"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"io"
+	"slices"
+	"strings"
+)
+
+// pruneEnvironments deletes all objects monaco deployed for the given projects that no longer correspond to any of
+// their configs. The objects to delete are printed to out first. In dry-run mode nothing is deleted, otherwise the
+// deletion has to be confirmed via in, unless autoApprove is set.
+// If a state is given, the pruned objects are removed from it.
+func pruneEnvironments(ctx context.Context, in io.Reader, out io.Writer, environments manifest.Environments, projects []project.Project, s *state.State, dryRun bool, autoApprove bool) error {
+	envNames := environments.Names()
+	slices.Sort(envNames)
+
+	clients := make(map[string]delete.ClientSet, len(environments))
+	orphans := make(map[string]prune.Orphans, len(environments))
+	for _, name := range envNames {
+		env := environments[name]
+		ctx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+		log.WithCtxFields(ctx).Info("Searching objects to prune on environment %q...", env.Name)
+
+		clientSet, err := dynatrace.CreateClientSet(env.URL.Value, env.Auth)
+		if err != nil {
+			return fmt.Errorf("failed to create API client for environment %q: %w", env.Name, err)
+		}
+		clients[env.Name] = delete.ClientSet{
+			Classic:    clientSet.Classic(),
+			Settings:   clientSet.Settings(),
+			Automation: clientSet.Automation(),
+			Buckets:    clientSet.Bucket(),
+		}
+
+		if orphans[env.Name], err = prune.Find(ctx, clients[env.Name], api.NewAPIs(), projects, env.Name, s); err != nil {
+			return fmt.Errorf("failed to find objects to prune on environment %q: %w", env.Name, err)
+		}
+	}
+
+	if err := prune.Print(out, orphans); err != nil {
+		return err
+	}
+
+	total := 0
+	for _, o := range orphans {
+		total += len(o)
+	}
+	if total == 0 || dryRun {
+		return nil
+	}
+
+	if !autoApprove {
+		confirmed, err := confirm(in, out, fmt.Sprintf("Do you want to delete these %d objects? Only 'yes' will be accepted: ", total))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			log.Info("Pruning cancelled - no objects were deleted")
+			return nil
+		}
+	}
+
+	automationResources := map[string]config.AutomationResource{
+		string(config.Workflow):         config.Workflow,
+		string(config.BusinessCalendar): config.BusinessCalendar,
+		string(config.SchedulingRule):   config.SchedulingRule,
+	}
+
+	var envsWithErrs []string
+	for _, name := range envNames {
+		if len(orphans[name]) == 0 {
+			continue
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("pruning was canceled before pruning environment %q: %w", name, context.Cause(ctx))
+		}
+
+		env := environments[name]
+		ctx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+		log.WithCtxFields(ctx).Info("Pruning %d objects from environment %q...", len(orphans[name]), name)
+
+		if err := delete.Configs(ctx, clients[name], api.NewAPIs(), automationResources, orphans[name].DeleteEntries()); err != nil {
+			log.WithCtxFields(ctx).Error("Failed to prune all objects from environment %q - check log for details", name)
+			envsWithErrs = append(envsWithErrs, name)
+			continue
+		}
+
+		if s != nil {
+			for _, o := range orphans[name] {
+				s.Delete(name, o.Coordinate)
+			}
+		}
+	}
+
+	if len(envsWithErrs) > 0 {
+		return fmt.Errorf("encountered deletion errors for the following environments: %v", strings.Join(envsWithErrs, ", "))
+	}
+	return nil
+}
+
+// confirm prints the question to out and returns whether 'yes' was answered on in
+func confirm(in io.Reader, out io.Writer, question string) (bool, error) {
+	if _, err := io.WriteString(out, question); err != nil {
+		return false, err
+	}
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	return strings.TrimSpace(answer) == "yes", nil
+}
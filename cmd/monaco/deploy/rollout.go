@@ -0,0 +1,103 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"io"
+	"slices"
+	"time"
+)
+
+// sleep waits between rollout stages, or until ctx is canceled. It is a variable to not actually wait in tests.
+var sleep = func(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+// deployStages deploys the projects stage by stage, in the order the stages are defined in the manifest's rollout.
+// If deploying to any environment of a stage fails, the remaining stages are not deployed. Before a stage is deployed,
+// its wait time passes if an earlier stage was deployed, and the rollout needs to be confirmed if the stage requires it.
+// In dry-run mode, all stages are validated without waiting or asking for confirmation.
+// Once ctx is canceled, no further stages are deployed.
+func deployStages(ctx context.Context, in io.Reader, out io.Writer, stages []manifest.Stage, projects []project.Project, clientSets deploy.EnvironmentClients, opts deploy.DeployConfigsOptions, autoApprove bool) error {
+	var errs []error
+	deployedStage := false
+
+	for i, stage := range stages {
+		clients := stageClients(stage, clientSets)
+		if len(clients) == 0 {
+			log.Debug("Skipping rollout stage %q, as none of its environments are deployed", stage.Name)
+			continue
+		}
+
+		if !opts.DryRun {
+			if stage.Wait > 0 && deployedStage {
+				log.Info("Waiting %v before deploying rollout stage %q...", stage.Wait, stage.Name)
+				sleep(ctx, stage.Wait)
+			}
+
+			if ctx.Err() != nil {
+				log.Warn("Rollout canceled before stage %q", stage.Name)
+				return errors.Join(append(errs, fmt.Errorf("rollout was canceled before stage %q: %w", stage.Name, context.Cause(ctx)))...)
+			}
+
+			if stage.Confirm && !autoApprove {
+				confirmed, err := confirm(in, out, fmt.Sprintf("Deploy rollout stage %q to environments %v? Only 'yes' will be accepted: ", stage.Name, clients.Names()))
+				if err != nil {
+					return errors.Join(append(errs, err)...)
+				}
+				if !confirmed {
+					log.Warn("Rollout aborted before stage %q", stage.Name)
+					return errors.Join(append(errs, fmt.Errorf("rollout was aborted before stage %q", stage.Name))...)
+				}
+			}
+		}
+
+		log.WithFields(field.F("stage", stage.Name)).Info("Deploying rollout stage %q (%d of %d)...", stage.Name, i+1, len(stages))
+		if err := deploy.Deploy(ctx, projects, clients, opts); err != nil {
+			errs = append(errs, fmt.Errorf("rollout stage %q failed: %w", stage.Name, err))
+			if !opts.DryRun {
+				log.WithFields(field.F("stage", stage.Name), field.Error(err)).Error("Rollout stage %q failed - remaining stages are not deployed", stage.Name)
+				break
+			}
+		}
+		deployedStage = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// stageClients returns the clients of all environments that are part of one of the stage's groups
+func stageClients(stage manifest.Stage, clientSets deploy.EnvironmentClients) deploy.EnvironmentClients {
+	clients := make(deploy.EnvironmentClients)
+	for env, c := range clientSets {
+		if slices.Contains(stage.Groups, env.Group) {
+			clients[env] = c
+		}
+	}
+	return clients
+}
@@ -0,0 +1,140 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_deployStages(t *testing.T) {
+	stages := []manifest.Stage{
+		{Name: "first", Groups: []string{"dev"}},
+		{Name: "second", Groups: []string{"staging"}, Wait: time.Minute},
+		{Name: "third", Groups: []string{"prod"}, Confirm: true},
+	}
+
+	envs := map[string]string{"dev-env": "dev", "staging-env": "staging", "prod-env": "prod"}
+	projects := []project.Project{{Id: "p", Configs: project.ConfigsPerTypePerEnvironments{}}}
+	for env := range envs {
+		projects[0].Configs[env] = project.ConfigsPerType{"builtin:test": {{
+			Template:    template.NewInMemoryTemplate("t", "{}"),
+			Coordinate:  coordinate.Coordinate{Project: "p", Type: "builtin:test", ConfigId: "c"},
+			Type:        config.SettingsType{SchemaId: "builtin:test"},
+			Environment: env,
+			Parameters:  config.Parameters{config.ScopeParameter: &value.ValueParameter{Value: "environment"}},
+		}}}
+	}
+
+	// givenClients returns clients for all environments that record to which environments configs were deployed
+	givenClients := func(t *testing.T, failingEnv string) (deploy.EnvironmentClients, func() []string) {
+		var lock sync.Mutex
+		var deployed []string
+
+		clients := make(deploy.EnvironmentClients)
+		for env, group := range envs {
+			env := env
+			c := dtclient.NewMockClient(gomock.NewController(t))
+			c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ any, _ dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				deployed = append(deployed, env)
+				if env == failingEnv {
+					return dtclient.DynatraceEntity{}, errors.New("failed")
+				}
+				return dtclient.DynatraceEntity{Id: "id"}, nil
+			})
+			clients[deploy.EnvironmentInfo{Name: env, Group: group}] = deploy.ClientSet{Settings: c}
+		}
+		return clients, func() []string { return deployed }
+	}
+
+	var waited []time.Duration
+	originalSleep := sleep
+	sleep = func(_ context.Context, d time.Duration) { waited = append(waited, d) }
+	t.Cleanup(func() { sleep = originalSleep })
+
+	t.Run("stages are deployed in order", func(t *testing.T) {
+		waited = nil
+		clients, deployed := givenClients(t, "")
+		out := bytes.Buffer{}
+
+		err := deployStages(context.TODO(), strings.NewReader("yes\n"), &out, stages, projects, clients, deploy.DeployConfigsOptions{}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"dev-env", "staging-env", "prod-env"}, deployed())
+		assert.Equal(t, []time.Duration{time.Minute}, waited)
+		assert.Contains(t, out.String(), `Deploy rollout stage "third" to environments [prod-env]?`)
+	})
+
+	t.Run("later stages are not deployed if a stage fails", func(t *testing.T) {
+		clients, deployed := givenClients(t, "dev-env")
+
+		err := deployStages(context.TODO(), strings.NewReader("yes\n"), &bytes.Buffer{}, stages, projects, clients, deploy.DeployConfigsOptions{ContinueOnErr: true}, false)
+		assert.ErrorContains(t, err, `rollout stage "first" failed`)
+		assert.Equal(t, []string{"dev-env"}, deployed())
+	})
+
+	t.Run("rollout is aborted if a stage is not confirmed", func(t *testing.T) {
+		clients, deployed := givenClients(t, "")
+
+		err := deployStages(context.TODO(), strings.NewReader("no\n"), &bytes.Buffer{}, stages, projects, clients, deploy.DeployConfigsOptions{}, false)
+		assert.ErrorContains(t, err, `rollout was aborted before stage "third"`)
+		assert.Equal(t, []string{"dev-env", "staging-env"}, deployed())
+	})
+
+	t.Run("auto-approve skips confirmation", func(t *testing.T) {
+		clients, deployed := givenClients(t, "")
+		out := bytes.Buffer{}
+
+		err := deployStages(context.TODO(), strings.NewReader(""), &out, stages, projects, clients, deploy.DeployConfigsOptions{}, true)
+		assert.NoError(t, err)
+		assert.Len(t, deployed(), 3)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("stages without deployed environments are skipped", func(t *testing.T) {
+		waited = nil
+		clients, deployed := givenClients(t, "")
+		for env := range clients {
+			if env.Group != "staging" {
+				delete(clients, env)
+			}
+		}
+
+		err := deployStages(context.TODO(), strings.NewReader(""), &bytes.Buffer{}, stages, projects, clients, deploy.DeployConfigsOptions{}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"staging-env"}, deployed())
+		assert.Empty(t, waited, "the first deployed stage must not wait")
+	})
+}
@@ -0,0 +1,141 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"path"
+	"strings"
+)
+
+// configSelection restricts a deployment to single configs. Configs are selected if they match any of the config
+// patterns or types. All configs the selected configs depend on are deployed as well.
+type configSelection struct {
+	// configs are patterns of coordinates in the form 'project:type:id'. Each part may be a glob, see path.Match.
+	configs []string
+	// types are config types to select. They may be globs, see path.Match.
+	types []string
+	// withDependents states that all configs depending on selected configs are deployed as well
+	withDependents bool
+}
+
+func (s configSelection) isEmpty() bool {
+	return len(s.configs) == 0 && len(s.types) == 0
+}
+
+// coordinatePattern matches coordinates whose parts match the respective glob pattern
+type coordinatePattern struct {
+	raw      string
+	project  string
+	typ      string
+	configID string
+}
+
+func (p coordinatePattern) matches(c coordinate.Coordinate) bool {
+	return match(p.project, c.Project) && match(p.typ, c.Type) && match(p.configID, c.ConfigId)
+}
+
+func match(pattern, s string) bool {
+	matched, _ := path.Match(pattern, s) // patterns are validated when parsing
+	return matched
+}
+
+// parsePatterns parses the coordinate patterns of the selection. As config types may contain colons (e.g. Settings
+// schemas), the project is the part before the first and the config ID the part after the last colon.
+func (s configSelection) parsePatterns() ([]coordinatePattern, error) {
+	patterns := make([]coordinatePattern, 0, len(s.configs)+len(s.types))
+
+	for _, c := range s.configs {
+		first, last := strings.Index(c, ":"), strings.LastIndex(c, ":")
+		if first == last {
+			return nil, fmt.Errorf("invalid config %q: expected the form 'project:type:id'", c)
+		}
+		patterns = append(patterns, coordinatePattern{raw: c, project: c[:first], typ: c[first+1 : last], configID: c[last+1:]})
+	}
+
+	for _, t := range s.types {
+		patterns = append(patterns, coordinatePattern{raw: t, project: "*", typ: t, configID: "*"})
+	}
+
+	for _, p := range patterns {
+		for _, part := range []string{p.project, p.typ, p.configID} {
+			if part == "" {
+				return nil, fmt.Errorf("invalid selection %q: parts must not be empty", p.raw)
+			}
+			if _, err := path.Match(part, ""); err != nil {
+				return nil, fmt.Errorf("invalid selection %q: %w", p.raw, err)
+			}
+		}
+	}
+	return patterns, nil
+}
+
+// selectConfigs returns the projects with only the configs of the selection, and all configs they depend on.
+// It fails if a pattern of the selection does not match any config.
+func selectConfigs(projects []project.Project, environments []string, selection configSelection) ([]project.Project, error) {
+	patterns, err := selection.parsePatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]bool, len(patterns))
+	isSelected := func(c coordinate.Coordinate) bool {
+		selected := false
+		for i, p := range patterns {
+			if p.matches(c) {
+				matched[i] = true
+				selected = true
+			}
+		}
+		return selected
+	}
+
+	graphs := graph.New(projects, environments)
+	selectedPerEnv := make(map[string]map[coordinate.Coordinate]struct{}, len(environments))
+	for _, env := range environments {
+		selectedPerEnv[env] = graphs.SelectConfigs(env, isSelected, selection.withDependents)
+		log.Info("Selected %d configurations to deploy to environment %q", len(selectedPerEnv[env]), env)
+	}
+
+	for i, p := range patterns {
+		if !matched[i] {
+			return nil, fmt.Errorf("no configuration matches %q", p.raw)
+		}
+	}
+
+	result := make([]project.Project, 0, len(projects))
+	for _, p := range projects {
+		configs := make(project.ConfigsPerTypePerEnvironments)
+		for env, configsPerType := range p.Configs {
+			configs[env] = make(project.ConfigsPerType)
+			for t, cfgs := range configsPerType {
+				for _, c := range cfgs {
+					if _, found := selectedPerEnv[env][c.Coordinate]; found {
+						configs[env][t] = append(configs[env][t], c)
+					}
+				}
+			}
+		}
+		p.Configs = configs
+		result = append(result, p)
+	}
+	return result, nil
+}
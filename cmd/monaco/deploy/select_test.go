@@ -0,0 +1,135 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_selectConfigs(t *testing.T) {
+	profile := coordinate.Coordinate{Project: "p1", Type: "alerting-profile", ConfigId: "profile"}
+	setting := coordinate.Coordinate{Project: "p1", Type: "builtin:alerting.profile", ConfigId: "setting"}
+	dashboard := coordinate.Coordinate{Project: "p2", Type: "dashboard", ConfigId: "dashboard"}
+	otherDashboard := coordinate.Coordinate{Project: "p2", Type: "dashboard", ConfigId: "other"}
+	zone := coordinate.Coordinate{Project: "p2", Type: "management-zone", ConfigId: "zone"}
+
+	newConfig := func(c coordinate.Coordinate, references ...coordinate.Coordinate) config.Config {
+		params := config.Parameters{}
+		for _, r := range references {
+			params[r.ConfigId] = &parameter.DummyParameter{References: []parameter.ParameterReference{{Config: r, Property: "id"}}}
+		}
+		return config.Config{Coordinate: c, Environment: "env", Parameters: params}
+	}
+
+	// the dashboard references the profile, which references the setting, and the zone
+	projects := []project.Project{
+		{
+			Id: "p1",
+			Configs: project.ConfigsPerTypePerEnvironments{"env": {
+				"alerting-profile":         {newConfig(profile, setting)},
+				"builtin:alerting.profile": {newConfig(setting)},
+			}},
+		},
+		{
+			Id: "p2",
+			Configs: project.ConfigsPerTypePerEnvironments{"env": {
+				"dashboard":       {newConfig(dashboard, profile, zone), newConfig(otherDashboard)},
+				"management-zone": {newConfig(zone)},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		selection configSelection
+		want      []coordinate.Coordinate
+		wantErr   string
+	}{
+		{
+			name:      "config with dependencies",
+			selection: configSelection{configs: []string{"p1:alerting-profile:profile"}},
+			want:      []coordinate.Coordinate{profile, setting},
+		},
+		{
+			name:      "config with dependents and their dependencies",
+			selection: configSelection{configs: []string{"p1:alerting-profile:profile"}, withDependents: true},
+			want:      []coordinate.Coordinate{profile, setting, dashboard, zone},
+		},
+		{
+			name:      "glob",
+			selection: configSelection{configs: []string{"p2:dash*:o*"}},
+			want:      []coordinate.Coordinate{otherDashboard},
+		},
+		{
+			name:      "type containing colons",
+			selection: configSelection{configs: []string{"*:builtin:alerting.*:*"}},
+			want:      []coordinate.Coordinate{setting},
+		},
+		{
+			name:      "type",
+			selection: configSelection{types: []string{"dashboard"}},
+			want:      []coordinate.Coordinate{profile, setting, dashboard, otherDashboard, zone},
+		},
+		{
+			name:      "no match",
+			selection: configSelection{configs: []string{"p1:dashboard:*"}, types: []string{"alerting-profile"}},
+			wantErr:   `no configuration matches "p1:dashboard:*"`,
+		},
+		{
+			name:      "invalid coordinate",
+			selection: configSelection{configs: []string{"p1:profile"}},
+			wantErr:   "expected the form 'project:type:id'",
+		},
+		{
+			name:      "invalid glob",
+			selection: configSelection{types: []string{"[dashboard"}},
+			wantErr:   "syntax error in pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectConfigs(projects, []string{"env"}, tt.selection)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			var coordinates []coordinate.Coordinate
+			for _, p := range got {
+				for _, cfgs := range p.Configs["env"] {
+					for _, c := range cfgs {
+						coordinates = append(coordinates, c.Coordinate)
+					}
+				}
+			}
+			slices.SortFunc(coordinates, func(a, b coordinate.Coordinate) int { return strings.Compare(a.String(), b.String()) })
+			slices.SortFunc(tt.want, func(a, b coordinate.Coordinate) int { return strings.Compare(a.String(), b.String()) })
+			assert.Equal(t, tt.want, coordinates)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVerifySnapshotDir(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, fs.MkdirAll("empty", 0777))
+	assert.NoError(t, afero.WriteFile(fs, "used/env.json", []byte("{}"), 0644))
+
+	assert.NoError(t, verifySnapshotDir(fs, "new"))
+	assert.NoError(t, verifySnapshotDir(fs, "empty"))
+	assert.ErrorContains(t, verifySnapshotDir(fs, "used"), "is not empty")
+}
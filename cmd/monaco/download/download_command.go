@@ -17,6 +17,7 @@ package download
 import (
 	"context"
 	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
@@ -28,10 +29,12 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"net/http"
+	"time"
 )
 
 func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
 	var f downloadCmdOptions
+	var timeout time.Duration
 
 	cmd = &cobra.Command{
 		Short: "Download configuration from Dynatrace",
@@ -52,11 +55,14 @@ func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
+			ctx, cancel := cmdutils.ContextWithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
 			if f.environmentURL != "" {
 				f.manifestFile = ""
-				return command.DownloadConfigs(fs, f)
+				return command.DownloadConfigs(ctx, fs, f)
 			}
-			return command.DownloadConfigsBasedOnManifest(fs, f)
+			return command.DownloadConfigsBasedOnManifest(ctx, fs, f)
 		},
 	}
 
@@ -80,6 +86,8 @@ func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&f.onlyAPIs, "only-apis", false, "Download only classic configuration APIs. Deprecated configuration APIs will not be included.")
 	cmd.Flags().BoolVar(&f.onlySettings, "only-settings", false, "Download only settings 2.0 objects")
 
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum duration of the download, e.g. '30m'. Once it passed, or the download is interrupted, no new requests are sent and nothing is written. By default, there is no timeout.")
+
 	// combinations
 	cmd.MarkFlagsMutuallyExclusive("settings-schema", "only-apis", "only-settings")
 	cmd.MarkFlagsMutuallyExclusive("api", "only-apis", "only-settings")
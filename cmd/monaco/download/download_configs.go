@@ -15,6 +15,7 @@
 package download
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
@@ -84,7 +85,7 @@ func readEnvVariable(envVar string) (manifest.AuthSecret, error) {
 	return manifest.AuthSecret{Name: envVar, Value: secret.MaskedString(content)}, nil
 }
 
-func (d DefaultCommand) DownloadConfigsBasedOnManifest(fs afero.Fs, cmdOptions downloadCmdOptions) error {
+func (d DefaultCommand) DownloadConfigsBasedOnManifest(ctx context.Context, fs afero.Fs, cmdOptions downloadCmdOptions) error {
 
 	m, errs := manifestloader.Load(&manifestloader.Context{
 		Fs:           fs,
@@ -137,10 +138,10 @@ func (d DefaultCommand) DownloadConfigsBasedOnManifest(fs afero.Fs, cmdOptions d
 	if err != nil {
 		return err
 	}
-	return doDownloadConfigs(fs, downloaders, options)
+	return doDownloadConfigs(ctx, fs, downloaders, options)
 }
 
-func (d DefaultCommand) DownloadConfigs(fs afero.Fs, cmdOptions downloadCmdOptions) error {
+func (d DefaultCommand) DownloadConfigs(ctx context.Context, fs afero.Fs, cmdOptions downloadCmdOptions) error {
 	a, errs := cmdOptions.auth.mapToAuth()
 	errs = append(errs, validateParameters(cmdOptions.environmentURL, cmdOptions.projectName)...)
 
@@ -172,7 +173,7 @@ func (d DefaultCommand) DownloadConfigs(fs afero.Fs, cmdOptions downloadCmdOptio
 	if err != nil {
 		return err
 	}
-	return doDownloadConfigs(fs, downloaders, options)
+	return doDownloadConfigs(ctx, fs, downloaders, options)
 }
 
 type downloadConfigsOptions struct {
@@ -196,18 +197,23 @@ func (opts downloadConfigsOptions) valid() []error {
 	return retVal
 }
 
-func doDownloadConfigs(fs afero.Fs, downloaders downloaders, opts downloadConfigsOptions) error {
+func doDownloadConfigs(ctx context.Context, fs afero.Fs, downloaders downloaders, opts downloadConfigsOptions) error {
 	err := preDownloadValidations(fs, opts.downloadOptionsShared)
 	if err != nil {
 		return err
 	}
 
 	log.Info("Downloading from environment '%v' into project '%v'", opts.environmentURL, opts.projectName)
-	downloadedConfigs, err := downloadConfigs(downloaders, opts)
+	downloadedConfigs, err := downloadConfigs(ctx, downloaders, opts)
 	if err != nil {
 		return err
 	}
 
+	// a canceled download is incomplete, so nothing is written
+	if ctx.Err() != nil {
+		return fmt.Errorf("download was canceled - no configurations were written: %w", context.Cause(ctx))
+	}
+
 	if len(downloadedConfigs) == 0 {
 		log.Info("No configurations downloaded. No project will be created.")
 		return nil
@@ -229,11 +235,11 @@ func doDownloadConfigs(fs afero.Fs, downloaders downloaders, opts downloadConfig
 	return writeConfigs(downloadedConfigs, opts.downloadOptionsShared, fs)
 }
 
-func downloadConfigs(downloaders downloaders, opts downloadConfigsOptions) (project.ConfigsPerType, error) {
+func downloadConfigs(ctx context.Context, downloaders downloaders, opts downloadConfigsOptions) (project.ConfigsPerType, error) {
 	configs := make(project.ConfigsPerType)
 
 	if shouldDownloadConfigs(opts) {
-		classicCfgs, err := downloaders.Classic().Download(opts.projectName)
+		classicCfgs, err := downloaders.Classic().Download(ctx, opts.projectName)
 		if err != nil {
 			return nil, err
 		}
@@ -244,7 +250,7 @@ func downloadConfigs(downloaders downloaders, opts downloadConfigsOptions) (proj
 		log.Info("Downloading settings objects")
 
 		settingTypes := makeSettingTypes(opts.specificSchemas)
-		settingCfgs, err := downloaders.Settings().Download(opts.projectName, settingTypes...)
+		settingCfgs, err := downloaders.Settings().Download(ctx, opts.projectName, settingTypes...)
 		if err != nil {
 			return nil, err
 		}
@@ -255,7 +261,7 @@ func downloadConfigs(downloaders downloaders, opts downloadConfigsOptions) (proj
 		if opts.auth.OAuth != nil {
 			log.Info("Downloading automation resources")
 
-			automationCfgs, err := downloaders.Automation().Download(opts.projectName)
+			automationCfgs, err := downloaders.Automation().Download(ctx, opts.projectName)
 			if err != nil {
 				return nil, err
 			}
@@ -268,7 +274,7 @@ func downloadConfigs(downloaders downloaders, opts downloadConfigsOptions) (proj
 	if shouldDownloadBuckets(opts) && opts.auth.OAuth != nil {
 		log.Info("Downloading Grail buckets")
 
-		bucketCfgs, err := downloaders.Bucket().Download(opts.projectName)
+		bucketCfgs, err := downloaders.Bucket().Download(ctx, opts.projectName)
 		if err != nil {
 			return nil, err
 		}
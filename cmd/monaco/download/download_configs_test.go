@@ -19,6 +19,7 @@
 package download
 
 import (
+	"context"
 	"errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
@@ -52,8 +53,8 @@ func TestDownloadConfigsBehaviour(t *testing.T) {
 			},
 			expectedBehaviour: func(c *dtclient.MockClient) {
 				c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).AnyTimes().Return([]dtclient.Value{}, nil)
-				c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).AnyTimes().Return([]byte("{}"), nil) // singleton configs are always attempted
-				c.EXPECT().ListSchemas().Return(dtclient.SchemaList{}, nil)
+				c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return([]byte("{}"), nil) // singleton configs are always attempted
+				c.EXPECT().ListSchemas(gomock.Any()).Return(dtclient.SchemaList{}, nil)
 				c.EXPECT().ListSettings(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return([]dtclient.DownloadSettingsObject{}, nil)
 			},
 		},
@@ -67,8 +68,8 @@ func TestDownloadConfigsBehaviour(t *testing.T) {
 			},
 			expectedBehaviour: func(c *dtclient.MockClient) {
 				c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).Times(0)
-				c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Times(0)
-				c.EXPECT().ListSchemas().AnyTimes().Return(dtclient.SchemaList{{SchemaId: "builtin:magic.secret"}}, nil)
+				c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				c.EXPECT().ListSchemas(gomock.Any()).AnyTimes().Return(dtclient.SchemaList{{SchemaId: "builtin:magic.secret"}}, nil)
 				c.EXPECT().ListSettings(gomock.Any(), "builtin:magic.secret", gomock.Any()).AnyTimes().Return([]dtclient.DownloadSettingsObject{}, nil)
 			},
 		},
@@ -82,8 +83,8 @@ func TestDownloadConfigsBehaviour(t *testing.T) {
 			},
 			expectedBehaviour: func(c *dtclient.MockClient) {
 				c.EXPECT().ListConfigs(gomock.Any(), api.NewAPIs()["alerting-profile"]).Return([]dtclient.Value{{Id: "42", Name: "profile"}}, nil)
-				c.EXPECT().ReadConfigById(gomock.Any(), "42").AnyTimes().Return([]byte("{}"), nil)
-				c.EXPECT().ListSchemas().Times(0)
+				c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), "42").AnyTimes().Return([]byte("{}"), nil)
+				c.EXPECT().ListSchemas(gomock.Any()).Times(0)
 				c.EXPECT().ListSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 		},
@@ -97,8 +98,8 @@ func TestDownloadConfigsBehaviour(t *testing.T) {
 			},
 			expectedBehaviour: func(c *dtclient.MockClient) {
 				c.EXPECT().ListConfigs(gomock.Any(), api.NewAPIs()["alerting-profile"]).Return([]dtclient.Value{{Id: "42", Name: "profile"}}, nil)
-				c.EXPECT().ReadConfigById(gomock.Any(), "42").AnyTimes().Return([]byte("{}"), nil)
-				c.EXPECT().ListSchemas().AnyTimes().Return(dtclient.SchemaList{{SchemaId: "builtin:magic.secret"}}, nil)
+				c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), "42").AnyTimes().Return([]byte("{}"), nil)
+				c.EXPECT().ListSchemas(gomock.Any()).AnyTimes().Return(dtclient.SchemaList{{SchemaId: "builtin:magic.secret"}}, nil)
 				c.EXPECT().ListSettings(gomock.Any(), "builtin:magic.secret", gomock.Any()).AnyTimes().Return([]dtclient.DownloadSettingsObject{}, nil)
 
 			},
@@ -113,8 +114,8 @@ func TestDownloadConfigsBehaviour(t *testing.T) {
 			},
 			expectedBehaviour: func(c *dtclient.MockClient) {
 				c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).AnyTimes().Return([]dtclient.Value{}, nil)
-				c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).AnyTimes().Return([]byte("{}"), nil) // singleton configs are always attempted
-				c.EXPECT().ListSchemas().Times(0)
+				c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return([]byte("{}"), nil) // singleton configs are always attempted
+				c.EXPECT().ListSchemas(gomock.Any()).Times(0)
 				c.EXPECT().ListSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 		},
@@ -128,8 +129,8 @@ func TestDownloadConfigsBehaviour(t *testing.T) {
 			},
 			expectedBehaviour: func(c *dtclient.MockClient) {
 				c.EXPECT().ListConfigs(gomock.Any(), gomock.Any()).Times(0)
-				c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Times(0)
-				c.EXPECT().ListSchemas().Return(dtclient.SchemaList{}, nil)
+				c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				c.EXPECT().ListSchemas(gomock.Any()).Return(dtclient.SchemaList{}, nil)
 				c.EXPECT().ListSettings(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return([]dtclient.DownloadSettingsObject{}, nil)
 			},
 		},
@@ -155,7 +156,7 @@ func TestDownloadConfigsBehaviour(t *testing.T) {
 
 			downloaders := downloaders{settings.NewDownloader(c), classicDownloader(c, tt.givenOpts)}
 
-			_, err := downloadConfigs(downloaders, tt.givenOpts)
+			_, err := downloadConfigs(context.TODO(), downloaders, tt.givenOpts)
 			assert.NoError(t, err)
 		})
 	}
@@ -168,7 +169,7 @@ type automationAssertDownloader struct {
 
 var _ download.Downloader[config.AutomationType] = (*automationAssertDownloader)(nil)
 
-func (a *automationAssertDownloader) Download(_ string, _ ...config.AutomationType) (projectv2.ConfigsPerType, error) {
+func (a *automationAssertDownloader) Download(_ context.Context, _ string, _ ...config.AutomationType) (projectv2.ConfigsPerType, error) {
 	if !a.wantCall {
 		a.t.Fatalf("automation downloader was not meant to be called but was")
 	}
@@ -182,7 +183,7 @@ type bucketAssertDownloader struct {
 
 var _ download.Downloader[config.BucketType] = (*bucketAssertDownloader)(nil)
 
-func (a *bucketAssertDownloader) Download(_ string, _ ...config.BucketType) (projectv2.ConfigsPerType, error) {
+func (a *bucketAssertDownloader) Download(_ context.Context, _ string, _ ...config.BucketType) (projectv2.ConfigsPerType, error) {
 	if !a.wantCall {
 		a.t.Fatalf("automation downloader was not meant to be called but was")
 	}
@@ -196,7 +197,7 @@ type settingAssertDownloader struct {
 
 var _ download.Downloader[config.SettingsType] = (*settingAssertDownloader)(nil)
 
-func (a *settingAssertDownloader) Download(_ string, _ ...config.SettingsType) (projectv2.ConfigsPerType, error) {
+func (a *settingAssertDownloader) Download(_ context.Context, _ string, _ ...config.SettingsType) (projectv2.ConfigsPerType, error) {
 	if !a.wantCall {
 		a.t.Fatalf("settings downloader was not meant to be called but was")
 	}
@@ -210,7 +211,7 @@ type configAssertDownloader struct {
 
 var _ download.Downloader[config.ClassicApiType] = (*configAssertDownloader)(nil)
 
-func (a *configAssertDownloader) Download(_ string, _ ...config.ClassicApiType) (projectv2.ConfigsPerType, error) {
+func (a *configAssertDownloader) Download(_ context.Context, _ string, _ ...config.ClassicApiType) (projectv2.ConfigsPerType, error) {
 	if !a.wantCall {
 		a.t.Fatalf("config API downloader was not meant to be called but was")
 	}
@@ -285,7 +286,7 @@ func TestDownload_Options(t *testing.T) {
 				&bucketAssertDownloader{t, tt.want.bucket},
 			}
 
-			_, err := downloadConfigs(downloaders, tt.given)
+			_, err := downloadConfigs(context.TODO(), downloaders, tt.given)
 			assert.NoError(t, err)
 		})
 	}
@@ -391,10 +392,10 @@ func TestDownloadConfigsExitsEarlyForUnknownSettingsSchema(t *testing.T) {
 		},
 	}
 
-	c.EXPECT().ListSchemas().Return(dtclient.SchemaList{{"builtin:some.schema"}}, nil)
+	c.EXPECT().ListSchemas(gomock.Any()).Return(dtclient.SchemaList{{"builtin:some.schema"}}, nil)
 
 	downloaders := downloaders{settings.NewDownloader(c), classic.NewDownloader(c, classic.WithAPIs(nil))}
-	err := doDownloadConfigs(afero.NewMemMapFs(), downloaders, givenOpts)
+	err := doDownloadConfigs(context.TODO(), afero.NewMemMapFs(), downloaders, givenOpts)
 	assert.ErrorContains(t, err, "not known", "expected download to fail for unkown Settings Schema")
 	c.EXPECT().ListSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0) // no downloads should even be attempted for unknown schema
 }
@@ -462,7 +463,7 @@ func TestDownloadConfigs_OnlyAutomationWithoutAutomationCredentials(t *testing.T
 
 	downloaders := downloaders{automation.NoopAutomationDownloader{}, classic.NewDownloader(nil, classic.WithAPIs(nil))}
 
-	err := doDownloadConfigs(testutils.CreateTestFileSystem(), downloaders, opts)
+	err := doDownloadConfigs(context.TODO(), testutils.CreateTestFileSystem(), downloaders, opts)
 	assert.ErrorContains(t, err, "no OAuth credentials configured")
 }
 
@@ -17,6 +17,7 @@
 package download
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
@@ -89,7 +90,7 @@ func TestDownloadIntegrationSimple(t *testing.T) {
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apiMap))}
 
 	// WHEN we download everything
-	err := doDownloadConfigs(fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
 
 	assert.NilError(t, err)
 
@@ -158,7 +159,7 @@ func TestDownloadIntegrationWithReference(t *testing.T) {
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apiMap))}
 
 	// WHEN we download everything
-	err := doDownloadConfigs(fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
 
 	assert.NilError(t, err)
 
@@ -246,7 +247,7 @@ func TestDownloadIntegrationWithMultipleApisAndReferences(t *testing.T) {
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apiMap))}
 
 	// WHEN we download everything
-	err := doDownloadConfigs(fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
 
 	assert.NilError(t, err)
 
@@ -362,7 +363,7 @@ func TestDownloadIntegrationSingletonConfig(t *testing.T) {
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apiMap))}
 
 	// WHEN we download everything
-	err := doDownloadConfigs(fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
 
 	assert.NilError(t, err)
 
@@ -428,7 +429,7 @@ func TestDownloadIntegrationSyntheticLocations(t *testing.T) {
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apiMap), classic.WithFiltering(shouldApplyFilter()))}
 
 	// WHEN we download everything
-	err := doDownloadConfigs(fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
 
 	assert.NilError(t, err)
 
@@ -496,7 +497,7 @@ func TestDownloadIntegrationDashboards(t *testing.T) {
 
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apiMap), classic.WithFiltering(shouldApplyFilter()))}
 	// WHEN we download everything
-	err := doDownloadConfigs(fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
 
 	assert.NilError(t, err)
 
@@ -593,7 +594,7 @@ func TestDownloadIntegrationAllDashboardsAreDownloadedIfFilterFFTurnedOff(t *tes
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apiMap), classic.WithFiltering(shouldApplyFilter()))}
 
 	// WHEN we download everything
-	err := doDownloadConfigs(fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
 
 	assert.NilError(t, err)
 
@@ -708,7 +709,7 @@ func TestDownloadIntegrationAnomalyDetectionMetrics(t *testing.T) {
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apiMap), classic.WithFiltering(shouldApplyFilter()))}
 
 	// WHEN we download everything
-	err := doDownloadConfigs(fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, setupTestingDownloadOptions(t, server, projectName))
 
 	assert.NilError(t, err)
 
@@ -847,7 +848,7 @@ func TestDownloadIntegrationHostAutoUpdate(t *testing.T) {
 			downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apiMap), classic.WithFiltering(shouldApplyFilter()))}
 
 			// WHEN we download everything
-			err := doDownloadConfigs(fs, downloaders, setupTestingDownloadOptions(t, server, testcase.projectName))
+			err := doDownloadConfigs(context.TODO(), fs, downloaders, setupTestingDownloadOptions(t, server, testcase.projectName))
 
 			assert.NilError(t, err)
 
@@ -917,7 +918,7 @@ func TestDownloadIntegrationOverwritesFolderAndManifestIfForced(t *testing.T) {
 	dtClient, _ := dtclient.NewDynatraceClientForTesting(server.URL, server.Client())
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apis))}
 
-	err := doDownloadConfigs(fs, downloaders, options)
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, options)
 
 	assert.NilError(t, err)
 
@@ -1008,7 +1009,7 @@ func TestDownloadIntegrationDownloadsAPIsAndSettings(t *testing.T) {
 
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apis))}
 
-	err := doDownloadConfigs(fs, downloaders, opts)
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, opts)
 
 	assert.NilError(t, err)
 
@@ -1070,7 +1071,7 @@ func TestDownloadIntegrationDownloadsOnlyAPIsIfConfigured(t *testing.T) {
 
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(apis))}
 
-	err := doDownloadConfigs(fs, downloaders, opts)
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, opts)
 
 	assert.NilError(t, err)
 
@@ -1123,7 +1124,7 @@ func TestDownloadIntegrationDoesNotDownloadUnmodifiableSettings(t *testing.T) {
 
 	downloaders := downloaders{settings.NewDownloader(dtClient), classic.NewDownloader(dtClient, classic.WithAPIs(nil))}
 
-	err := doDownloadConfigs(fs, downloaders, opts)
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, opts)
 
 	assert.NilError(t, err)
 
@@ -1182,7 +1183,7 @@ func TestDownloadIntegrationDownloadsUnmodifiableSettingsIfFFTurnedOff(t *testin
 	// GIVEN filter feature flag is turned OFF
 	t.Setenv(featureflags.DownloadFilterSettingsUnmodifiable().EnvName(), "false")
 
-	err := doDownloadConfigs(fs, downloaders, opts)
+	err := doDownloadConfigs(context.TODO(), fs, downloaders, opts)
 
 	assert.NilError(t, err)
 
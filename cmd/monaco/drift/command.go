@@ -0,0 +1,96 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+func GetDriftCommand(fs afero.Fs) (driftCmd *cobra.Command) {
+	var manifestName, format string
+	var environment, project, groups []string
+	var timeout time.Duration
+
+	driftCmd = &cobra.Command{
+		Use:               "drift <manifest.yaml>",
+		Short:             "Detect differences between configurations and the objects on Dynatrace environments",
+		Long:              "Detect differences between configurations and the objects on Dynatrace environments, e.g. because objects were changed manually. Exits with a non-zero exit code if any object drifted.",
+		Example:           "monaco drift manifest.yaml -e dev-environment --format json",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.DeployCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			manifestName = args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				err := fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+				return err
+			}
+
+			if format != driftFormatText && format != driftFormatJSON {
+				return fmt.Errorf("unknown format %q - supported formats are %q and %q", format, driftFormatText, driftFormatJSON)
+			}
+
+			ctx, cancel := cmdutils.ContextWithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			return detectDrift(ctx, fs, cmd.OutOrStdout(), format, manifestName, groups, environment, project)
+		},
+	}
+
+	driftCmd.Flags().StringSliceVarP(&environment, "environment", "e", []string{},
+		"Specify one (or multiple) environment(s) to check. "+
+			"To set multiple environments either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--group'.")
+	driftCmd.Flags().StringSliceVarP(&groups, "group", "g", []string{},
+		"Specify one (or multiple) environmentGroup(s) to check. "+
+			"To set multiple groups either repeat this flag, or separate them using a comma (,). "+
+			"If this flag is specified, all environments within this group will be checked. "+
+			"This flag is mutually exclusive with '--environment'")
+	driftCmd.Flags().StringSliceVarP(&project, "project", "p", make([]string, 0), "Project configuration to check (also checks any dependent configurations)")
+	driftCmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum duration of the drift detection, e.g. '30m'. Once it passed, or the drift detection is interrupted, no new requests are sent. By default, there is no timeout.")
+	driftCmd.Flags().StringVar(&format, "format", driftFormatText, fmt.Sprintf("Format of the drift report, either %q or %q", driftFormatText, driftFormatJSON))
+
+	err := driftCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag)
+	if err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	err = driftCmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest)
+	if err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	err = driftCmd.RegisterFlagCompletionFunc("format", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{driftFormatText, driftFormatJSON}, cobra.ShellCompDirectiveDefault
+	})
+	if err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	driftCmd.MarkFlagsMutuallyExclusive("environment", "group")
+
+	return driftCmd
+}
@@ -0,0 +1,152 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/internal/clientset"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download"
+	dlautomation "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/settings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/drift"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/spf13/afero"
+	"io"
+	"path/filepath"
+)
+
+const (
+	driftFormatText = "text"
+	driftFormatJSON = "json"
+)
+
+// errDrift is returned if drift was detected, so that the command exits with a non-zero exit code
+var errDrift = errors.New("drift detected - objects on the environments differ from their configurations")
+
+// detectDrift compares the configurations of the given projects with the objects on the environments and writes the
+// report to out in the given format. If any object drifted, errDrift is returned.
+func detectDrift(ctx context.Context, fs afero.Fs, out io.Writer, format string, manifestPath string, environmentGroups []string, specificEnvironments []string, specificProjects []string) error {
+	absManifestPath, err := filepath.Abs(filepath.Clean(manifestPath))
+	if err != nil {
+		return fmt.Errorf("error while finding absolute path for `%s`: %w", manifestPath, err)
+	}
+
+	m, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: absManifestPath,
+		Groups:       environmentGroups,
+		Environments: specificEnvironments,
+		Opts:         manifestloader.Options{RequireEnvironmentGroups: true},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return errors.New("error while loading manifest")
+	}
+
+	if !dynatrace.VerifyEnvironmentGeneration(m.Environments) {
+		return errors.New("unable to verify Dynatrace environment generation")
+	}
+
+	projects, errs := project.LoadProjects(fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(absManifestPath),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return errors.New("failed to load projects")
+	}
+
+	projects, err = cmdutils.FilterProjects(projects, specificProjects, m.Environments.Names())
+	if err != nil {
+		return err
+	}
+
+	if err := cmdutils.CheckEnvironments(projects, m.Environments); err != nil {
+		return err
+	}
+
+	environments, err := newEnvironments(m.Environments)
+	if err != nil {
+		return fmt.Errorf("failed to create API clients: %w", err)
+	}
+
+	report, err := drift.Detect(ctx, projects, environments)
+	if report != nil {
+		if writeErr := writeDriftReport(out, format, report); writeErr != nil {
+			return fmt.Errorf("failed to write drift report: %w", writeErr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("drift detection failed - check logs for details: %w", err)
+	}
+
+	if report.HasDrift() {
+		return errDrift
+	}
+
+	log.Info("No drift detected")
+	return nil
+}
+
+// newEnvironments creates the clients and downloaders to detect drift on each of the given environments
+func newEnvironments(environments manifest.Environments) (drift.Environments, error) {
+	result := make(drift.Environments, len(environments))
+	for _, env := range environments {
+		cl, err := dynatrace.CreateClientSet(env.URL.Value, env.Auth)
+		if err != nil {
+			return nil, err
+		}
+
+		var automationDownloader download.Downloader[config.AutomationType] = dlautomation.NoopAutomationDownloader{}
+		if cl.Automation() != nil {
+			automationDownloader = dlautomation.NewDownloader(cl.Automation())
+		}
+
+		result[deploy.EnvironmentInfo{Name: env.Name, Group: env.Group}] = drift.Environment{
+			Clients: clientset.FromClientSet(cl),
+			Downloaders: drift.Downloaders{
+				Classic:    classic.NewDownloader(cl.Classic(), classic.WithFiltering(false)),
+				Settings:   settings.NewDownloader(cl.Settings()),
+				Automation: automationDownloader,
+				Bucket:     bucket.NewDownloader(cl.Bucket()),
+			},
+		}
+	}
+	return result, nil
+}
+
+func writeDriftReport(out io.Writer, format string, report drift.Report) error {
+	if format == driftFormatJSON {
+		return drift.WriteJSON(out, report)
+	}
+	return drift.Print(out, report)
+}
@@ -149,7 +149,8 @@ func TestDelete(t *testing.T) {
 
 			// DELETE Config
 			cmd = runner.BuildCli(fs)
-			baseCmd := []string{"delete", "--verbose"}
+			// the manifest is copied without its projects, so they can not be loaded to protect configs
+			baseCmd := []string{"delete", "--verbose", "--skip-protection"}
 			cmd.SetArgs(append(baseCmd, tt.cmdFlags...))
 			err = cmd.Execute()
 			assert.NoError(t1, err)
@@ -250,7 +251,7 @@ environmentGroups:
 
 	// DELETE Configs - with API Token only Manifest
 	cmd = runner.BuildCli(fs)
-	cmd.SetArgs([]string{"delete", "--verbose"})
+	cmd.SetArgs([]string{"delete", "--verbose", "--skip-protection"})
 	err = cmd.Execute()
 	assert.NoError(t, err)
 
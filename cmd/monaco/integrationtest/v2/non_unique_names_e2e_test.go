@@ -59,7 +59,7 @@ func TestNonUniqueNameUpserts(t *testing.T) {
 
 	t.Cleanup(func() {
 		for _, id := range []string{firstExistingObjectUUID, secondExistingObjectUUID, monacoGeneratedUUID} {
-			if err := c.DeleteConfigById(a, id); err != nil {
+			if err := c.DeleteConfigById(context.TODO(), a, id); err != nil {
 				t.Log("failed to cleanup test config with ID: ", id)
 			}
 		}
@@ -132,7 +132,7 @@ func TestNonUniqueNameUpserts_InactiveUpdateByName(t *testing.T) {
 
 	t.Cleanup(func() {
 		for _, id := range []string{firstExistingObjectUUID, secondExistingObjectUUID, monacoGeneratedUUID, otherMonacoGeneratedUUID} {
-			if err := c.DeleteConfigById(a, id); err != nil {
+			if err := c.DeleteConfigById(context.TODO(), a, id); err != nil {
 				t.Log("failed to cleanup test config with ID: ", id)
 			}
 		}
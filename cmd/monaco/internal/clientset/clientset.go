@@ -18,6 +18,7 @@ package clientset
 
 import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
@@ -49,10 +50,17 @@ func NewClientSet(env manifest.EnvironmentDefinition, dryRun bool) (deploy.Clien
 		return deploy.ClientSet{}, err
 	}
 
+	return FromClientSet(cl), nil
+}
+
+// FromClientSet returns the deploy.ClientSet using the clients of the given client.ClientSet
+func FromClientSet(cl *client.ClientSet) deploy.ClientSet {
 	return deploy.ClientSet{
 		Classic:    cl.Classic(),
 		Settings:   cl.Settings(),
 		Automation: cl.Automation(),
 		Bucket:     cl.Bucket(),
-	}, nil
+		Events:     cl.Events(),
+		Entities:   cl.Entities(),
+	}
 }
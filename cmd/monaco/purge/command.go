@@ -31,6 +31,7 @@ func GetPurgeCommand(fs afero.Fs) (purgeCmd *cobra.Command) {
 	var environment []string
 	var manifestName string
 	var specificApis []string
+	var skipProtection bool
 
 	purgeCmd = &cobra.Command{
 		Use:     "purge <manifest.yaml>",
@@ -48,7 +49,7 @@ func GetPurgeCommand(fs afero.Fs) (purgeCmd *cobra.Command) {
 				return err
 			}
 
-			return purge(fs, manifestName, environment, specificApis)
+			return purge(fs, manifestName, environment, specificApis, skipProtection)
 		},
 		ValidArgsFunction: completion.PurgeCompletion,
 	}
@@ -56,6 +57,8 @@ func GetPurgeCommand(fs afero.Fs) (purgeCmd *cobra.Command) {
 	purgeCmd.Flags().StringSliceVarP(&environment, "environment", "e", make([]string, 0), "Deletes configuration only for specified environments. All environments are included if this property is not set. ")
 	purgeCmd.Flags().StringSliceVarP(&specificApis, "api", "a", make([]string, 0), "One or more specific APIs to delete from (flag can be repeated or value defined as comma-separated list)")
 
+	purgeCmd.Flags().BoolVar(&skipProtection, cmdutils.SkipProtectionFlag, false, "Delete configurations without loading the projects of the manifest to protect configurations marked with 'preventDestroy'. Use this flag if the projects can not be loaded.")
+
 	if err := purgeCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByArg0); err != nil {
 		log.Fatal("failed to setup CLI %v", err)
 	}
@@ -20,11 +20,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 	"github.com/spf13/afero"
@@ -32,7 +34,7 @@ import (
 	"path/filepath"
 )
 
-func purge(fs afero.Fs, deploymentManifestPath string, environmentNames []string, apiNames []string) error {
+func purge(fs afero.Fs, deploymentManifestPath string, environmentNames []string, apiNames []string, skipProtection bool) error {
 
 	deploymentManifestPath = filepath.Clean(deploymentManifestPath)
 	deploymentManifestPath, manifestErr := filepath.Abs(deploymentManifestPath)
@@ -55,13 +57,21 @@ func purge(fs afero.Fs, deploymentManifestPath string, environmentNames []string
 		return errors.New("error while loading manifest")
 	}
 
-	return purgeConfigs(maps.Values(mani.Environments), apis)
+	var protected map[string]pointer.Protected
+	if !skipProtection {
+		var err error
+		if protected, err = cmdutils.LoadProtectedConfigs(fs, deploymentManifestPath, mani); err != nil {
+			return err
+		}
+	}
+
+	return purgeConfigs(maps.Values(mani.Environments), apis, protected)
 }
 
-func purgeConfigs(environments []manifest.EnvironmentDefinition, apis api.APIs) error {
+func purgeConfigs(environments []manifest.EnvironmentDefinition, apis api.APIs, protected map[string]pointer.Protected) error {
 
 	for _, env := range environments {
-		err := purgeForEnvironment(env, apis)
+		err := purgeForEnvironment(env, apis, protected[env.Name])
 		if err != nil {
 			return err
 		}
@@ -70,7 +80,7 @@ func purgeConfigs(environments []manifest.EnvironmentDefinition, apis api.APIs)
 	return nil
 }
 
-func purgeForEnvironment(env manifest.EnvironmentDefinition, apis api.APIs) error {
+func purgeForEnvironment(env manifest.EnvironmentDefinition, apis api.APIs, protected pointer.Protected) error {
 
 	deleteClients, err := getClientSet(env)
 	if err != nil {
@@ -81,7 +91,7 @@ func purgeForEnvironment(env manifest.EnvironmentDefinition, apis api.APIs) erro
 
 	log.WithCtxFields(ctx).Info("Deleting configs for environment `%s`", env.Name)
 
-	if err := delete.All(ctx, deleteClients, apis); err != nil {
+	if err := delete.All(ctx, deleteClients, apis, protected); err != nil {
 		log.Error("Encountered errors while puring configurations from environment %s, further manual cleanup may be needed - check logs for details.", env.Name)
 	}
 	return nil
@@ -0,0 +1,77 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rollback
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func GetRollbackCommand(fs afero.Fs) (rollbackCmd *cobra.Command) {
+	var manifestName, stateFile string
+	var environment, groups []string
+
+	rollbackCmd = &cobra.Command{
+		Use:   "rollback <snapshot>",
+		Short: "Restore the objects a deployment changed on Dynatrace environments",
+		Long: "Restore the objects captured in a snapshot taken by 'monaco deploy --snapshot': objects the deployment updated are reset to their previous payload, " +
+			"and objects the deployment created are deleted. The environments are read from the manifest.",
+		Example: "monaco rollback ./snapshots/2024-01-31 --manifest manifest.yaml -e dev-environment",
+		Args:    cobra.ExactArgs(1),
+		PreRun:  cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !files.IsYamlFileExtension(manifestName) {
+				err := fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
+				return err
+			}
+
+			return rollback(cmd.Context(), fs, rollbackCmdOptions{
+				snapshotDir:          args[0],
+				manifestPath:         manifestName,
+				environmentGroups:    groups,
+				specificEnvironments: environment,
+				stateFile:            stateFile,
+			})
+		},
+	}
+
+	rollbackCmd.Flags().StringVarP(&manifestName, "manifest", "m", "manifest.yaml", "The manifest defining the environments to roll back")
+	rollbackCmd.Flags().StringSliceVarP(&environment, "environment", "e", []string{},
+		"Specify one (or multiple) environment(s) to roll back. "+
+			"To set multiple environments either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--group'.")
+	rollbackCmd.Flags().StringSliceVarP(&groups, "group", "g", []string{},
+		"Specify one (or multiple) environmentGroup(s) to roll back. "+
+			"To set multiple groups either repeat this flag, or separate them using a comma (,). "+
+			"If this flag is specified, all environments within this group will be rolled back. "+
+			"This flag is mutually exclusive with '--environment'")
+	rollbackCmd.Flags().StringVar(&stateFile, "state", "", "Path of the local deployment state file used by the deployment. Deleted objects are removed from the state.")
+
+	err := rollbackCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag)
+	if err != nil {
+		log.Fatal("failed to setup CLI %v", err)
+	}
+
+	rollbackCmd.MarkFlagsMutuallyExclusive("environment", "group")
+
+	return rollbackCmd
+}
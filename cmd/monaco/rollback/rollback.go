@@ -0,0 +1,87 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/spf13/afero"
+	"path/filepath"
+)
+
+type rollbackCmdOptions struct {
+	snapshotDir          string
+	manifestPath         string
+	environmentGroups    []string
+	specificEnvironments []string
+	// stateFile is the path of the deployment state file to update. If empty, no state is updated.
+	stateFile string
+}
+
+// rollback restores the snapshot stored in the snapshot directory on the environments of the manifest
+func rollback(ctx context.Context, fs afero.Fs, opts rollbackCmdOptions) error {
+	snap, err := snapshot.Load(fs, opts.snapshotDir)
+	if err != nil {
+		return err
+	}
+
+	absManifestPath, err := filepath.Abs(filepath.Clean(opts.manifestPath))
+	if err != nil {
+		return fmt.Errorf("error while finding absolute path for `%s`: %w", opts.manifestPath, err)
+	}
+	loadedManifest, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: absManifestPath,
+		Groups:       opts.environmentGroups,
+		Environments: opts.specificEnvironments,
+		Opts:         manifestloader.Options{RequireEnvironmentGroups: true},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return errors.New("error while loading manifest")
+	}
+
+	var s *state.State
+	if opts.stateFile != "" {
+		if s, err = state.Load(fs, opts.stateFile); err != nil {
+			return err
+		}
+	}
+
+	rollbackErr := cmdutils.RestoreSnapshot(ctx, loadedManifest.Environments, snap, s)
+
+	if s != nil {
+		if err := s.Write(fs, opts.stateFile); err != nil {
+			return err
+		}
+		log.Info("Deployment state written to %q", opts.stateFile)
+	}
+
+	if rollbackErr != nil {
+		return fmt.Errorf("rollback failed - check logs for details: %w", rollbackErr)
+	}
+
+	log.Info("Rollback finished without errors")
+	return nil
+}
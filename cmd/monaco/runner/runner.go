@@ -15,13 +15,17 @@
 package runner
 
 import (
+	"context"
+	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/account"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/convert"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/delete"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/download"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/drift"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/generate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/purge"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/rollback"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/support"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/version"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
@@ -31,12 +35,18 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"io"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func Run() int {
 	rootCmd := BuildCli(afero.NewOsFs())
 
-	if err := rootCmd.Execute(); err != nil {
+	ctx, cancel := signalContext()
+	defer cancel(nil)
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		log.WithFields(field.Error(err)).Error("Error: %v", err)
 		log.WithFields(field.F("errorLogFilePath", log.ErrorFilePath())).Error("error logs written to %s", log.ErrorFilePath())
 		return 1
@@ -44,6 +54,29 @@ func Run() int {
 	return 0
 }
 
+// signalContext returns a context that is canceled once the process receives SIGINT or SIGTERM. Commands stop
+// starting new work once it is canceled, and let work that is in progress finish. A second signal terminates the
+// process immediately.
+func signalContext() (context.Context, context.CancelCauseFunc) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-signals:
+			log.Warn("Received %v - finishing requests in progress, no new requests are started. Send the signal again to terminate immediately.", sig)
+			// restore the default behavior, so that a second signal terminates the process
+			signal.Stop(signals)
+			cancel(fmt.Errorf("received %v", sig))
+		case <-ctx.Done():
+			signal.Stop(signals)
+		}
+	}()
+
+	return ctx, cancel
+}
+
 func BuildCli(fs afero.Fs) *cobra.Command {
 	return BuildCliWithLogSpy(fs, nil)
 }
@@ -89,6 +122,8 @@ Examples:
 	rootCmd.AddCommand(download.GetDownloadCommand(fs, &download.DefaultCommand{}))
 	rootCmd.AddCommand(convert.GetConvertCommand(fs))
 	rootCmd.AddCommand(deploy.GetDeployCommand(fs))
+	rootCmd.AddCommand(drift.GetDriftCommand(fs))
+	rootCmd.AddCommand(rollback.GetRollbackCommand(fs))
 	rootCmd.AddCommand(delete.GetDeleteCommand(fs))
 	rootCmd.AddCommand(version.GetVersionCommand())
 	rootCmd.AddCommand(generate.Command(fs))
@@ -20,13 +20,15 @@ import (
 	"encoding/base64"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"strings"
 )
 
+const externalIDPrefix = "monaco:"
+
 // GenerateExternalID generates a string that serves as an external ID for a Settings 2.0 object.
 // It requires a [[coordinate.Coordinate]] as input and produces a string in the format "monaco:<BASE64_ENCODED_STR>"
 // If Type or ConfigId of the passed [[coordinate.Coordinate]] is empty, an error is returned
 func GenerateExternalID(c coordinate.Coordinate) (string, error) {
-	const prefix = "monaco:"
 	const externalIDMaxLength = 500
 
 	if c.Type == "" || c.ConfigId == "" {
@@ -41,12 +43,34 @@ func GenerateExternalID(c coordinate.Coordinate) (string, error) {
 	}
 
 	encodedID := base64.StdEncoding.EncodeToString([]byte(formattedID))
-	encodedIDMaxLength := externalIDMaxLength - len(prefix)
+	encodedIDMaxLength := externalIDMaxLength - len(externalIDPrefix)
 	if len(encodedID) > encodedIDMaxLength {
 		encodedID = encodedID[encodedIDMaxLength:]
 	}
 
-	return fmt.Sprintf("%s%s", prefix, encodedID), nil
+	return fmt.Sprintf("%s%s", externalIDPrefix, encodedID), nil
+}
+
+// ParseExternalID returns the coordinate an external ID was generated from by GenerateExternalID.
+// An error is returned if the external ID was not generated by monaco, was generated without a project, or was cut
+// because it exceeded the maximum length.
+func ParseExternalID(externalID string) (coordinate.Coordinate, error) {
+	encodedID, found := strings.CutPrefix(externalID, externalIDPrefix)
+	if !found {
+		return coordinate.Coordinate{}, fmt.Errorf("external ID %q was not generated by monaco", externalID)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encodedID)
+	if err != nil {
+		return coordinate.Coordinate{}, fmt.Errorf("failed to decode external ID %q: %w", externalID, err)
+	}
+
+	parts := strings.Split(string(decoded), "$")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return coordinate.Coordinate{}, fmt.Errorf("external ID %q does not contain a project, type and config ID", externalID)
+	}
+
+	return coordinate.Coordinate{Project: parts[0], Type: parts[1], ConfigId: parts[2]}, nil
 }
 
 type ExternalIDGenerator func(coordinate.Coordinate) (string, error)
@@ -132,3 +132,34 @@ func TestGenerateExternalIdRawIdParts(t *testing.T) {
 	copy(rawId, decoded)
 	assert.Equal(t, "project-name$schema-id$config-id", string(decoded))
 }
+
+func TestParseExternalID(t *testing.T) {
+	c := coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "my-profile"}
+	externalID, err := GenerateExternalID(c)
+	assert.NoError(t, err)
+
+	parsed, err := ParseExternalID(externalID)
+	assert.NoError(t, err)
+	assert.Equal(t, c, parsed)
+}
+
+func TestParseExternalID_Errors(t *testing.T) {
+	legacyID, err := GenerateExternalID(coordinate.Coordinate{Type: "builtin:alerting.profile", ConfigId: "my-profile"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		externalID string
+	}{
+		{"not generated by monaco", "some-external-id"},
+		{"not base64 encoded", "monaco:not-base64!"},
+		{"legacy ID without project", legacyID},
+		{"missing config ID", "monaco:" + base64.StdEncoding.EncodeToString([]byte("project$type$"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExternalID(tt.externalID)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DifferenceKind describes how a value differs between two JSON documents
+type DifferenceKind string
+
+const (
+	// Added marks a value that is only present in the target document
+	Added DifferenceKind = "added"
+	// Removed marks a value that is only present in the source document
+	Removed DifferenceKind = "removed"
+	// Changed marks a value that is present in both documents, but differs
+	Changed DifferenceKind = "changed"
+)
+
+// Difference is a single difference found between two JSON documents
+type Difference struct {
+	// Path is the location of the differing value, e.g. "rules[0].enabled". The document root is denoted by an empty Path.
+	Path string `json:"path"`
+	// Kind describes how the value differs
+	Kind DifferenceKind `json:"kind"`
+	// From is the value in the source document, nil if the value was Added
+	From any `json:"from,omitempty"`
+	// To is the value in the target document, nil if the value was Removed
+	To any `json:"to,omitempty"`
+}
+
+func (d Difference) String() string {
+	path := d.Path
+	if path == "" {
+		path = "<root>"
+	}
+	switch d.Kind {
+	case Added:
+		return fmt.Sprintf("+ %s: %s", path, marshalValue(d.To))
+	case Removed:
+		return fmt.Sprintf("- %s: %s", path, marshalValue(d.From))
+	default:
+		return fmt.Sprintf("~ %s: %s => %s", path, marshalValue(d.From), marshalValue(d.To))
+	}
+}
+
+func marshalValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// DiffOptions define how Diff compares two documents
+type DiffOptions struct {
+	// IgnoreUndefinedKeys makes Diff ignore object keys that are only present in the source document.
+	// This is useful when comparing a payload against an object stored on a server, which commonly contains
+	// additional server-populated properties and defaults.
+	IgnoreUndefinedKeys bool
+}
+
+// Diff returns the differences between the JSON documents from and to, describing what needs to change to turn from into to.
+// Documents are compared semantically - formatting, order of object keys and number representation do not matter.
+// Differences are returned sorted by their path.
+func Diff(from, to []byte, opts DiffOptions) ([]Difference, error) {
+	var f, t any
+	if err := json.Unmarshal(from, &f); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON to compare: %w", err)
+	}
+	if err := json.Unmarshal(to, &t); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON to compare: %w", err)
+	}
+
+	diffs := diffValues("", f, t, opts)
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs, nil
+}
+
+func diffValues(path string, from, to any, opts DiffOptions) []Difference {
+	switch f := from.(type) {
+	case map[string]any:
+		if t, ok := to.(map[string]any); ok {
+			return diffObjects(path, f, t, opts)
+		}
+	case []any:
+		if t, ok := to.([]any); ok {
+			return diffArrays(path, f, t, opts)
+		}
+	}
+
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+	return []Difference{{Path: path, Kind: Changed, From: from, To: to}}
+}
+
+func diffObjects(path string, from, to map[string]any, opts DiffOptions) []Difference {
+	var diffs []Difference
+	for k, t := range to {
+		f, found := from[k]
+		if !found {
+			diffs = append(diffs, Difference{Path: joinKey(path, k), Kind: Added, To: t})
+			continue
+		}
+		diffs = append(diffs, diffValues(joinKey(path, k), f, t, opts)...)
+	}
+
+	if opts.IgnoreUndefinedKeys {
+		return diffs
+	}
+
+	for k, f := range from {
+		if _, found := to[k]; !found {
+			diffs = append(diffs, Difference{Path: joinKey(path, k), Kind: Removed, From: f})
+		}
+	}
+	return diffs
+}
+
+func diffArrays(path string, from, to []any, opts DiffOptions) []Difference {
+	var diffs []Difference
+	for i := 0; i < len(from) || i < len(to); i++ {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(from):
+			diffs = append(diffs, Difference{Path: p, Kind: Added, To: to[i]})
+		case i >= len(to):
+			diffs = append(diffs, Difference{Path: p, Kind: Removed, From: from[i]})
+		default:
+			diffs = append(diffs, diffValues(p, from[i], to[i], opts)...)
+		}
+	}
+	return diffs
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,97 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		opts DiffOptions
+		want []Difference
+	}{
+		{
+			name: "equal documents",
+			from: `{"a": 1, "b": ["x", "y"]}`,
+			to:   `{"b": ["x","y"], "a": 1.0}`,
+			want: nil,
+		},
+		{
+			name: "changed value",
+			from: `{"a": 1, "b": {"c": true}}`,
+			to:   `{"a": 1, "b": {"c": false}}`,
+			want: []Difference{{Path: "b.c", Kind: Changed, From: true, To: false}},
+		},
+		{
+			name: "added and removed keys",
+			from: `{"a": 1, "b": 2}`,
+			to:   `{"a": 1, "c": 3}`,
+			want: []Difference{
+				{Path: "b", Kind: Removed, From: 2.0},
+				{Path: "c", Kind: Added, To: 3.0},
+			},
+		},
+		{
+			name: "undefined keys are ignored",
+			from: `{"a": 1, "id": "server-id", "nested": {"b": 2, "default": true}}`,
+			to:   `{"a": 1, "nested": {"b": 3}}`,
+			opts: DiffOptions{IgnoreUndefinedKeys: true},
+			want: []Difference{{Path: "nested.b", Kind: Changed, From: 2.0, To: 3.0}},
+		},
+		{
+			name: "array elements",
+			from: `{"list": [{"id": 1}, {"id": 2}]}`,
+			to:   `{"list": [{"id": 3}]}`,
+			want: []Difference{
+				{Path: "list[0].id", Kind: Changed, From: 1.0, To: 3.0},
+				{Path: "list[1]", Kind: Removed, From: map[string]any{"id": 2.0}},
+			},
+		},
+		{
+			name: "type change",
+			from: `{"a": "1"}`,
+			to:   `{"a": 1}`,
+			want: []Difference{{Path: "a", Kind: Changed, From: "1", To: 1.0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff([]byte(tt.from), []byte(tt.to), tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiff_InvalidJSON(t *testing.T) {
+	_, err := Diff([]byte(`{`), []byte(`{}`), DiffOptions{})
+	assert.Error(t, err)
+}
+
+func TestDifference_String(t *testing.T) {
+	assert.Equal(t, `~ a.b: 1 => 2`, Difference{Path: "a.b", Kind: Changed, From: 1, To: 2}.String())
+	assert.Equal(t, `+ a: "x"`, Difference{Path: "a", Kind: Added, To: "x"}.String())
+	assert.Equal(t, `- <root>: null`, Difference{Kind: Removed}.String())
+}
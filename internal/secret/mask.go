@@ -0,0 +1,97 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Mask is the replacement written instead of a registered secret value
+const Mask = "****"
+
+// minLength is the minimum length of registered secret values. Shorter values like "true", "admin" or a port are too
+// likely to occur in unrelated output, so they are not masked.
+const minLength = 8
+
+var (
+	lock     sync.RWMutex
+	values   = make(map[string]struct{})
+	replacer = strings.NewReplacer()
+)
+
+// Register marks the given values as secrets. All occurrences of registered values are replaced by Mask in strings passed
+// to MaskValues, and in everything written by a Writer. Values shorter than 8 characters are ignored.
+//
+// Masking replaces substrings, so it is only meant for logs and console output. Data that is written to be read again,
+// like the deployment state, must never be masked.
+func Register(vals ...string) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	added := false
+	for _, v := range vals {
+		if _, found := values[v]; len(v) < minLength || found {
+			continue
+		}
+		values[v] = struct{}{}
+		added = true
+	}
+
+	if added {
+		replacer = newReplacer()
+	}
+}
+
+// newReplacer returns a replacer for all registered values. Longer values are replaced first, so that secrets that
+// contain other secrets are masked completely.
+func newReplacer() *strings.Replacer {
+	sorted := make([]string, 0, len(values))
+	for v := range values {
+		sorted = append(sorted, v)
+	}
+	slices.SortFunc(sorted, func(a, b string) int { return len(b) - len(a) })
+
+	oldnew := make([]string, 0, 2*len(sorted))
+	for _, v := range sorted {
+		oldnew = append(oldnew, v, Mask)
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// MaskValues replaces all registered secret values in s with Mask
+func MaskValues(s string) string {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	return replacer.Replace(s)
+}
+
+// Writer masks all registered secret values before writing to the wrapped io.Writer.
+// Each call to Write is masked on its own, so values split across several calls are not masked.
+type Writer struct {
+	io.Writer
+}
+
+func (w Writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.Writer, MaskValues(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,49 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret_test
+
+import (
+	"bytes"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMaskValues(t *testing.T) {
+	secret.Register("my-token", "my-token-with-suffix", "")
+
+	assert.Equal(t, "auth: ****, other: ****", secret.MaskValues("auth: my-token, other: my-token-with-suffix"))
+	assert.Equal(t, "nothing to mask", secret.MaskValues("nothing to mask"))
+}
+
+func TestRegister_IgnoresShortValues(t *testing.T) {
+	secret.Register("true", "admin", "8080")
+
+	assert.Equal(t, `{"enabled": true, "user": "admin", "port": 8080}`, secret.MaskValues(`{"enabled": true, "user": "admin", "port": 8080}`))
+}
+
+func TestWriter(t *testing.T) {
+	secret.Register("password")
+
+	out := bytes.Buffer{}
+	n, err := secret.Writer{Writer: &out}.Write([]byte(`{"password": "password"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 24, n, "written length must be the length of the unmasked input")
+	assert.Equal(t, `{"****": "****"}`, out.String())
+}
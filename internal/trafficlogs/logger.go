@@ -21,6 +21,7 @@ import (
 	lib "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/timeutils"
 	"github.com/google/uuid"
 	"github.com/spf13/afero"
@@ -129,10 +130,14 @@ func (l *FileBasedLogger) logRequest(id string, request *http.Request, body io.R
 		return err
 	}
 
-	// write body
+	// write body, masking registered secret values
 	if body != nil {
 		defer body.Close()
-		if _, err := io.Copy(l.requestLogFile, body); err != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		if _, err := l.requestLogFile.WriteString(secret.MaskValues(string(b))); err != nil {
 			return err
 		}
 	}
@@ -167,10 +172,14 @@ func (l *FileBasedLogger) logResponse(id string, response *http.Response, body i
 		return err
 	}
 
-	// write body
+	// write body, masking registered secret values
 	if body != nil {
 		defer body.Close()
-		if _, err := io.Copy(l.responseLogFile, body); err != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		if _, err := l.responseLogFile.WriteString(secret.MaskValues(string(b))); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,57 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package webhook sends JSON payloads to HTTP webhooks, as done by hooks and notifications.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
+	"io"
+	"net/http"
+)
+
+// Post sends the JSON body to the webhook at the URL. The headers are resolved right before the request is sent, so
+// values loaded from environment variables are registered as secrets. Post fails unless the webhook responds with a 2xx
+// status code. The request is bound to ctx, which should carry a timeout.
+func Post(ctx context.Context, url string, headers map[string]hook.HeaderValue, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		val, err := v.Resolve()
+		if err != nil {
+			return fmt.Errorf("failed to resolve header %q: %w", k, err)
+		}
+		req.Header.Set(k, val)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("webhook responded with HTTP %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
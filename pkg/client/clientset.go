@@ -58,6 +58,14 @@ func (s ClientSet) Settings() *dtclient.DynatraceClient {
 	return s.dtClient
 }
 
+func (s ClientSet) Events() *dtclient.DynatraceClient {
+	return s.dtClient
+}
+
+func (s ClientSet) Entities() *dtclient.DynatraceClient {
+	return s.dtClient
+}
+
 func (s ClientSet) Automation() *automation.Client {
 	return s.autClient
 }
@@ -49,7 +49,7 @@ type ConfigClient interface {
 	// ReadConfigById reads a Dynatrace config identified by id from the given API.
 	// It calls the underlying GET endpoint for the API. E.g. for alerting profiles this would be:
 	//    GET <environment-url>/api/config/v1/alertingProfiles/<id> ... to get the alerting profile
-	ReadConfigById(a api.API, id string) (json []byte, err error)
+	ReadConfigById(ctx context.Context, a api.API, id string) (json []byte, err error)
 
 	// UpsertConfigByName creates a given Dynatrace config if it doesn't exist and updates it otherwise using its name.
 	// It calls the underlying GET, POST, and PUT endpoints for the API. E.g. for alerting profiles this would be:
@@ -70,7 +70,7 @@ type ConfigClient interface {
 	// DeleteConfigById removes a given config for a given API using its id.
 	// It calls the DELETE endpoint for the API. E.g. for alerting profiles this would be:
 	//    DELETE <environment-url>/api/config/v1/alertingProfiles/<id> ... to delete the config
-	DeleteConfigById(a api.API, id string) error
+	DeleteConfigById(ctx context.Context, a api.API, id string) error
 
 	// ConfigExistsByName checks if a config with the given name exists for the given API.
 	// It calls the underlying GET endpoint for the API. E.g. for alerting profiles this would be:
@@ -117,19 +117,29 @@ type SettingsClient interface {
 	// update the object.
 	UpsertSettings(context.Context, SettingsObject, UpsertSettingsOptions) (DynatraceEntity, error)
 
+	// UpsertSettingsByObjectID creates or replaces the settings object with the origin object ID of the supplied object,
+	// and sets the given external ID. Unlike UpsertSettings, the object is not searched by external ID or unique key
+	// constraints, and no external ID is generated from the object's coordinate.
+	UpsertSettingsByObjectID(ctx context.Context, obj SettingsObject, externalID string) (DynatraceEntity, error)
+
+	// FindSettingsObject searches for the existing object UpsertSettings would update for the supplied object.
+	// Objects are matched in the same way as UpsertSettings does - by external-id, origin object ID, and the schema's
+	// unique key constraints. If no such object exists, false is returned.
+	FindSettingsObject(context.Context, SettingsObject) (DownloadSettingsObject, bool, error)
+
 	// ListSchemas returns all schemas that the Dynatrace environment reports
-	ListSchemas() (SchemaList, error)
+	ListSchemas(context.Context) (SchemaList, error)
 
-	FetchSchemasConstraints(schemaID string) (SchemaConstraints, error)
+	FetchSchemasConstraints(ctx context.Context, schemaID string) (SchemaConstraints, error)
 
 	// ListSettings returns all settings objects for a given schema.
 	ListSettings(context.Context, string, ListSettingsOptions) ([]DownloadSettingsObject, error)
 
 	// GetSettingById returns the setting with the given object ID
-	GetSettingById(string) (*DownloadSettingsObject, error)
+	GetSettingById(context.Context, string) (*DownloadSettingsObject, error)
 
 	// DeleteSettings deletes a settings object giving its object ID
-	DeleteSettings(string) error
+	DeleteSettings(context.Context, string) error
 }
 
 type UpsertSettingsOptions struct {
@@ -197,6 +207,12 @@ type DynatraceClient struct {
 	//  settingsObjectAPIPath is the API path to use for accessing settings objects
 	settingsObjectAPIPath string
 
+	// eventsAPIPath is the API path to use for ingesting events
+	eventsAPIPath string
+
+	// entitiesAPIPath is the API path to use for reading monitored entities
+	entitiesAPIPath string
+
 	// retrySettings are the settings to be used for retrying failed http requests
 	retrySettings rest.RetrySettings
 
@@ -327,6 +343,8 @@ func NewPlatformClient(dtURL string, classicURL string, client *rest.Client, cla
 		retrySettings:          rest.DefaultRetrySettings,
 		settingsSchemaAPIPath:  settingsSchemaAPIPathPlatform,
 		settingsObjectAPIPath:  settingsObjectAPIPathPlatform,
+		eventsAPIPath:          eventsAPIPathPlatform,
+		entitiesAPIPath:        entitiesAPIPathPlatform,
 		limiter:                concurrency.NewLimiter(5),
 		generateExternalID:     idutils.GenerateExternalID,
 		settingsCache:          &cache.DefaultCache[[]DownloadSettingsObject]{},
@@ -357,6 +375,8 @@ func NewClassicClient(dtURL string, client *rest.Client, opts ...func(dynatraceC
 		retrySettings:          rest.DefaultRetrySettings,
 		settingsSchemaAPIPath:  settingsSchemaAPIPathClassic,
 		settingsObjectAPIPath:  settingsObjectAPIPathClassic,
+		eventsAPIPath:          eventsAPIPathClassic,
+		entitiesAPIPath:        entitiesAPIPathClassic,
 		limiter:                concurrency.NewLimiter(5),
 		generateExternalID:     idutils.GenerateExternalID,
 		settingsCache:          &cache.DefaultCache[[]DownloadSettingsObject]{},
@@ -401,9 +421,9 @@ func (d *DynatraceClient) listConfigs(ctx context.Context, api api.API) (values
 	return values, err
 }
 
-func (d *DynatraceClient) ReadConfigById(api api.API, id string) (json []byte, err error) {
+func (d *DynatraceClient) ReadConfigById(ctx context.Context, api api.API, id string) (json []byte, err error) {
 	d.limiter.ExecuteBlocking(func() {
-		json, err = d.readConfigById(context.TODO(), api, id)
+		json, err = d.readConfigById(ctx, api, id)
 	})
 	return
 }
@@ -431,9 +451,9 @@ func (d *DynatraceClient) readConfigById(ctx context.Context, api api.API, id st
 	return response.Body, nil
 }
 
-func (d *DynatraceClient) DeleteConfigById(api api.API, id string) (err error) {
+func (d *DynatraceClient) DeleteConfigById(ctx context.Context, api api.API, id string) (err error) {
 	d.limiter.ExecuteBlocking(func() {
-		err = d.deleteConfigById(context.TODO(), api, id)
+		err = d.deleteConfigById(ctx, api, id)
 	})
 	return
 }
@@ -500,9 +520,9 @@ func (d *DynatraceClient) upsertConfigByNonUniqueNameAndId(ctx context.Context,
 	return d.upsertDynatraceEntityByNonUniqueNameAndId(ctx, entityId, name, api, payload, duplicate)
 }
 
-func (d *DynatraceClient) GetSettingById(objectId string) (res *DownloadSettingsObject, err error) {
+func (d *DynatraceClient) GetSettingById(ctx context.Context, objectId string) (res *DownloadSettingsObject, err error) {
 	d.limiter.ExecuteBlocking(func() {
-		res, err = d.getSettingById(context.TODO(), objectId)
+		res, err = d.getSettingById(ctx, objectId)
 	})
 	return
 }
@@ -535,9 +555,9 @@ func (d *DynatraceClient) getSettingById(ctx context.Context, objectId string) (
 	return &result, nil
 }
 
-func (d *DynatraceClient) DeleteSettings(objectID string) (err error) {
+func (d *DynatraceClient) DeleteSettings(ctx context.Context, objectID string) (err error) {
 	d.limiter.ExecuteBlocking(func() {
-		err = d.deleteSettings(context.TODO(), objectID)
+		err = d.deleteSettings(ctx, objectID)
 	})
 	return
 }
@@ -745,7 +745,7 @@ func TestReadByIdReturnsAnErrorUponEncounteringAnError(t *testing.T) {
 		generateExternalID:    idutils.GenerateExternalID,
 	}
 
-	_, err := client.ReadConfigById(mockAPI, "test")
+	_, err := client.ReadConfigById(context.TODO(), mockAPI, "test")
 	assert.ErrorContains(t, err, "Response was")
 }
 
@@ -760,7 +760,7 @@ func TestReadByIdEscapesTheId(t *testing.T) {
 		limiter:               concurrency.NewLimiter(5),
 		generateExternalID:    idutils.GenerateExternalID,
 	}
-	_, err := client.ReadConfigById(mockAPINotSingle, unescapedID)
+	_, err := client.ReadConfigById(context.TODO(), mockAPINotSingle, unescapedID)
 	assert.NoError(t, err)
 }
 
@@ -779,7 +779,7 @@ func TestReadByIdReturnsTheResponseGivenNoError(t *testing.T) {
 		generateExternalID:    idutils.GenerateExternalID,
 	}
 
-	resp, err := client.ReadConfigById(mockAPI, "test")
+	resp, err := client.ReadConfigById(context.TODO(), mockAPI, "test")
 	assert.NoError(t, err, "there should not be an error")
 	assert.Equal(t, body, resp)
 }
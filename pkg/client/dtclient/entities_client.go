@@ -0,0 +1,98 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"net/url"
+)
+
+// MonitoredEntity is an entity monitored by Dynatrace, e.g. a host or a service
+type MonitoredEntity struct {
+	// EntityId is the ID of the entity, e.g. HOST-0123456789ABCDEF
+	EntityId string `json:"entityId"`
+	// Type of the entity, e.g. HOST
+	Type string `json:"type"`
+	// DisplayName of the entity
+	DisplayName string `json:"displayName"`
+}
+
+//go:generate mockgen -source=entities_client.go -destination=entities_client_mock.go -package=dtclient EntitiesClient
+
+// EntitiesClient reads monitored entities using the [entities api] of Dynatrace
+//
+// [entities api]: https://docs.dynatrace.com/docs/dynatrace-api/environment-api/entity-v2/get-entities-list
+type EntitiesClient interface {
+	// ListMonitoredEntities returns all monitored entities matching the given entity selector
+	ListMonitoredEntities(ctx context.Context, entitySelector string) ([]MonitoredEntity, error)
+}
+
+var _ EntitiesClient = (*DynatraceClient)(nil)
+
+const (
+	entitiesAPIPathClassic  = "/api/v2/entities"
+	entitiesAPIPathPlatform = "/platform/classic/environment-api/v2/entities"
+)
+
+func (d *DynatraceClient) ListMonitoredEntities(ctx context.Context, entitySelector string) (res []MonitoredEntity, err error) {
+	d.limiter.ExecuteBlocking(func() {
+		res, err = d.listMonitoredEntities(ctx, entitySelector)
+	})
+	return
+}
+
+func (d *DynatraceClient) listMonitoredEntities(ctx context.Context, entitySelector string) ([]MonitoredEntity, error) {
+	params := url.Values{
+		"entitySelector": []string{entitySelector},
+		"pageSize":       []string{defaultPageSize},
+	}
+
+	result := make([]MonitoredEntity, 0)
+
+	addToResult := func(body []byte) (int, error) {
+		var parsed struct {
+			Entities []MonitoredEntity `json:"entities"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+
+		result = append(result, parsed.Entities...)
+		return len(parsed.Entities), nil
+	}
+
+	u, err := buildUrl(d.environmentURL, d.entitiesAPIPath, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for entity selector %q: %w", entitySelector, err)
+	}
+
+	_, err = rest.ListPaginated(ctx, d.platformClient, d.retrySettings, u, entitySelector, addToResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monitored entities matching %q: %w", entitySelector, err)
+	}
+
+	return result, nil
+}
+
+// ListMonitoredEntities of the DummyClient returns a single entity with a fake ID for each entity selector, so that
+// entity parameters can be resolved in dry-runs
+func (c *DummyClient) ListMonitoredEntities(_ context.Context, entitySelector string) ([]MonitoredEntity, error) {
+	return []MonitoredEntity{{EntityId: "DUMMY-ENTITY-ID", DisplayName: entitySelector}}, nil
+}
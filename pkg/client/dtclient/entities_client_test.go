@@ -0,0 +1,88 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListMonitoredEntities(t *testing.T) {
+	tests := []struct {
+		name         string
+		newClient    func(url string, c *rest.Client) (*DynatraceClient, error)
+		expectedPath string
+	}{
+		{
+			name:         "classic",
+			newClient:    func(url string, c *rest.Client) (*DynatraceClient, error) { return NewClassicClient(url, c) },
+			expectedPath: entitiesAPIPathClassic,
+		},
+		{
+			name:         "platform",
+			newClient:    func(url string, c *rest.Client) (*DynatraceClient, error) { return NewPlatformClient(url, url, c, c) },
+			expectedPath: entitiesAPIPathPlatform,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, http.MethodGet, req.Method)
+				assert.Equal(t, tt.expectedPath, req.URL.Path)
+
+				if req.URL.Query().Get("nextPageKey") == "page-2" {
+					_, _ = rw.Write([]byte(`{"totalCount": 2, "entities": [{"entityId": "HOST-2", "type": "HOST", "displayName": "host-2"}]}`))
+					return
+				}
+				assert.Equal(t, "type(HOST)", req.URL.Query().Get("entitySelector"))
+				_, _ = rw.Write([]byte(`{"totalCount": 2, "nextPageKey": "page-2", "entities": [{"entityId": "HOST-1", "type": "HOST", "displayName": "host-1"}]}`))
+			}))
+			defer server.Close()
+
+			d, err := tt.newClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
+			assert.NoError(t, err)
+
+			entities, err := d.ListMonitoredEntities(context.TODO(), "type(HOST)")
+			assert.NoError(t, err)
+			assert.Equal(t, []MonitoredEntity{
+				{EntityId: "HOST-1", Type: "HOST", DisplayName: "host-1"},
+				{EntityId: "HOST-2", Type: "HOST", DisplayName: "host-2"},
+			}, entities)
+		})
+	}
+}
+
+func TestListMonitoredEntities_ReturnsErrorOnFailedRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte(`{"error": "invalid selector"}`))
+	}))
+	defer server.Close()
+
+	d, err := NewClassicClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
+	assert.NoError(t, err)
+
+	_, err = d.ListMonitoredEntities(context.TODO(), "invalid")
+	assert.ErrorContains(t, err, `failed to list monitored entities matching "invalid"`)
+}
@@ -0,0 +1,87 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"net/http"
+)
+
+// EventTypeCustomConfiguration is the type of events that describe a configuration change
+const EventTypeCustomConfiguration = "CUSTOM_CONFIGURATION"
+
+// Event is an event ingested using the Events API v2
+type Event struct {
+	// EventType is the type of the event, e.g. EventTypeCustomConfiguration
+	EventType string `json:"eventType"`
+	// Title of the event
+	Title string `json:"title"`
+	// EntitySelector selects the entities the event is attached to. If it is empty, the event is not attached to any
+	// entity.
+	EntitySelector string `json:"entitySelector,omitempty"`
+	// Properties are additional key-value pairs describing the event
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+//go:generate mockgen -source=events_client.go -destination=events_client_mock.go -package=dtclient EventsClient
+
+// EventsClient ingests events using the [events api] of Dynatrace
+//
+// [events api]: https://docs.dynatrace.com/docs/dynatrace-api/environment-api/events-v2/post-event
+type EventsClient interface {
+	// SendEvent ingests the given event
+	SendEvent(context.Context, Event) error
+}
+
+var _ EventsClient = (*DynatraceClient)(nil)
+
+const (
+	eventsAPIPathClassic  = "/api/v2/events/ingest"
+	eventsAPIPathPlatform = "/platform/classic/environment-api/v2/events/ingest"
+)
+
+func (d *DynatraceClient) SendEvent(ctx context.Context, e Event) (err error) {
+	d.limiter.ExecuteBlocking(func() {
+		err = d.sendEvent(ctx, e)
+	})
+	return
+}
+
+func (d *DynatraceClient) sendEvent(ctx context.Context, e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	requestURL := d.environmentURL + d.eventsAPIPath
+
+	resp, err := d.platformClient.Post(ctx, requestURL, payload)
+	if err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	if !resp.IsSuccess() {
+		return rest.NewRespErr(fmt.Sprintf("failed to send event (HTTP %d)!\n    Response was: %s", resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodPost, requestURL)
+	}
+	return nil
+}
+
+func (c *DummyClient) SendEvent(_ context.Context, _ Event) error {
+	return nil
+}
@@ -0,0 +1,90 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendEvent(t *testing.T) {
+	event := Event{
+		EventType:      EventTypeCustomConfiguration,
+		Title:          "Monaco deployment",
+		EntitySelector: "type(HOST)",
+		Properties:     map[string]string{"revision": "abc"},
+	}
+
+	tests := []struct {
+		name         string
+		newClient    func(url string, c *rest.Client) (*DynatraceClient, error)
+		expectedPath string
+	}{
+		{
+			name:         "classic",
+			newClient:    func(url string, c *rest.Client) (*DynatraceClient, error) { return NewClassicClient(url, c) },
+			expectedPath: eventsAPIPathClassic,
+		},
+		{
+			name:         "platform",
+			newClient:    func(url string, c *rest.Client) (*DynatraceClient, error) { return NewPlatformClient(url, url, c, c) },
+			expectedPath: eventsAPIPathPlatform,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, tt.expectedPath, req.URL.Path)
+
+				var got Event
+				assert.NoError(t, json.NewDecoder(req.Body).Decode(&got))
+				assert.Equal(t, event, got)
+				rw.WriteHeader(http.StatusCreated)
+			}))
+			defer server.Close()
+
+			d, err := tt.newClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
+			assert.NoError(t, err)
+			assert.NoError(t, d.SendEvent(context.TODO(), event))
+		})
+	}
+}
+
+func TestSendEvent_ReturnsErrorOnFailedRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte(`{"error": "invalid"}`))
+	}))
+	defer server.Close()
+
+	d, err := NewClassicClient(server.URL, rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy()))
+	assert.NoError(t, err)
+
+	err = d.SendEvent(context.TODO(), Event{EventType: EventTypeCustomConfiguration, Title: "t"})
+	var respErr rest.RespError
+	assert.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusBadRequest, respErr.StatusCode)
+}
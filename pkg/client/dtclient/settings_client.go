@@ -92,9 +92,9 @@ type (
 	}
 )
 
-func (d *DynatraceClient) ListSchemas() (schemas SchemaList, err error) {
+func (d *DynatraceClient) ListSchemas(ctx context.Context) (schemas SchemaList, err error) {
 	d.limiter.ExecuteBlocking(func() {
-		schemas, err = d.listSchemas(context.TODO())
+		schemas, err = d.listSchemas(ctx)
 	})
 	return
 }
@@ -128,9 +128,9 @@ func (d *DynatraceClient) listSchemas(ctx context.Context) (SchemaList, error) {
 	return result.Items, nil
 }
 
-func (d *DynatraceClient) FetchSchemasConstraints(schemaID string) (constraints SchemaConstraints, err error) {
+func (d *DynatraceClient) FetchSchemasConstraints(ctx context.Context, schemaID string) (constraints SchemaConstraints, err error) {
 	d.limiter.ExecuteBlocking(func() {
-		constraints, err = d.fetchSchemasConstraints(context.TODO(), schemaID)
+		constraints, err = d.fetchSchemasConstraints(ctx, schemaID)
 	})
 	return
 }
@@ -193,9 +193,114 @@ func (d *DynatraceClient) upsertSettings(ctx context.Context, obj SettingsObject
 		}
 	}
 
-	if matchingObject, found, err := d.findObjectWithMatchingConstraints(ctx, obj); err != nil {
+	externalID, err := d.generateExternalID(obj.Coordinate)
+	if err != nil {
+		return DynatraceEntity{}, fmt.Errorf("unable to generate external id: %w", err)
+	}
+
+	// the object with the origin object ID - e.g. the one recorded in the deployment state - takes precedence, the
+	// object is only searched otherwise
+	if existing, found, err := d.findSettingsObjectByOriginObjectID(ctx, obj); err != nil {
 		return DynatraceEntity{}, err
 	} else if found {
+		log.WithCtxFields(ctx).Debug("Updating existing object %q with origin object ID", existing.ObjectId)
+		if externalID, err = d.externalIDForExistingObject(ctx, existing, externalID); err != nil {
+			return DynatraceEntity{}, err
+		}
+	} else if obj.OriginObjectId, err = d.findObjectIDToUpdate(ctx, obj, externalID); err != nil {
+		return DynatraceEntity{}, err
+	}
+
+	// special handling of this Settings object.
+	// It is delete-protected BUT has a key property which is internally
+	// used to find the object to be updated
+	if obj.SchemaId == "builtin:oneagent.features" {
+		externalID = ""
+		obj.OriginObjectId = ""
+	}
+
+	payload, err := buildPostRequestPayload(ctx, obj, externalID)
+	if err != nil {
+		return DynatraceEntity{}, fmt.Errorf("failed to build settings object: %w", err)
+	}
+
+	var retrySetting rest.RetrySetting
+	if options.OverrideRetry != nil {
+		retrySetting = *options.OverrideRetry
+	} else {
+		retrySetting = d.retrySettings.Normal
+	}
+
+	requestUrl := d.environmentURL + d.settingsObjectAPIPath
+	resp, err := rest.SendWithRetryWithInitialTry(ctx, d.platformClient.Post, obj.Coordinate.ConfigId, requestUrl, payload, retrySetting)
+	if err != nil {
+		d.settingsCache.Delete(obj.SchemaId)
+		return DynatraceEntity{}, fmt.Errorf("failed to create or update Settings object with externalId %s: %w", externalID, err)
+	}
+
+	if !resp.IsSuccess() {
+		d.settingsCache.Delete(obj.SchemaId)
+		return DynatraceEntity{}, rest.NewRespErr(fmt.Sprintf("failed to create or update Settings object with externalId %s (HTTP %d)!\n\tResponse was: %s", externalID, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodPost, requestUrl)
+	}
+
+	entity, err := parsePostResponse(resp)
+	if err != nil {
+		return DynatraceEntity{}, rest.NewRespErr("failed to parse response", resp).WithRequestInfo(http.MethodPost, requestUrl).WithErr(err)
+	}
+
+	log.WithCtxFields(ctx).Debug("Created/Updated object %s (%s) with externalId %s", obj.Coordinate.ConfigId, obj.SchemaId, externalID)
+	return entity, nil
+}
+
+// findSettingsObjectByOriginObjectID returns the object with the origin object ID of the given object, if it exists and
+// is of the same schema
+func (d *DynatraceClient) findSettingsObjectByOriginObjectID(ctx context.Context, obj SettingsObject) (DownloadSettingsObject, bool, error) {
+	// see upsertSettings - this object is identified by its key property only
+	if obj.OriginObjectId == "" || obj.SchemaId == "builtin:oneagent.features" {
+		return DownloadSettingsObject{}, false, nil
+	}
+
+	existing, err := d.getSettingById(ctx, obj.OriginObjectId)
+	if errors.Is(err, ErrSettingNotFound) {
+		return DownloadSettingsObject{}, false, nil
+	}
+	if err != nil {
+		return DownloadSettingsObject{}, false, fmt.Errorf("unable to fetch settings object with object id %q: %w", obj.OriginObjectId, err)
+	}
+	if existing.SchemaId != obj.SchemaId {
+		return DownloadSettingsObject{}, false, nil
+	}
+	return *existing, true, nil
+}
+
+// externalIDForExistingObject returns the external ID to set on the existing object. If the object does not have the
+// given external ID yet, but another object has, its current external ID is kept, as a POST request can only target one
+// object.
+func (d *DynatraceClient) externalIDForExistingObject(ctx context.Context, existing DownloadSettingsObject, externalID string) (string, error) {
+	if existing.ExternalId == externalID {
+		return externalID, nil
+	}
+
+	settings, err := d.listSettings(ctx, existing.SchemaId, ListSettingsOptions{
+		Filter: func(object DownloadSettingsObject) bool { return object.ExternalId == externalID },
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(settings) > 0 {
+		log.WithCtxFields(ctx).Warn("Found two configs, one with the defined originObjectId (%q), and one with the expected monaco externalId (%q). Updating the one with the originObjectId.", existing.ObjectId, settings[0].ObjectId)
+		return existing.ExternalId, nil
+	}
+	return externalID, nil
+}
+
+// findObjectIDToUpdate returns the ID of the object to update if no object with the origin object ID exists, or an
+// empty string if a new object is created. Objects matching the schema's unique constraints, and objects with the legacy
+// external ID are updated.
+func (d *DynatraceClient) findObjectIDToUpdate(ctx context.Context, obj SettingsObject, externalID string) (string, error) {
+	if matchingObject, found, err := d.findObjectWithMatchingConstraints(ctx, obj); err != nil {
+		return "", err
+	} else if found {
 
 		var props []string
 		for k, v := range matchingObject.matches {
@@ -212,25 +317,20 @@ func (d *DynatraceClient) upsertSettings(ctx context.Context, obj SettingsObject
 	// This can be removed in a later release of monaco
 	legacyExternalID, err := d.generateExternalID(coordinate.Coordinate{Type: obj.Coordinate.Type, ConfigId: obj.Coordinate.ConfigId})
 	if err != nil {
-		return DynatraceEntity{}, fmt.Errorf("unable to generate external id: %w", err)
+		return "", fmt.Errorf("unable to generate external id: %w", err)
 	}
 
 	settingsWithExternalID, err := d.listSettings(ctx, obj.SchemaId, ListSettingsOptions{
 		Filter: func(object DownloadSettingsObject) bool { return object.ExternalId == legacyExternalID },
 	})
 	if err != nil {
-		return DynatraceEntity{}, err
+		return "", err
 	}
 
 	if len(settingsWithExternalID) > 0 {
 		obj.OriginObjectId = settingsWithExternalID[0].ObjectId
 	}
 
-	externalID, err := d.generateExternalID(obj.Coordinate)
-	if err != nil {
-		return DynatraceEntity{}, fmt.Errorf("unable to generate external id: %w", err)
-	}
-
 	// If the server contains two configs, one with the origin-object-id and a second config with the externalID,
 	// it is not possible to update the setting using the externalId and origin-object-id on the same POST request,
 	// as two settings objects can be the target of the change. In this case, we remove the origin-object-id
@@ -241,7 +341,7 @@ func (d *DynatraceClient) upsertSettings(ctx context.Context, obj SettingsObject
 		},
 	})
 	if err != nil {
-		return DynatraceEntity{}, err
+		return "", err
 	}
 	if len(settings) == 2 {
 		var exIdSetting, ooIdSetting string
@@ -256,37 +356,31 @@ func (d *DynatraceClient) upsertSettings(ctx context.Context, obj SettingsObject
 		log.WithCtxFields(ctx).Warn("Found two configs, one with the defined originObjectId (%q), and one with the expected monaco externalId (%q). Updating the one with the expected externalId.", ooIdSetting, exIdSetting)
 		obj.OriginObjectId = ""
 	}
+	return obj.OriginObjectId, nil
+}
 
-	// special handling of this Settings object.
-	// It is delete-protected BUT has a key property which is internally
-	// used to find the object to be updated
-	if obj.SchemaId == "builtin:oneagent.features" {
-		externalID = ""
-		obj.OriginObjectId = ""
-	}
+func (d *DynatraceClient) UpsertSettingsByObjectID(ctx context.Context, obj SettingsObject, externalID string) (result DynatraceEntity, err error) {
+	d.limiter.ExecuteBlocking(func() {
+		result, err = d.upsertSettingsByObjectID(ctx, obj, externalID)
+	})
+	return
+}
 
+func (d *DynatraceClient) upsertSettingsByObjectID(ctx context.Context, obj SettingsObject, externalID string) (DynatraceEntity, error) {
 	payload, err := buildPostRequestPayload(ctx, obj, externalID)
 	if err != nil {
 		return DynatraceEntity{}, fmt.Errorf("failed to build settings object: %w", err)
 	}
 
-	var retrySetting rest.RetrySetting
-	if options.OverrideRetry != nil {
-		retrySetting = *options.OverrideRetry
-	} else {
-		retrySetting = d.retrySettings.Normal
-	}
-
 	requestUrl := d.environmentURL + d.settingsObjectAPIPath
-	resp, err := rest.SendWithRetryWithInitialTry(ctx, d.platformClient.Post, obj.Coordinate.ConfigId, requestUrl, payload, retrySetting)
+	resp, err := rest.SendWithRetryWithInitialTry(ctx, d.platformClient.Post, obj.OriginObjectId, requestUrl, payload, d.retrySettings.Normal)
+	d.settingsCache.Delete(obj.SchemaId)
 	if err != nil {
-		d.settingsCache.Delete(obj.SchemaId)
-		return DynatraceEntity{}, fmt.Errorf("failed to create or update Settings object with externalId %s: %w", externalID, err)
+		return DynatraceEntity{}, fmt.Errorf("failed to create or update Settings object with objectId %s: %w", obj.OriginObjectId, err)
 	}
 
 	if !resp.IsSuccess() {
-		d.settingsCache.Delete(obj.SchemaId)
-		return DynatraceEntity{}, rest.NewRespErr(fmt.Sprintf("failed to create or update Settings object with externalId %s (HTTP %d)!\n\tResponse was: %s", externalID, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodPost, requestUrl)
+		return DynatraceEntity{}, rest.NewRespErr(fmt.Sprintf("failed to create or update Settings object with objectId %s (HTTP %d)!\n\tResponse was: %s", obj.OriginObjectId, resp.StatusCode, string(resp.Body)), resp).WithRequestInfo(http.MethodPost, requestUrl)
 	}
 
 	entity, err := parsePostResponse(resp)
@@ -294,10 +388,68 @@ func (d *DynatraceClient) upsertSettings(ctx context.Context, obj SettingsObject
 		return DynatraceEntity{}, rest.NewRespErr("failed to parse response", resp).WithRequestInfo(http.MethodPost, requestUrl).WithErr(err)
 	}
 
-	log.WithCtxFields(ctx).Debug("Created/Updated object %s (%s) with externalId %s", obj.Coordinate.ConfigId, obj.SchemaId, externalID)
+	log.WithCtxFields(ctx).Debug("Created/Updated object %s (%s) with externalId %q", obj.OriginObjectId, obj.SchemaId, externalID)
 	return entity, nil
 }
 
+func (d *DynatraceClient) FindSettingsObject(ctx context.Context, obj SettingsObject) (res DownloadSettingsObject, found bool, err error) {
+	d.limiter.ExecuteBlocking(func() {
+		res, found, err = d.findSettingsObject(ctx, obj)
+	})
+	return
+}
+
+// findSettingsObject mirrors the object identification of upsertSettings without modifying anything.
+// The object with the origin object ID takes precedence, followed by an object with the monaco external ID, an object
+// with the legacy external ID, and finally an object matching the schema's unique constraints.
+func (d *DynatraceClient) findSettingsObject(ctx context.Context, obj SettingsObject) (DownloadSettingsObject, bool, error) {
+	if existing, found, err := d.findSettingsObjectByOriginObjectID(ctx, obj); err != nil || found {
+		return existing, found, err
+	}
+
+	matchingObject, matchFound, err := d.findObjectWithMatchingConstraints(ctx, obj)
+	if err != nil {
+		return DownloadSettingsObject{}, false, err
+	}
+
+	// see upsertSettings - this object is identified by its key property only
+	if obj.SchemaId == "builtin:oneagent.features" {
+		return matchingObject.object, matchFound, nil
+	}
+
+	externalID, err := d.generateExternalID(obj.Coordinate)
+	if err != nil {
+		return DownloadSettingsObject{}, false, fmt.Errorf("unable to generate external id: %w", err)
+	}
+	legacyExternalID, err := d.generateExternalID(coordinate.Coordinate{Type: obj.Coordinate.Type, ConfigId: obj.Coordinate.ConfigId})
+	if err != nil {
+		return DownloadSettingsObject{}, false, fmt.Errorf("unable to generate external id: %w", err)
+	}
+
+	objects, err := d.listSettings(ctx, obj.SchemaId, ListSettingsOptions{})
+	if err != nil {
+		return DownloadSettingsObject{}, false, err
+	}
+
+	var byLegacyExternalID *DownloadSettingsObject
+	for i := range objects {
+		switch objects[i].ExternalId {
+		case externalID:
+			return objects[i], true, nil
+		case legacyExternalID:
+			byLegacyExternalID = &objects[i]
+		}
+	}
+
+	switch {
+	case byLegacyExternalID != nil:
+		return *byLegacyExternalID, true, nil
+	case matchFound:
+		return matchingObject.object, true, nil
+	}
+	return DownloadSettingsObject{}, false, nil
+}
+
 type match struct {
 	object  DownloadSettingsObject
 	matches constraintMatch
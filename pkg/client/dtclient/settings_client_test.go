@@ -28,6 +28,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -536,6 +537,22 @@ func TestUpsertSettings(t *testing.T) {
 			listSettingsResponseCode:    http.StatusOK,
 			listSettingsResponseContent: `{"items":[{"externalId":"monaco:YnVpbHRpbjphbGVydGluZy5wcm9maWxlJHVzZXItcHJvdmlkZWQtaWQ=","objectId":"ORIGIN_OBJECT_ID","scope":"tenant"}]}`,
 		},
+		{
+			name:                       "Object with origin object ID takes precedence over other objects",
+			serverVersion:              version.Version{Major: 1, Minor: 262, Patch: 0},
+			expectSettingsRequestValue: "{}",
+			expectOriginObjectID:       "anObjectID",
+			expectError:                false,
+			expectEntity: DynatraceEntity{
+				Id:   "anObjectID",
+				Name: "anObjectID",
+			},
+			postSettingsResponseContent: `[{"objectId": "anObjectID"}]`,
+			getSettingsResponseCode:     http.StatusOK,
+			getSettingsResponseContent:  fmt.Sprintf(`{"externalId":%q,"objectId":"anObjectID","schemaId":"builtin:alerting.profile","scope":"tenant"}`, exId),
+			listSettingsResponseCode:    http.StatusOK,
+			listSettingsResponseContent: `{"items":[{"externalId":"monaco:YnVpbHRpbjphbGVydGluZy5wcm9maWxlJHVzZXItcHJvdmlkZWQtaWQ=","objectId":"LEGACY_OBJECT_ID","scope":"tenant"}]}`,
+		},
 		{
 			name:                        "Valid request, invalid response",
 			expectSettingsRequestValue:  "{}",
@@ -604,6 +621,10 @@ func TestUpsertSettings(t *testing.T) {
 				if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v2/settings/objects") {
 					// GET single settings obj request
 					if len(strings.TrimPrefix(r.URL.Path, "/api/v2/settings/objects")) > 0 {
+						if test.getSettingsResponseCode == 0 {
+							writer.WriteHeader(http.StatusNotFound)
+							return
+						}
 						writer.WriteHeader(test.getSettingsResponseCode)
 						writer.Write([]byte(test.getSettingsResponseContent))
 						return
@@ -702,6 +723,36 @@ func TestUpsertSettingsRetries(t *testing.T) {
 	assert.Equal(t, numAPICalls, 3)
 }
 
+func TestUpsertSettingsByObjectID(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method, "objects must not be searched before they are updated")
+
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"schemaId":"some:schema","scope":"environment","value":{"name":"old"},"externalId":"original-external-id","objectId":"object-id"}]`, string(body))
+
+		rw.WriteHeader(200)
+		_, _ = rw.Write([]byte(`[{"objectId": "object-id"}]`))
+	}))
+	defer server.Close()
+
+	restClient := rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy())
+	client, _ := NewClassicClient(server.URL, restClient,
+		WithRetrySettings(testRetrySettings),
+		WithClientRequestLimiter(concurrency.NewLimiter(5)),
+		WithExternalIDGenerator(idutils.GenerateExternalID))
+
+	entity, err := client.UpsertSettingsByObjectID(context.TODO(), SettingsObject{
+		SchemaId:       "some:schema",
+		Scope:          "environment",
+		Content:        []byte(`{"name":"old"}`),
+		OriginObjectId: "object-id",
+	}, "original-external-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "object-id", entity.Id)
+}
+
 func TestUpsertSettingsFromCache(t *testing.T) {
 	numAPIGetCalls := 0
 	numAPIPostCalls := 0
@@ -1577,7 +1628,7 @@ func TestGetSettingById(t *testing.T) {
 				WithClientRequestLimiter(concurrency.NewLimiter(5)),
 				WithExternalIDGenerator(idutils.GenerateExternalID))
 
-			settingsObj, err := client.GetSettingById(tt.args.objectID)
+			settingsObj, err := client.GetSettingById(context.TODO(), tt.args.objectID)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -1671,9 +1722,115 @@ func TestDeleteSettings(t *testing.T) {
 				WithClientRequestLimiter(concurrency.NewLimiter(5)),
 				WithExternalIDGenerator(idutils.GenerateExternalID))
 
-			if err := client.DeleteSettings(tt.args.objectID); (err != nil) != tt.wantErr {
+			if err := client.DeleteSettings(context.TODO(), tt.args.objectID); (err != nil) != tt.wantErr {
 				t.Errorf("DeleteSettings() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestFindSettingsObject(t *testing.T) {
+	coord := coordinate.Coordinate{Project: "project", Type: "some:schema", ConfigId: "id"}
+	externalID, _ := idutils.GenerateExternalID(coord)
+	legacyExternalID, _ := idutils.GenerateExternalID(coordinate.Coordinate{Type: "some:schema", ConfigId: "id"})
+
+	tests := []struct {
+		name           string
+		originObjectID string
+		objectByID     string
+		items          string
+		wantFound      bool
+		wantObjectID   string
+	}{
+		{
+			name:      "no objects",
+			items:     `[]`,
+			wantFound: false,
+		},
+		{
+			name:         "found by external ID",
+			items:        fmt.Sprintf(`[{"objectId": "other", "externalId": "monaco:abc"}, {"objectId": "obj-1", "externalId": %q}]`, externalID),
+			wantFound:    true,
+			wantObjectID: "obj-1",
+		},
+		{
+			name:         "found by legacy external ID",
+			items:        fmt.Sprintf(`[{"objectId": "obj-2", "externalId": %q}]`, legacyExternalID),
+			wantFound:    true,
+			wantObjectID: "obj-2",
+		},
+		{
+			name:           "found by origin object ID",
+			originObjectID: "obj-3",
+			objectByID:     `{"objectId": "obj-3", "schemaId": "some:schema"}`,
+			items:          `[]`,
+			wantFound:      true,
+			wantObjectID:   "obj-3",
+		},
+		{
+			name:           "origin object ID takes precedence over external ID",
+			originObjectID: "obj-3",
+			objectByID:     `{"objectId": "obj-3", "schemaId": "some:schema"}`,
+			items:          fmt.Sprintf(`[{"objectId": "obj-3"}, {"objectId": "obj-1", "externalId": %q}]`, externalID),
+			wantFound:      true,
+			wantObjectID:   "obj-3",
+		},
+		{
+			name:           "falls back to external ID if origin object does not exist",
+			originObjectID: "obj-3",
+			items:          fmt.Sprintf(`[{"objectId": "obj-1", "externalId": %q}]`, externalID),
+			wantFound:      true,
+			wantObjectID:   "obj-1",
+		},
+		{
+			name:           "origin object of other schema is ignored",
+			originObjectID: "obj-3",
+			objectByID:     `{"objectId": "obj-3", "schemaId": "other:schema"}`,
+			items:          `[]`,
+			wantFound:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == settingsSchemaAPIPathClassic+"/some:schema" {
+					rw.WriteHeader(http.StatusOK)
+					rw.Write([]byte("{}"))
+					return
+				}
+				if req.Method != http.MethodGet {
+					t.Fatalf("unexpected %s request - FindSettingsObject must not modify objects", req.Method)
+				}
+				if strings.HasPrefix(req.URL.Path, settingsObjectAPIPathClassic+"/") {
+					if tt.objectByID == "" {
+						rw.WriteHeader(http.StatusNotFound)
+						return
+					}
+					rw.WriteHeader(http.StatusOK)
+					rw.Write([]byte(tt.objectByID))
+					return
+				}
+				rw.WriteHeader(http.StatusOK)
+				rw.Write([]byte(fmt.Sprintf(`{"items": %s}`, tt.items)))
+			}))
+			defer server.Close()
+
+			restClient := rest.NewRestClient(server.Client(), nil, rest.CreateRateLimitStrategy())
+			client, _ := NewClassicClient(server.URL, restClient,
+				WithRetrySettings(testRetrySettings),
+				WithClientRequestLimiter(concurrency.NewLimiter(5)),
+				WithExternalIDGenerator(idutils.GenerateExternalID))
+
+			got, found, err := client.FindSettingsObject(context.TODO(), SettingsObject{
+				Coordinate:     coord,
+				SchemaId:       "some:schema",
+				Content:        []byte("{}"),
+				OriginObjectId: tt.originObjectID,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantObjectID, got.ObjectId)
+		})
+	}
+}
@@ -22,12 +22,20 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	configErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	compoundParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
+	entityParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/entity"
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	expressionParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/expression"
+	fileParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/file"
 	listParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
+	lookupParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/lookup"
+	manifestParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/manifest"
 	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	sopsParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/sops"
 	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	vaultParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/vault"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 )
 
@@ -148,6 +156,27 @@ type Config struct {
 
 	// OriginObjectId is the DT object ID of the object when it was downloaded from an environment
 	OriginObjectId string
+
+	// Lifecycle defines how the object of this config is managed once it exists
+	Lifecycle Lifecycle
+
+	// Hooks are run before and after this config is deployed
+	Hooks hook.Hooks
+
+	// DependsOn are the coordinates of configs that must be deployed before this config, although it does not reference
+	// any of their properties
+	DependsOn []coordinate.Coordinate
+}
+
+// Lifecycle defines how the object of a config is managed once it exists on an environment
+type Lifecycle struct {
+	// CreateOnly states that the object is created if it does not exist, but never updated afterwards
+	CreateOnly bool
+	// IgnoreChanges holds the paths of JSON fields whose values are kept as they are on the environment when an existing
+	// object is updated. Path segments are separated by dots, and numeric segments index arrays.
+	IgnoreChanges []string
+	// PreventDestroy states that the object must never be deleted by monaco
+	PreventDestroy bool
 }
 
 func (c *Config) Render(properties map[string]interface{}) (string, error) {
@@ -202,6 +231,13 @@ var DefaultParameterParsers = map[string]parameter.ParameterSerDe{
 	envParam.EnvironmentVariableParameterType: envParam.EnvironmentVariableParameterSerde,
 	compoundParam.CompoundParameterType:       compoundParam.CompoundParameterSerde,
 	listParam.ListParameterType:               listParam.ListParameterSerde,
+	fileParam.FileParameterType:               fileParam.FileParameterSerde,
+	sopsParam.SopsParameterType:               sopsParam.SopsParameterSerde,
+	vaultParam.VaultParameterType:             vaultParam.VaultParameterSerde,
+	entityParam.EntityParameterType:           entityParam.EntityParameterSerde,
+	lookupParam.LookupParameterType:           lookupParam.LookupParameterSerde,
+	expressionParam.ExpressionParameterType:   expressionParam.ExpressionParameterSerde,
+	manifestParam.ManifestParameterType:       manifestParam.ManifestParameterSerde,
 }
 
 func (c *Config) References() []coordinate.Coordinate {
@@ -214,7 +250,7 @@ func (c *Config) References() []coordinate.Coordinate {
 		count += len(p.GetReferences())
 	}
 
-	refs := make([]coordinate.Coordinate, 0, count)
+	refs := make([]coordinate.Coordinate, 0, count+len(c.DependsOn))
 	for _, p := range c.Parameters {
 		references := p.GetReferences()
 		for i := range references {
@@ -222,7 +258,7 @@ func (c *Config) References() []coordinate.Coordinate {
 		}
 	}
 
-	return refs
+	return append(refs, c.DependsOn...)
 }
 
 // EntityLookup is used in parameter resolution to fetch the resolved entity of deployed configuration
@@ -235,7 +271,9 @@ type EntityLookup interface {
 // ResolveParameterValues will resolve the values of all config.Parameters of a config.Config and return them as a parameter.Properties map.
 // Resolving will ensure that parameters are resolved in the right order if they have dependencies between each other.
 // To be able to resolve reference.ReferenceParameter values an EntityLookup needs to be provided, which contains all
-// config.ResolvedEntity values of configurations that the config.Config could depend on.
+// config.ResolvedEntity values of configurations that the config.Config could depend on. If the EntityLookup is a
+// parameter.MonitoredEntityFinder or parameter.RemoteObjectFinder as well, it is used to resolve entity.EntityParameter and
+// lookup.LookupParameter values.
 // Ordering of configurations to ensure that possible dependency configurations are contained in teh EntityLookup is responsibility
 // of the caller of ResolveParameterValues.
 //
@@ -29,6 +29,10 @@ type ResolvedEntity struct {
 	// can differ.
 	EntityName string
 
+	// ObjectID is the identifier of the object on the Dynatrace environment. It can differ from the `id` property,
+	// e.g. for management zone Settings 2.0 objects, whose `id` property is the numeric ID of the management zone.
+	ObjectID string
+
 	// Coordinate of the config.Config this entity represents
 	Coordinate coordinate.Coordinate
 
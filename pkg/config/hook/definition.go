@@ -0,0 +1,178 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hook
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Definition is a Hook as it is defined in manifests and config files
+type Definition struct {
+	Command []string               `yaml:"command,omitempty" json:"command,omitempty" jsonschema:"description=The local command to run, followed by its arguments, e.g. ['./smoke-test.sh', '--fast']. It is not run by a shell. The deployment is described by the environment variables MONACO_HOOK_PHASE, MONACO_ENVIRONMENT, MONACO_ENVIRONMENT_GROUP, and for configs MONACO_PROJECT, MONACO_CONFIG_TYPE, MONACO_CONFIG_ID and MONACO_OBJECT_ID."`
+	URL     string                 `yaml:"url,omitempty" json:"url,omitempty" jsonschema:"description=The URL of an HTTP webhook. The deployment is described by a JSON body sent with a POST request. The hook fails unless the webhook responds with a 2xx status code."`
+	Headers map[string]HeaderValue `yaml:"headers,omitempty" json:"headers,omitempty" jsonschema:"description=Headers sent with the request to the webhook. As they usually contain credentials, their values can be loaded from environment variables."`
+	Timeout string                 `yaml:"timeout,omitempty" json:"timeout,omitempty" jsonschema:"description=The maximum duration of the hook, e.g. '5m'. By default, the hook times out after 30 seconds."`
+}
+
+// HeaderValue is the value of a webhook header. It is either defined directly as a string, or loaded from an environment
+// variable when the webhook is called, e.g. {type: environment, value: WEBHOOK_TOKEN}.
+type HeaderValue struct {
+	Type  string `yaml:"type,omitempty" json:"type,omitempty" jsonschema:"enum=environment,enum=value,description=The type of this value - either an 'environment' variable to read, or simply a 'value' directly in the YAML."`
+	Value string `yaml:"value" json:"value" jsonschema:"required,description=The value is depending on 'type' either the name of an environment variable to load or just a string value."`
+}
+
+const (
+	// TypeValue marks header values defined directly
+	TypeValue = "value"
+	// TypeEnvironment marks header values loaded from an environment variable
+	TypeEnvironment = "environment"
+)
+
+// UnmarshalYAML parses header values defined directly as a string, as well as full values
+func (v *HeaderValue) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		*v = HeaderValue{Type: TypeValue, Value: s}
+		return nil
+	}
+
+	type plain HeaderValue
+	return unmarshal((*plain)(v))
+}
+
+// MarshalYAML writes header values defined directly as a string
+func (v HeaderValue) MarshalYAML() (any, error) {
+	if v.Type == "" || v.Type == TypeValue {
+		return v.Value, nil
+	}
+
+	type plain HeaderValue
+	return plain(v), nil
+}
+
+// Resolve returns the value of the header. Values loaded from environment variables are registered as secrets.
+func (v HeaderValue) Resolve() (string, error) {
+	if v.Type != TypeEnvironment {
+		return v.Value, nil
+	}
+
+	val, found := os.LookupEnv(v.Value)
+	if !found {
+		return "", fmt.Errorf("environment variable %q could not be found", v.Value)
+	}
+	if val == "" {
+		return "", fmt.Errorf("environment variable %q is defined but has no value", v.Value)
+	}
+	secret.Register(val)
+	return val, nil
+}
+
+// ValidateHeaders returns an error if one of the header values has an unknown type, or does not name the environment
+// variable to load
+func ValidateHeaders(headers map[string]HeaderValue) error {
+	for name, v := range headers {
+		if v.Type != "" && v.Type != TypeValue && v.Type != TypeEnvironment {
+			return fmt.Errorf("header %q has unknown type %q - expected %q or %q", name, v.Type, TypeValue, TypeEnvironment)
+		}
+		if v.Type == TypeEnvironment && v.Value == "" {
+			return fmt.Errorf("header %q must name the environment variable to load", name)
+		}
+	}
+	return nil
+}
+
+// Definitions are Hooks as they are defined in manifests and config files
+type Definitions struct {
+	PreDeploy  []Definition `yaml:"preDeploy,omitempty" json:"preDeploy,omitempty" jsonschema:"description=Hooks run before the deployment. If one of them fails, nothing is deployed."`
+	PostDeploy []Definition `yaml:"postDeploy,omitempty" json:"postDeploy,omitempty" jsonschema:"description=Hooks run after a successful deployment. If one of them fails, the deployment fails."`
+}
+
+// Parse returns the Hooks of the given Definitions
+func Parse(d Definitions) (Hooks, error) {
+	pre, preErr := parseAll(d.PreDeploy, PreDeploy)
+	post, postErr := parseAll(d.PostDeploy, PostDeploy)
+	if err := errors.Join(preErr, postErr); err != nil {
+		return Hooks{}, err
+	}
+	return Hooks{PreDeploy: pre, PostDeploy: post}, nil
+}
+
+func parseAll(definitions []Definition, p Phase) ([]Hook, error) {
+	var hooks []Hook
+	var errs []error
+	for i, d := range definitions {
+		h, err := parse(d)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s hook on index `%d`: %w", p, i, err))
+			continue
+		}
+		hooks = append(hooks, h)
+	}
+	return hooks, errors.Join(errs...)
+}
+
+func parse(d Definition) (Hook, error) {
+	if (len(d.Command) > 0) == (d.URL != "") {
+		return Hook{}, errors.New("exactly one of `command` and `url` must be set")
+	}
+	if len(d.Command) > 0 && d.Command[0] == "" {
+		return Hook{}, errors.New("`command` must not start with an empty string")
+	}
+	if len(d.Headers) > 0 && d.URL == "" {
+		return Hook{}, errors.New("`headers` can only be set for webhooks")
+	}
+	if err := ValidateHeaders(d.Headers); err != nil {
+		return Hook{}, err
+	}
+	if d.URL != "" {
+		if u, err := url.ParseRequestURI(d.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return Hook{}, fmt.Errorf("%q is not a valid HTTP(S) URL", d.URL)
+		}
+	}
+
+	var timeout time.Duration
+	if d.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(d.Timeout); err != nil || timeout < 0 {
+			return Hook{}, fmt.Errorf("invalid timeout %q - expected a duration like '30s'", d.Timeout)
+		}
+	}
+
+	return Hook{Command: d.Command, URL: d.URL, Headers: d.Headers, Timeout: timeout}, nil
+}
+
+// ToDefinitions returns the Definitions of the given Hooks
+func ToDefinitions(h Hooks) Definitions {
+	return Definitions{PreDeploy: toDefinitions(h.PreDeploy), PostDeploy: toDefinitions(h.PostDeploy)}
+}
+
+func toDefinitions(hooks []Hook) []Definition {
+	var definitions []Definition
+	for _, h := range hooks {
+		d := Definition{Command: h.Command, URL: h.URL, Headers: h.Headers}
+		if h.Timeout > 0 {
+			d.Timeout = h.Timeout.String()
+		}
+		definitions = append(definitions, d)
+	}
+	return definitions
+}
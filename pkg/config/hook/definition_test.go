@@ -0,0 +1,82 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hook_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	hooks, err := hook.Parse(hook.Definitions{
+		PreDeploy:  []hook.Definition{{Command: []string{"./script.sh", "arg"}, Timeout: "30s"}},
+		PostDeploy: []hook.Definition{{URL: "https://example.com/hook", Headers: map[string]hook.HeaderValue{"Authorization": {Value: "Bearer token"}}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, hook.Hooks{
+		PreDeploy:  []hook.Hook{{Command: []string{"./script.sh", "arg"}, Timeout: 30 * time.Second}},
+		PostDeploy: []hook.Hook{{URL: "https://example.com/hook", Headers: map[string]hook.HeaderValue{"Authorization": {Value: "Bearer token"}}}},
+	}, hooks)
+
+	assert.Equal(t, hook.Definitions{
+		PreDeploy:  []hook.Definition{{Command: []string{"./script.sh", "arg"}, Timeout: "30s"}},
+		PostDeploy: []hook.Definition{{URL: "https://example.com/hook", Headers: map[string]hook.HeaderValue{"Authorization": {Value: "Bearer token"}}}},
+	}, hook.ToDefinitions(hooks))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition hook.Definition
+		wantErr    string
+	}{
+		{"neither command nor url", hook.Definition{}, "exactly one of `command` and `url` must be set"},
+		{"command and url", hook.Definition{Command: []string{"cmd"}, URL: "https://example.com"}, "exactly one of `command` and `url` must be set"},
+		{"empty command", hook.Definition{Command: []string{""}}, "must not start with an empty string"},
+		{"headers of command", hook.Definition{Command: []string{"cmd"}, Headers: map[string]hook.HeaderValue{"a": {Value: "b"}}}, "`headers` can only be set for webhooks"},
+		{"unknown header type", hook.Definition{URL: "https://example.com", Headers: map[string]hook.HeaderValue{"a": {Type: "file", Value: "b"}}}, `header "a" has unknown type "file"`},
+		{"header without environment variable", hook.Definition{URL: "https://example.com", Headers: map[string]hook.HeaderValue{"a": {Type: hook.TypeEnvironment}}}, `header "a" must name the environment variable to load`},
+		{"invalid url", hook.Definition{URL: "example.com"}, `"example.com" is not a valid HTTP(S) URL`},
+		{"invalid timeout", hook.Definition{Command: []string{"cmd"}, Timeout: "soon"}, `invalid timeout "soon"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := hook.Parse(hook.Definitions{PostDeploy: []hook.Definition{tt.definition}})
+			assert.ErrorContains(t, err, "invalid post-deploy hook on index `0`")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestHeaderValue_YAML(t *testing.T) {
+	var d hook.Definition
+	err := yaml.Unmarshal([]byte("url: https://example.com\nheaders:\n  X-Plain: plain\n  Authorization:\n    type: environment\n    value: HOOK_TOKEN\n"), &d)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]hook.HeaderValue{
+		"X-Plain":       {Type: hook.TypeValue, Value: "plain"},
+		"Authorization": {Type: hook.TypeEnvironment, Value: "HOOK_TOKEN"},
+	}, d.Headers)
+
+	out, err := yaml.Marshal(d.Headers)
+	assert.NoError(t, err)
+	assert.Equal(t, "Authorization:\n  type: environment\n  value: HOOK_TOKEN\nX-Plain: plain\n", string(out))
+}
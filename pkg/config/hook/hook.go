@@ -0,0 +1,79 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package hook defines custom steps run before and after deployments to environments and of single configs. A hook is
+// either a local command, or an HTTP webhook.
+package hook
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Phase describes when a hook is run
+type Phase string
+
+const (
+	// PreDeploy hooks run before the deployment
+	PreDeploy Phase = "pre-deploy"
+	// PostDeploy hooks run after a successful deployment
+	PostDeploy Phase = "post-deploy"
+)
+
+// Hook is a single custom step of a deployment. Exactly one of Command and URL is set.
+type Hook struct {
+	// Command is the local command to run, followed by its arguments. It is run directly, not by a shell.
+	Command []string
+	// URL of the HTTP webhook to call
+	URL string
+	// Headers are sent with the webhook request. Values loaded from environment variables are resolved when the webhook
+	// is called.
+	Headers map[string]HeaderValue
+	// Timeout is the maximum duration of the hook. If it is 0, DefaultTimeout applies.
+	Timeout time.Duration
+}
+
+// DefaultTimeout is the maximum duration of hooks that do not define a timeout
+const DefaultTimeout = 30 * time.Second
+
+func (h Hook) String() string {
+	if len(h.Command) > 0 {
+		return fmt.Sprintf("command %q", strings.Join(h.Command, " "))
+	}
+	return fmt.Sprintf("webhook %q", h.URL)
+}
+
+// Hooks are the hooks run before and after a deployment
+type Hooks struct {
+	// PreDeploy hooks run before the deployment. If one of them fails, nothing is deployed.
+	PreDeploy []Hook
+	// PostDeploy hooks run after a successful deployment
+	PostDeploy []Hook
+}
+
+// IsEmpty returns true if no hooks are defined
+func (h Hooks) IsEmpty() bool {
+	return len(h.PreDeploy) == 0 && len(h.PostDeploy) == 0
+}
+
+// Of returns the hooks of the given Phase
+func (h Hooks) Of(p Phase) []Hook {
+	if p == PreDeploy {
+		return h.PreDeploy
+	}
+	return h.PostDeploy
+}
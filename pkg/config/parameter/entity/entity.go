@@ -0,0 +1,136 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	stdStrings "strings"
+)
+
+// EntityParameterType specifies the type of the parameter used in config files
+const EntityParameterType = "entity"
+
+// maxListedEntities limits how many matching entity IDs are listed in errors
+const maxListedEntities = 5
+
+var EntityParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeEntityParameter,
+	Deserializer: parseEntityParameter,
+}
+
+// EntityParameter defines a parameter resolving to the IDs of the monitored entities matching an entity selector on the
+// environment the config is deployed to, e.g. `type(HOST),entityName.equals("my-host")`.
+// By default, the selector must match exactly one entity, and the parameter resolves to its ID. If List is set, the
+// selector may match any number of entities but at least one, and the parameter resolves to a JSON list of their IDs.
+type EntityParameter struct {
+	// Selector is the entity selector used to find the entities
+	Selector string
+
+	// List defines whether the parameter resolves to a list of IDs instead of a single ID
+	List bool
+}
+
+func New(selector string, list bool) *EntityParameter {
+	return &EntityParameter{
+		Selector: selector,
+		List:     list,
+	}
+}
+
+// this forces the compiler to check if EntityParameter is of type Parameter
+var _ parameter.Parameter = (*EntityParameter)(nil)
+
+func (p *EntityParameter) GetType() string {
+	return EntityParameterType
+}
+
+func (p *EntityParameter) GetReferences() []parameter.ParameterReference {
+	// entity parameters cannot have references
+	return []parameter.ParameterReference{}
+}
+
+func (p *EntityParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	if context.MonitoredEntityFinder == nil {
+		return nil, parameter.NewParameterResolveValueError(context, "monitored entities can not be looked up")
+	}
+
+	ids, err := context.MonitoredEntityFinder.FindMonitoredEntities(p.Selector)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to find entities matching `%s`: %s", p.Selector, err))
+	}
+
+	if len(ids) == 0 {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("no entity matches `%s`", p.Selector))
+	}
+
+	if p.List {
+		quoted := make([]string, len(ids))
+		for i, id := range ids {
+			quoted[i] = fmt.Sprintf(`"%s"`, id)
+		}
+		return fmt.Sprintf("[ %s ]", stdStrings.Join(quoted, ",")), nil
+	}
+
+	if len(ids) > 1 {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("%d entities match `%s`, but exactly one is required: %s. Refine the selector, or set `list: true` to resolve all of them", len(ids), p.Selector, listed(ids)))
+	}
+	return ids[0], nil
+}
+
+// listed joins the first few of the given IDs for use in error messages
+func listed(ids []string) string {
+	if len(ids) <= maxListedEntities {
+		return stdStrings.Join(ids, ", ")
+	}
+	return fmt.Sprintf("%s, and %d more", stdStrings.Join(ids[:maxListedEntities], ", "), len(ids)-maxListedEntities)
+}
+
+// parseEntityParameter parses an EntityParameter from a given context. it requires a `selector` field to be set, and
+// accepts an optional boolean `list` field.
+func parseEntityParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	selector, ok := context.Value["selector"]
+	if !ok || strings.ToString(selector) == "" {
+		return nil, parameter.NewParameterParserError(context, "missing property `selector`")
+	}
+
+	list := false
+	if val, ok := context.Value["list"]; ok {
+		if list, ok = val.(bool); !ok {
+			return nil, parameter.NewParameterParserError(context, fmt.Sprintf("property `list` must be a boolean, but is `%v`", val))
+		}
+	}
+
+	return New(strings.ToString(selector), list), nil
+}
+
+func writeEntityParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	entityParam, ok := context.Parameter.(*EntityParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `EntityParameter`")
+	}
+
+	result := map[string]interface{}{
+		"selector": entityParam.Selector,
+	}
+	if entityParam.List {
+		result["list"] = true
+	}
+	return result, nil
+}
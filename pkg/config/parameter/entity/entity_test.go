@@ -0,0 +1,106 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity_test
+
+import (
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type finder map[string][]string
+
+func (f finder) FindMonitoredEntities(entitySelector string) ([]string, error) {
+	if ids, found := f[entitySelector]; found {
+		return ids, nil
+	}
+	return nil, errors.New("invalid selector")
+}
+
+var givenFinder = finder{
+	"type(HOST)":    {"HOST-1", "HOST-2", "HOST-3", "HOST-4", "HOST-5", "HOST-6"},
+	"type(SERVICE)": {"SERVICE-1"},
+	"type(PROCESS)": {},
+}
+
+func TestParseEntityParameter(t *testing.T) {
+	p, err := entity.EntityParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value: map[string]interface{}{"selector": "type(HOST)"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.New("type(HOST)", false), p)
+
+	p, err = entity.EntityParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value: map[string]interface{}{"selector": "type(HOST)", "list": true},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.New("type(HOST)", true), p)
+
+	_, err = entity.EntityParameterSerde.Deserializer(parameter.ParameterParserContext{Value: map[string]interface{}{}})
+	assert.ErrorContains(t, err, "missing property `selector`")
+
+	_, err = entity.EntityParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value: map[string]interface{}{"selector": "type(HOST)", "list": "yes"},
+	})
+	assert.ErrorContains(t, err, "property `list` must be a boolean")
+}
+
+func TestWriteEntityParameter(t *testing.T) {
+	result, err := entity.EntityParameterSerde.Serializer(parameter.ParameterWriterContext{Parameter: entity.New("type(HOST)", false)})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"selector": "type(HOST)"}, result)
+
+	result, err = entity.EntityParameterSerde.Serializer(parameter.ParameterWriterContext{Parameter: entity.New("type(HOST)", true)})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"selector": "type(HOST)", "list": true}, result)
+}
+
+func TestResolveValue(t *testing.T) {
+	context := parameter.ResolveContext{MonitoredEntityFinder: givenFinder}
+
+	val, err := entity.New("type(SERVICE)", false).ResolveValue(context)
+	assert.NoError(t, err)
+	assert.Equal(t, "SERVICE-1", val)
+
+	val, err = entity.New("type(SERVICE)", true).ResolveValue(context)
+	assert.NoError(t, err)
+	assert.Equal(t, `[ "SERVICE-1" ]`, val)
+
+	val, err = entity.New("type(HOST)", true).ResolveValue(context)
+	assert.NoError(t, err)
+	assert.Equal(t, `[ "HOST-1","HOST-2","HOST-3","HOST-4","HOST-5","HOST-6" ]`, val)
+}
+
+func TestResolveValue_Errors(t *testing.T) {
+	context := parameter.ResolveContext{MonitoredEntityFinder: givenFinder}
+
+	_, err := entity.New("type(HOST)", false).ResolveValue(context)
+	assert.ErrorContains(t, err, "6 entities match `type(HOST)`, but exactly one is required: HOST-1, HOST-2, HOST-3, HOST-4, HOST-5, and 1 more")
+
+	_, err = entity.New("type(PROCESS)", true).ResolveValue(context)
+	assert.ErrorContains(t, err, "no entity matches `type(PROCESS)`")
+
+	_, err = entity.New("invalid", false).ResolveValue(context)
+	assert.ErrorContains(t, err, "failed to find entities matching `invalid`: invalid selector")
+
+	_, err = entity.New("type(SERVICE)", false).ResolveValue(parameter.ResolveContext{})
+	assert.ErrorContains(t, err, "monitored entities can not be looked up")
+}
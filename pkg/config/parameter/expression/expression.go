@@ -0,0 +1,230 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expression
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/google/go-cmp/cmp"
+	templ "text/template" // nosemgrep: go.lang.security.audit.xss.import-text-template.import-text-template
+)
+
+// ExpressionParameterType specifies the type of the parameter used in config files
+const ExpressionParameterType = "expression"
+
+var ExpressionParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeExpressionParameter,
+	Deserializer: parseExpressionParameter,
+}
+
+// Reference is a parameter referenced by an expression, and the Name it is available as in the expression
+type Reference struct {
+	parameter.ParameterReference
+	// Name of the referenced value in the expression, e.g. `{{ .name }}`
+	Name string
+}
+
+// ExpressionParameter is a parameter whose value is computed by a Go template expression over referenced parameters.
+// Parameters of the same config and of other configs can be referenced. Next to the builtin template functions and
+// conditionals, a small library of side effect free functions is available, see functions.
+type ExpressionParameter struct {
+	expression    *templ.Template
+	rawExpression string
+	references    []Reference
+}
+
+func New(name string, expression string, references []Reference) (*ExpressionParameter, error) {
+	tmpl, err := templ.New(name).Option("missingkey=error").Funcs(functions).Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExpressionParameter{
+		expression:    tmpl,
+		rawExpression: expression,
+		references:    references,
+	}, nil
+}
+
+// this forces the compiler to check if ExpressionParameter is of type Parameter
+var _ parameter.Parameter = (*ExpressionParameter)(nil)
+
+func (p *ExpressionParameter) GetType() string {
+	return ExpressionParameterType
+}
+
+func (p *ExpressionParameter) GetReferences() []parameter.ParameterReference {
+	refs := make([]parameter.ParameterReference, len(p.references))
+	for i, r := range p.references {
+		refs[i] = r.ParameterReference
+	}
+	return refs
+}
+
+func (p *ExpressionParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	data := make(map[string]interface{}, len(p.references))
+
+	for _, ref := range p.references {
+		val, err := resolveReference(context, ref.ParameterReference)
+		if err != nil {
+			return nil, err
+		}
+		data[ref.Name] = val
+	}
+
+	out := bytes.Buffer{}
+	if err := p.expression.Execute(&out, data); err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("error evaluating expression: %s", err))
+	}
+
+	return template.EscapeSpecialCharactersInValue(out.String(), template.FullStringEscapeFunction)
+}
+
+func resolveReference(context parameter.ResolveContext, ref parameter.ParameterReference) (interface{}, error) {
+	if context.ConfigCoordinate.Match(ref.Config) {
+		if val, found := context.ResolvedParameterValues[ref.Property]; found {
+			return val, nil
+		}
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("referenced parameter `%s` has not been resolved yet or does not exist", ref.Property))
+	}
+
+	if context.PropertyResolver == nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("cannot resolve reference %s: no PropertyResolver is defined", ref))
+	}
+
+	if val, found := context.PropertyResolver.GetResolvedProperty(ref.Config, ref.Property); found {
+		return val, nil
+	}
+	return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("cannot resolve reference %s: config has not been resolved yet or does not exist", ref))
+}
+
+// Equal is required to compare two ExpressionParameter without opening all fields.
+func (p *ExpressionParameter) Equal(o *ExpressionParameter) bool {
+	return p.rawExpression == o.rawExpression && cmp.Equal(p.references, o.references)
+}
+
+// parseExpressionParameter parses a given context into an instance of ExpressionParameter.
+// This requires a string `expression`, and an optional list of `references`. Each reference is either the name of a
+// parameter of the same config, or a map referencing a property of another config by `project`, `configType`,
+// `configId` and `property`, which is available in the expression by its `name` (default: the property).
+func parseExpressionParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	expression, ok := context.Value["expression"]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "missing property `expression`")
+	}
+
+	var references []Reference
+	if val, ok := context.Value["references"]; ok {
+		refs, ok := val.([]interface{})
+		if !ok {
+			return nil, parameter.NewParameterParserError(context, "malformed value `references`")
+		}
+
+		for _, r := range refs {
+			ref, err := toReference(r, context.Coordinate)
+			if err != nil {
+				return nil, parameter.NewParameterParserError(context, fmt.Sprintf("invalid parameter reference: %s", err))
+			}
+			references = append(references, ref)
+		}
+	}
+
+	p, err := New(context.ParameterName, strings.ToString(expression), references)
+	if err != nil {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("invalid expression: %s", err))
+	}
+	return p, nil
+}
+
+func toReference(r interface{}, coord coordinate.Coordinate) (Reference, error) {
+	switch r := r.(type) {
+	case map[interface{}]interface{}:
+		m := maps.ToStringMap(r)
+
+		v, ok := m["property"]
+		if !ok {
+			return Reference{}, fmt.Errorf("missing `property`")
+		}
+		property := strings.ToString(v)
+
+		ref := coord
+		if v, ok := m["project"]; ok {
+			ref.Project = strings.ToString(v)
+		}
+		if v, ok := m["configType"]; ok {
+			ref.Type = strings.ToString(v)
+		}
+		if v, ok := m["configId"]; ok {
+			ref.ConfigId = strings.ToString(v)
+		}
+
+		name := property
+		if v, ok := m["name"]; ok {
+			name = strings.ToString(v)
+		}
+
+		return Reference{ParameterReference: parameter.ParameterReference{Config: ref, Property: property}, Name: name}, nil
+	case []interface{}:
+		return Reference{}, fmt.Errorf("%v is neither a string nor a map", r)
+	default:
+		property := strings.ToString(r)
+		return Reference{ParameterReference: parameter.ParameterReference{Config: coord, Property: property}, Name: property}, nil
+	}
+}
+
+func writeExpressionParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	expressionParam, ok := context.Parameter.(*ExpressionParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `ExpressionParameter`")
+	}
+
+	result := map[string]interface{}{
+		"expression": expressionParam.rawExpression,
+	}
+
+	if len(expressionParam.references) == 0 {
+		return result, nil
+	}
+
+	references := make([]interface{}, len(expressionParam.references))
+	for i, ref := range expressionParam.references {
+		if ref.Config == context.Coordinate && ref.Name == ref.Property {
+			references[i] = ref.Property
+			continue
+		}
+
+		m := map[string]interface{}{
+			"project":    ref.Config.Project,
+			"configType": ref.Config.Type,
+			"configId":   ref.Config.ConfigId,
+			"property":   ref.Property,
+		}
+		if ref.Name != ref.Property {
+			m["name"] = ref.Name
+		}
+		references[i] = m
+	}
+	result["references"] = references
+
+	return result, nil
+}
@@ -0,0 +1,183 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expression_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/expression"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+var coord = coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "config"}
+var otherCoord = coordinate.Coordinate{Project: "other", Type: "alerting-profile", ConfigId: "profile"}
+
+type propertyResolver map[coordinate.Coordinate]map[string]any
+
+func (r propertyResolver) GetResolvedProperty(c coordinate.Coordinate, property string) (any, bool) {
+	v, found := r[c][property]
+	return v, found
+}
+
+func parse(t *testing.T, value map[string]interface{}) *expression.ExpressionParameter {
+	p, err := expression.ExpressionParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Coordinate:    coord,
+		ParameterName: "param",
+		Value:         value,
+	})
+	assert.NoError(t, err)
+	return p.(*expression.ExpressionParameter)
+}
+
+func TestParseExpressionParameter(t *testing.T) {
+	p := parse(t, map[string]interface{}{
+		"expression": "{{ .name }}-{{ .profileId }}",
+		"references": []interface{}{
+			"name",
+			map[interface{}]interface{}{"project": "other", "configType": "alerting-profile", "configId": "profile", "property": "id", "name": "profileId"},
+		},
+	})
+
+	assert.Equal(t, []parameter.ParameterReference{
+		{Config: coord, Property: "name"},
+		{Config: otherCoord, Property: "id"},
+	}, p.GetReferences())
+}
+
+func TestParseExpressionParameter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   map[string]interface{}
+		wantErr string
+	}{
+		{"missing expression", map[string]interface{}{}, "missing property `expression`"},
+		{"invalid expression", map[string]interface{}{"expression": "{{ .name "}, "invalid expression"},
+		{"unknown function", map[string]interface{}{"expression": "{{ exec .name }}"}, `function "exec" not defined`},
+		{"malformed references", map[string]interface{}{"expression": "a", "references": "name"}, "malformed value `references`"},
+		{"reference without property", map[string]interface{}{"expression": "a", "references": []interface{}{map[interface{}]interface{}{"configId": "c"}}}, "missing `property`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expression.ExpressionParameterSerde.Deserializer(parameter.ParameterParserContext{Coordinate: coord, Value: tt.value})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestResolveValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       string
+	}{
+		{"string functions", `{{ .name | replace " " "-" | lower }}-{{ upper "x" }}-{{ trim "  y " }}`, "my-dashboard-X-y"},
+		{"default", `{{ default "fallback" .empty }}/{{ default "fallback" .name }}`, "fallback/My Dashboard"},
+		{"join and split", `{{ .list | join "," }}/{{ split "-" "a-b" | join "+" }}`, "a,b,c/a+b"},
+		{"toJson", `{{ toJson .list }}`, `[\"a\",\"b\",\"c\"]`},
+		{"base64", `{{ b64enc "monaco" }}/{{ b64dec "bW9uYWNv" }}`, "bW9uYWNv/monaco"},
+		{"arithmetic", `{{ add .count 1 }} {{ sub 10 .count }} {{ mul .count 2 }} {{ div 7 2 }} {{ mod 7 2 }} {{ div 7.0 2 }}`, "43 -32 84 3 1 3.5"},
+		{"conditionals", `{{ if eq .env "prod" }}p{{ else }}np{{ end }}-{{ ternary "a" "b" (hasPrefix "My" .name) }}`, "p-a"},
+		{"other config", `{{ .profileId }}`, "profile-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parse(t, map[string]interface{}{
+				"expression": tt.expression,
+				"references": []interface{}{
+					"name", "empty", "list", "count", "env",
+					map[interface{}]interface{}{"project": "other", "configType": "alerting-profile", "configId": "profile", "property": "id", "name": "profileId"},
+				},
+			})
+
+			val, err := p.ResolveValue(parameter.ResolveContext{
+				ConfigCoordinate: coord,
+				PropertyResolver: propertyResolver{otherCoord: {"id": "profile-id"}},
+				ResolvedParameterValues: parameter.Properties{
+					"name":  "My Dashboard",
+					"empty": "",
+					"list":  []any{"a", "b", "c"},
+					"count": "42",
+					"env":   "prod",
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, val)
+		})
+	}
+}
+
+func TestResolveValue_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		references []interface{}
+		wantErr    string
+	}{
+		{"unresolved parameter", "{{ .missing }}", []interface{}{"missing"}, "referenced parameter `missing` has not been resolved yet"},
+		{"unresolved config", "{{ .id }}", []interface{}{map[interface{}]interface{}{"configId": "other", "property": "id"}}, "config has not been resolved yet"},
+		{"not referenced", "{{ .name }}", nil, `map has no entry for key "name"`},
+		{"not a number", "{{ add .name 1 }}", []interface{}{"name"}, "is not a number"},
+		{"division by zero", "{{ div 1 0 }}", nil, "division by zero"},
+		{"float division by zero", "{{ div 1.5 0 }}", nil, "division by zero"},
+		{"modulo by zero", "{{ mod 1.5 0 }}", nil, "division by zero"},
+		{"float overflow", "{{ mul 1e308 10 }}", nil, "result is out of range"},
+		{"integer overflow", "{{ add 9223372036854775807 1 }}", nil, "result is out of range"},
+		{"unsigned integer overflow", "{{ add .big 1 }}", []interface{}{"big"}, "result is out of range"},
+		{"result is not a number", `{{ add "NaN" 1.5 }}`, nil, "result is not a number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parse(t, map[string]interface{}{"expression": tt.expression, "references": tt.references})
+
+			_, err := p.ResolveValue(parameter.ResolveContext{
+				ConfigCoordinate:        coord,
+				PropertyResolver:        propertyResolver{},
+				ResolvedParameterValues: parameter.Properties{"name": "name", "big": uint64(math.MaxUint64)},
+			})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWriteExpressionParameter(t *testing.T) {
+	value := map[string]interface{}{
+		"expression": "{{ .name }}-{{ .profileId }}",
+		"references": []interface{}{
+			"name",
+			map[string]interface{}{"project": "other", "configType": "alerting-profile", "configId": "profile", "property": "id", "name": "profileId"},
+		},
+	}
+
+	result, err := expression.ExpressionParameterSerde.Serializer(parameter.ParameterWriterContext{
+		Coordinate: coord,
+		Parameter: parse(t, map[string]interface{}{
+			"expression": value["expression"],
+			"references": []interface{}{
+				"name",
+				map[interface{}]interface{}{"project": "other", "configType": "alerting-profile", "configId": "profile", "property": "id", "name": "profileId"},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, value, result)
+}
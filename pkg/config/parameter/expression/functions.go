@@ -0,0 +1,284 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expression
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	templ "text/template" // nosemgrep: go.lang.security.audit.xss.import-text-template.import-text-template
+)
+
+// functions available in expressions. Functions take the value they work on as last argument, so they can be used
+// in pipelines, e.g. `{{ .name | replace " " "-" | lower }}`. None of the functions have side effects or access
+// anything outside the expression.
+var functions = templ.FuncMap{
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"trim":      strings.TrimSpace,
+	"replace":   replace,
+	"contains":  contains,
+	"hasPrefix": hasPrefix,
+	"hasSuffix": hasSuffix,
+	"split":     split,
+	"join":      join,
+	"default":   defaultValue,
+	"empty":     empty,
+	"ternary":   ternary,
+	"toJson":    toJson,
+	"b64enc":    b64enc,
+	"b64dec":    b64dec,
+	"add":       add,
+	"sub":       sub,
+	"mul":       mul,
+	"div":       div,
+	"mod":       mod,
+}
+
+func replace(old string, replacement string, s string) string {
+	return strings.ReplaceAll(s, old, replacement)
+}
+
+func contains(substr string, s string) bool {
+	return strings.Contains(s, substr)
+}
+
+func hasPrefix(prefix string, s string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+func hasSuffix(suffix string, s string) bool {
+	return strings.HasSuffix(s, suffix)
+}
+
+func split(sep string, s string) []string {
+	return strings.Split(s, sep)
+}
+
+// join joins the elements of a list, converting all elements to strings
+func join(sep string, list any) (string, error) {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expected a list, got %T", list)
+	}
+
+	elems := make([]string, v.Len())
+	for i := range elems {
+		elems[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(elems, sep), nil
+}
+
+// defaultValue returns def if val is empty
+func defaultValue(def any, val any) any {
+	if empty(val) {
+		return def
+	}
+	return val
+}
+
+// empty returns whether val is nil, the zero value of its type, or an empty list or map
+func empty(val any) bool {
+	if val == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// ternary returns ifTrue if cond is true, and ifFalse otherwise
+func ternary(ifTrue any, ifFalse any, cond bool) any {
+	if cond {
+		return ifTrue
+	}
+	return ifFalse
+}
+
+func toJson(val any) (string, error) {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func b64enc(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func b64dec(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func add(a any, b any) (any, error) {
+	return arithmetic(a, b,
+		func(x, y int64) (int64, error) {
+			r := x + y
+			if (x > 0 && y > 0 && r < 0) || (x < 0 && y < 0 && r >= 0) {
+				return 0, errOverflow
+			}
+			return r, nil
+		},
+		func(x, y float64) (float64, error) { return x + y, nil })
+}
+
+func sub(a any, b any) (any, error) {
+	return arithmetic(a, b,
+		func(x, y int64) (int64, error) {
+			r := x - y
+			if (x >= 0 && y < 0 && r < 0) || (x < 0 && y > 0 && r >= 0) {
+				return 0, errOverflow
+			}
+			return r, nil
+		},
+		func(x, y float64) (float64, error) { return x - y, nil })
+}
+
+func mul(a any, b any) (any, error) {
+	return arithmetic(a, b,
+		func(x, y int64) (int64, error) {
+			r := x * y
+			if x != 0 && (r/x != y || (x == -1 && y == math.MinInt64)) {
+				return 0, errOverflow
+			}
+			return r, nil
+		},
+		func(x, y float64) (float64, error) { return x * y, nil })
+}
+
+var (
+	errDivisionByZero = errors.New("division by zero")
+	errOverflow       = errors.New("result is out of range")
+	errNotANumber     = errors.New("result is not a number")
+)
+
+func div(a any, b any) (any, error) {
+	return arithmetic(a, b,
+		func(x, y int64) (int64, error) {
+			if y == 0 {
+				return 0, errDivisionByZero
+			}
+			if x == math.MinInt64 && y == -1 {
+				return 0, errOverflow
+			}
+			return x / y, nil
+		},
+		func(x, y float64) (float64, error) {
+			if y == 0 {
+				return 0, errDivisionByZero
+			}
+			return x / y, nil
+		})
+}
+
+func mod(a any, b any) (any, error) {
+	return arithmetic(a, b,
+		func(x, y int64) (int64, error) {
+			if y == 0 {
+				return 0, errDivisionByZero
+			}
+			if y == -1 {
+				// avoids the overflow of math.MinInt64 % -1
+				return 0, nil
+			}
+			return x % y, nil
+		},
+		func(x, y float64) (float64, error) {
+			if y == 0 {
+				return 0, errDivisionByZero
+			}
+			return math.Mod(x, y), nil
+		})
+}
+
+// arithmetic applies intOp if both operands are integers, and floatOp otherwise. Numbers given as strings are parsed,
+// as resolved parameter values are often strings. Results that are infinite or not a number are returned as errors,
+// as they can not be rendered into JSON.
+func arithmetic(a any, b any, intOp func(int64, int64) (int64, error), floatOp func(float64, float64) (float64, error)) (any, error) {
+	x, err := toNumber(a)
+	if err != nil {
+		return nil, err
+	}
+	y, err := toNumber(b)
+	if err != nil {
+		return nil, err
+	}
+
+	xi, xIsInt := x.(int64)
+	yi, yIsInt := y.(int64)
+	if xIsInt && yIsInt {
+		return intOp(xi, yi)
+	}
+
+	res, err := floatOp(toFloat(x), toFloat(y))
+	if err != nil {
+		return nil, err
+	}
+	if math.IsInf(res, 0) {
+		return nil, errOverflow
+	}
+	if math.IsNaN(res) {
+		return nil, errNotANumber
+	}
+	return res, nil
+}
+
+// toNumber converts val to an int64 or a float64
+func toNumber(val any) (any, error) {
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return nil, errOverflow
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return i, nil
+		}
+		if f, err := strconv.ParseFloat(v.String(), 64); err == nil {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("%v is not a number", val)
+}
+
+func toFloat(n any) float64 {
+	if i, ok := n.(int64); ok {
+		return float64(i)
+	}
+	return n.(float64)
+}
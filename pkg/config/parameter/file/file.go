@@ -0,0 +1,106 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/spf13/afero"
+	"path/filepath"
+	stdStrings "strings"
+)
+
+// FileParameterType specifies the type of the parameter used in config files
+const FileParameterType = "file"
+
+var FileParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeFileParameter,
+	Deserializer: parseFileParameter,
+}
+
+// FileParameter defines a parameter loading a secret value from a local file. The value is read when the parameter is
+// resolved, and is masked in logs. Trailing line breaks are removed from the file content.
+type FileParameter struct {
+	// Path of the file as defined in the config. Relative paths are resolved against Folder.
+	Path string
+
+	// Folder of the config file the parameter is defined in
+	Folder string
+
+	// fs is the file system the file is read from
+	fs afero.Fs
+}
+
+func New(fs afero.Fs, path string, folder string) *FileParameter {
+	return &FileParameter{
+		Path:   path,
+		Folder: folder,
+		fs:     fs,
+	}
+}
+
+// this forces the compiler to check if FileParameter is of type Parameter
+var _ parameter.Parameter = (*FileParameter)(nil)
+
+func (p *FileParameter) GetType() string {
+	return FileParameterType
+}
+
+func (p *FileParameter) GetReferences() []parameter.ParameterReference {
+	// file parameters cannot have references
+	return []parameter.ParameterReference{}
+}
+
+func (p *FileParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	data, err := afero.ReadFile(p.fs, p.filePath())
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to read file `%s`: %s", p.Path, err))
+	}
+
+	return parameter.SecretValue(stdStrings.TrimRight(string(data), "\r\n"))
+}
+
+// filePath returns the path of the file to read, resolving relative paths against the folder of the config
+func (p *FileParameter) filePath() string {
+	if filepath.IsAbs(p.Path) {
+		return p.Path
+	}
+	return filepath.Join(p.Folder, p.Path)
+}
+
+// parseFileParameter parses a FileParameter from a given context. it requires a `path` field to be set.
+func parseFileParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	path, ok := context.Value["path"]
+	if !ok || strings.ToString(path) == "" {
+		return nil, parameter.NewParameterParserError(context, "missing property `path`")
+	}
+
+	return New(context.Fs, strings.ToString(path), context.Folder), nil
+}
+
+func writeFileParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	fileParam, ok := context.Parameter.(*FileParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `FileParameter`")
+	}
+
+	return map[string]interface{}{
+		"path": fileParam.Path,
+	}, nil
+}
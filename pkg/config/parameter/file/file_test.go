@@ -0,0 +1,70 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/file"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFileParameter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	p, err := file.FileParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value:  map[string]interface{}{"path": "secrets/token"},
+		Folder: "project/dashboard",
+		Fs:     fs,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, file.New(fs, "secrets/token", "project/dashboard"), p)
+
+	_, err = file.FileParameterSerde.Deserializer(parameter.ParameterParserContext{Value: map[string]interface{}{}})
+	assert.ErrorContains(t, err, "missing property `path`")
+}
+
+func TestWriteFileParameter(t *testing.T) {
+	result, err := file.FileParameterSerde.Serializer(parameter.ParameterWriterContext{Parameter: file.New(afero.NewMemMapFs(), "secrets/token", "project")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"path": "secrets/token"}, result)
+}
+
+func TestResolveValue(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	dir, err := filepath.Abs("project")
+	assert.NoError(t, err)
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "token"), []byte("file-secret-\"value\"\n"), 0600))
+
+	val, err := file.New(fs, "token", dir).ResolveValue(parameter.ResolveContext{})
+	assert.NoError(t, err)
+	assert.Equal(t, `file-secret-\"value\"`, val, "value must be escaped and trailing line breaks removed")
+	assert.Equal(t, "token: ****", secret.MaskValues(`token: file-secret-"value"`), "value must be masked")
+
+	val, err = file.New(fs, filepath.Join(dir, "token"), "other").ResolveValue(parameter.ResolveContext{})
+	assert.NoError(t, err, "absolute paths must not be resolved against the folder")
+	assert.Equal(t, `file-secret-\"value\"`, val)
+}
+
+func TestResolveValue_MissingFile(t *testing.T) {
+	_, err := file.New(afero.NewMemMapFs(), "missing", "project").ResolveValue(parameter.ResolveContext{ParameterName: "token"})
+	assert.ErrorContains(t, err, "failed to read file `missing`")
+}
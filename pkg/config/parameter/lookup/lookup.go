@@ -0,0 +1,225 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lookup
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"sort"
+)
+
+// LookupParameterType specifies the type of the parameter used in config files
+const LookupParameterType = "lookup"
+
+// DefaultScope is the scope settings objects are looked up in, if no scope is defined
+const DefaultScope = "environment"
+
+var LookupParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeLookupParameter,
+	Deserializer: parseLookupParameter,
+}
+
+// LookupParameter defines a parameter resolving to an existing object on the environment the config is deployed to,
+// which does not need to be managed by monaco. Objects of classic APIs are found by their API and Name, settings
+// objects by their Schema and Scope, and the top-level fields of their value defined in Match.
+// Exactly one object must be found. The parameter resolves to a map holding the `id` and `name` of the object, which
+// can be used in templates like `{{ .profile.id }}`.
+type LookupParameter struct {
+	// API is the ID of the classic API of the object, e.g. `alerting-profile`
+	API string
+
+	// Name of the classic API object
+	Name string
+
+	// Schema is the ID of the settings schema of the object, e.g. `builtin:alerting.profile`
+	Schema string
+
+	// Scope of the settings object
+	Scope string
+
+	// Match are the top-level fields the value of the settings object must have
+	Match map[string]any
+}
+
+// NewClassic returns a LookupParameter finding the object of the given classic API with the given name
+func NewClassic(api string, name string) *LookupParameter {
+	return &LookupParameter{
+		API:  api,
+		Name: name,
+	}
+}
+
+// NewSettings returns a LookupParameter finding the settings object of the given schema and scope, whose value has all
+// the given fields
+func NewSettings(schema string, scope string, match map[string]any) *LookupParameter {
+	return &LookupParameter{
+		Schema: schema,
+		Scope:  scope,
+		Match:  match,
+	}
+}
+
+// this forces the compiler to check if LookupParameter is of type Parameter
+var _ parameter.Parameter = (*LookupParameter)(nil)
+
+func (p *LookupParameter) GetType() string {
+	return LookupParameterType
+}
+
+func (p *LookupParameter) GetReferences() []parameter.ParameterReference {
+	// lookup parameters cannot have references
+	return []parameter.ParameterReference{}
+}
+
+func (p *LookupParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	if context.RemoteObjectFinder == nil {
+		return nil, parameter.NewParameterResolveValueError(context, "objects can not be looked up")
+	}
+
+	var obj parameter.RemoteObject
+	if p.API != "" {
+		found, exists, err := context.RemoteObjectFinder.FindClassicObject(p.API, p.Name)
+		if err != nil {
+			return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to look up `%s` object named `%s`: %s", p.API, p.Name, err))
+		}
+		if !exists {
+			return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("no `%s` object named `%s` exists", p.API, p.Name))
+		}
+		obj = found
+	} else {
+		found, err := context.RemoteObjectFinder.FindSettingsObjects(p.Schema, p.Scope, p.Match)
+		if err != nil {
+			return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to look up settings objects of schema `%s`: %s", p.Schema, err))
+		}
+		if len(found) != 1 {
+			return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("%d settings objects of schema `%s` in scope `%s` match %s, but exactly one is required", len(found), p.Schema, p.Scope, matchString(p.Match)))
+		}
+		obj = found[0]
+	}
+
+	return template.EscapeSpecialCharactersInValue(map[string]interface{}{
+		"id":   obj.ID,
+		"name": obj.Name,
+	}, template.FullStringEscapeFunction)
+}
+
+// matchString returns the fields to match as JSON, sorted by their key
+func matchString(match map[string]any) string {
+	b, err := json.Marshal(match)
+	if err != nil {
+		return fmt.Sprintf("%v", match)
+	}
+	return string(b)
+}
+
+// parseLookupParameter parses a LookupParameter from a given context. Objects of classic APIs require an `api` and a
+// `name` field to be set, settings objects a `schema` and a `match` field, and an optional `scope`.
+func parseLookupParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	apiID, hasAPI := context.Value["api"]
+	schema, hasSchema := context.Value["schema"]
+
+	switch {
+	case hasAPI && hasSchema:
+		return nil, parameter.NewParameterParserError(context, "properties `api` and `schema` are mutually exclusive")
+	case hasAPI:
+		return parseClassicLookup(context, strings.ToString(apiID))
+	case hasSchema:
+		return parseSettingsLookup(context, strings.ToString(schema))
+	default:
+		return nil, parameter.NewParameterParserError(context, "either property `api` or `schema` is required")
+	}
+}
+
+func parseClassicLookup(context parameter.ParameterParserContext, apiID string) (parameter.Parameter, error) {
+	if _, found := api.NewAPIs()[apiID]; !found {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("unknown API `%s`", apiID))
+	}
+
+	name, ok := context.Value["name"]
+	if !ok || strings.ToString(name) == "" {
+		return nil, parameter.NewParameterParserError(context, "missing property `name`")
+	}
+
+	return NewClassic(apiID, strings.ToString(name)), nil
+}
+
+func parseSettingsLookup(context parameter.ParameterParserContext, schema string) (parameter.Parameter, error) {
+	if schema == "" {
+		return nil, parameter.NewParameterParserError(context, "missing property `schema`")
+	}
+
+	scope := DefaultScope
+	if val, ok := context.Value["scope"]; ok {
+		scope = strings.ToString(val)
+	}
+
+	var match map[string]any
+	switch val := context.Value["match"].(type) {
+	case map[interface{}]interface{}:
+		match = maps.ToStringMap(val)
+	case map[string]interface{}:
+		match = val
+	}
+	if len(match) == 0 {
+		return nil, parameter.NewParameterParserError(context, "missing property `match`")
+	}
+
+	for _, k := range sortedKeys(match) {
+		switch match[k].(type) {
+		case string, bool, int, float64, nil:
+		default:
+			return nil, parameter.NewParameterParserError(context, fmt.Sprintf("field `%s` of `match` must be a string, number or boolean", k))
+		}
+	}
+
+	return NewSettings(schema, scope, match), nil
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeLookupParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	lookupParam, ok := context.Parameter.(*LookupParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `LookupParameter`")
+	}
+
+	if lookupParam.API != "" {
+		return map[string]interface{}{
+			"api":  lookupParam.API,
+			"name": lookupParam.Name,
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"schema": lookupParam.Schema,
+		"scope":  lookupParam.Scope,
+		"match":  lookupParam.Match,
+	}, nil
+}
@@ -0,0 +1,160 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lookup_test
+
+import (
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/lookup"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type finder struct{}
+
+func (finder) FindClassicObject(api string, name string) (parameter.RemoteObject, bool, error) {
+	switch {
+	case api == "alerting-profile" && name == `Team "A"`:
+		return parameter.RemoteObject{ID: "profile-id", Name: name}, true, nil
+	case api == "alerting-profile":
+		return parameter.RemoteObject{}, false, nil
+	default:
+		return parameter.RemoteObject{}, false, errors.New("failed")
+	}
+}
+
+func (finder) FindSettingsObjects(schemaId string, _ string, fields map[string]any) ([]parameter.RemoteObject, error) {
+	if schemaId != "builtin:management-zones" {
+		return nil, errors.New("failed")
+	}
+	switch fields["name"] {
+	case "zone":
+		return []parameter.RemoteObject{{ID: "zone-id", Name: "zone"}}, nil
+	case "duplicate":
+		return []parameter.RemoteObject{{ID: "1"}, {ID: "2"}}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func TestParseLookupParameter(t *testing.T) {
+	p, err := lookup.LookupParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value: map[string]interface{}{"api": "alerting-profile", "name": "Default"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, lookup.NewClassic("alerting-profile", "Default"), p)
+
+	p, err = lookup.LookupParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value: map[string]interface{}{"schema": "builtin:management-zones", "match": map[interface{}]interface{}{"name": "zone"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, lookup.NewSettings("builtin:management-zones", "environment", map[string]any{"name": "zone"}), p)
+
+	p, err = lookup.LookupParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value: map[string]interface{}{"schema": "builtin:tags.auto-tagging", "scope": "HOST-1", "match": map[interface{}]interface{}{"enabled": true}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, lookup.NewSettings("builtin:tags.auto-tagging", "HOST-1", map[string]any{"enabled": true}), p)
+}
+
+func TestParseLookupParameter_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value map[string]interface{}
+		err   string
+	}{
+		{
+			name:  "neither api nor schema",
+			value: map[string]interface{}{"name": "Default"},
+			err:   "either property `api` or `schema` is required",
+		},
+		{
+			name:  "api and schema",
+			value: map[string]interface{}{"api": "alerting-profile", "schema": "builtin:alerting.profile"},
+			err:   "properties `api` and `schema` are mutually exclusive",
+		},
+		{
+			name:  "unknown api",
+			value: map[string]interface{}{"api": "unknown", "name": "Default"},
+			err:   "unknown API `unknown`",
+		},
+		{
+			name:  "missing name",
+			value: map[string]interface{}{"api": "alerting-profile"},
+			err:   "missing property `name`",
+		},
+		{
+			name:  "missing match",
+			value: map[string]interface{}{"schema": "builtin:alerting.profile"},
+			err:   "missing property `match`",
+		},
+		{
+			name:  "nested match",
+			value: map[string]interface{}{"schema": "builtin:alerting.profile", "match": map[interface{}]interface{}{"rules": []interface{}{}}},
+			err:   "field `rules` of `match` must be a string, number or boolean",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lookup.LookupParameterSerde.Deserializer(parameter.ParameterParserContext{Value: tt.value})
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestWriteLookupParameter(t *testing.T) {
+	result, err := lookup.LookupParameterSerde.Serializer(parameter.ParameterWriterContext{Parameter: lookup.NewClassic("alerting-profile", "Default")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"api": "alerting-profile", "name": "Default"}, result)
+
+	result, err = lookup.LookupParameterSerde.Serializer(parameter.ParameterWriterContext{Parameter: lookup.NewSettings("builtin:management-zones", "environment", map[string]any{"name": "zone"})})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"schema": "builtin:management-zones", "scope": "environment", "match": map[string]any{"name": "zone"}}, result)
+}
+
+func TestResolveValue(t *testing.T) {
+	context := parameter.ResolveContext{RemoteObjectFinder: finder{}}
+
+	val, err := lookup.NewClassic("alerting-profile", `Team "A"`).ResolveValue(context)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "profile-id", "name": `Team \"A\"`}, val, "values must be escaped for JSON templates")
+
+	val, err = lookup.NewSettings("builtin:management-zones", "environment", map[string]any{"name": "zone"}).ResolveValue(context)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "zone-id", "name": "zone"}, val)
+}
+
+func TestResolveValue_Errors(t *testing.T) {
+	context := parameter.ResolveContext{RemoteObjectFinder: finder{}}
+
+	_, err := lookup.NewClassic("alerting-profile", "missing").ResolveValue(context)
+	assert.ErrorContains(t, err, "no `alerting-profile` object named `missing` exists")
+
+	_, err = lookup.NewClassic("dashboard", "Default").ResolveValue(context)
+	assert.ErrorContains(t, err, "failed to look up `dashboard` object named `Default`: failed")
+
+	_, err = lookup.NewSettings("builtin:management-zones", "environment", map[string]any{"name": "missing"}).ResolveValue(context)
+	assert.ErrorContains(t, err, "0 settings objects of schema `builtin:management-zones` in scope `environment` match {\"name\":\"missing\"}, but exactly one is required")
+
+	_, err = lookup.NewSettings("builtin:management-zones", "environment", map[string]any{"name": "duplicate"}).ResolveValue(context)
+	assert.ErrorContains(t, err, "2 settings objects")
+
+	_, err = lookup.NewClassic("alerting-profile", "Default").ResolveValue(parameter.ResolveContext{})
+	assert.ErrorContains(t, err, "objects can not be looked up")
+}
@@ -0,0 +1,107 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifest
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// ManifestParameterType specifies the type of the parameter used in config files
+const ManifestParameterType = "manifest"
+
+var ManifestParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeManifestParameter,
+	Deserializer: parseManifestParameter,
+}
+
+// ManifestParameter defines a parameter which evaluates to a parameter defined in the manifest for the environment
+// (or its group) the config is loaded for. The value is looked up when the config is loaded.
+type ManifestParameter struct {
+	// Name of the parameter in the manifest
+	Name string
+
+	// Value the manifest defines for the environment
+	Value interface{}
+
+	// flag indicating that a default value has been set. this is needed, as
+	// we cannot distinguish an empty value from a not set value.
+	HasDefaultValue bool
+
+	// default value used if the manifest does not define the parameter for the environment.
+	// note: this value is only used, if the `HasDefaultValue` flag is set to true.
+	DefaultValue interface{}
+}
+
+// this forces the compiler to check if ManifestParameter is of type Parameter
+var _ parameter.Parameter = (*ManifestParameter)(nil)
+
+func (p *ManifestParameter) GetType() string {
+	return ManifestParameterType
+}
+
+func (p *ManifestParameter) GetReferences() []parameter.ParameterReference {
+	// manifest parameters cannot have references
+	return []parameter.ParameterReference{}
+}
+
+func (p *ManifestParameter) ResolveValue(_ parameter.ResolveContext) (interface{}, error) {
+	return template.EscapeSpecialCharactersInValue(p.Value, template.FullStringEscapeFunction)
+}
+
+// parseManifestParameter parses a ManifestParameter from a given context.
+// it requires a `name` field to be set. `default` is an optional field used if the manifest does not define the
+// parameter for the environment.
+func parseManifestParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	n, ok := context.Value["name"]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "missing property `name`")
+	}
+	name := strings.ToString(n)
+
+	defaultValue, hasDefault := context.Value["default"]
+
+	if val, found := context.EnvironmentParameters[name]; found {
+		return &ManifestParameter{Name: name, Value: val, HasDefaultValue: hasDefault, DefaultValue: defaultValue}, nil
+	}
+
+	if hasDefault {
+		return &ManifestParameter{Name: name, Value: defaultValue, HasDefaultValue: true, DefaultValue: defaultValue}, nil
+	}
+
+	return nil, parameter.NewParameterParserError(context, fmt.Sprintf("manifest does not define parameter `%s` for the environment", name))
+}
+
+func writeManifestParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	manifestParam, ok := context.Parameter.(*ManifestParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `ManifestParameter`")
+	}
+
+	result := map[string]interface{}{
+		"name": manifestParam.Name,
+	}
+
+	if manifestParam.HasDefaultValue {
+		result["default"] = manifestParam.DefaultValue
+	}
+
+	return result, nil
+}
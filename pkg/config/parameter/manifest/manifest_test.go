@@ -0,0 +1,95 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifest_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/manifest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseManifestParameter(t *testing.T) {
+	envParameters := map[string]interface{}{"team": "team \"a\"", "tags": []interface{}{"a", "b"}}
+
+	tests := []struct {
+		name    string
+		value   map[string]interface{}
+		want    *manifest.ManifestParameter
+		wantErr string
+	}{
+		{
+			name:  "defined parameter",
+			value: map[string]interface{}{"name": "team"},
+			want:  &manifest.ManifestParameter{Name: "team", Value: "team \"a\""},
+		},
+		{
+			name:  "defined parameter with default",
+			value: map[string]interface{}{"name": "tags", "default": "none"},
+			want:  &manifest.ManifestParameter{Name: "tags", Value: []interface{}{"a", "b"}, HasDefaultValue: true, DefaultValue: "none"},
+		},
+		{
+			name:  "undefined parameter with default",
+			value: map[string]interface{}{"name": "owner", "default": "nobody"},
+			want:  &manifest.ManifestParameter{Name: "owner", Value: "nobody", HasDefaultValue: true, DefaultValue: "nobody"},
+		},
+		{
+			name:    "undefined parameter",
+			value:   map[string]interface{}{"name": "owner"},
+			wantErr: "manifest does not define parameter `owner` for the environment",
+		},
+		{
+			name:    "missing name",
+			value:   map[string]interface{}{},
+			wantErr: "missing property `name`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := manifest.ManifestParameterSerde.Deserializer(parameter.ParameterParserContext{
+				Value:                 tt.value,
+				EnvironmentParameters: envParameters,
+			})
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, p)
+		})
+	}
+}
+
+func TestResolveValue(t *testing.T) {
+	p := &manifest.ManifestParameter{Name: "team", Value: "team \"a\""}
+
+	val, err := p.ResolveValue(parameter.ResolveContext{})
+	assert.NoError(t, err)
+	assert.Equal(t, `team \"a\"`, val)
+}
+
+func TestWriteManifestParameter(t *testing.T) {
+	result, err := manifest.ManifestParameterSerde.Serializer(parameter.ParameterWriterContext{
+		Parameter: &manifest.ManifestParameter{Name: "owner", Value: "nobody", HasDefaultValue: true, DefaultValue: "nobody"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "owner", "default": "nobody"}, result)
+}
@@ -15,7 +15,9 @@
 package parameter
 
 import (
+	"context"
 	"fmt"
+	"github.com/spf13/afero"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
@@ -29,6 +31,36 @@ type PropertyResolver interface {
 	GetResolvedProperty(coordinate coordinate.Coordinate, propertyName string) (any, bool)
 }
 
+// MonitoredEntityFinder is used in parameter resolution to find the monitored entities on the environment a config is
+// deployed to
+type MonitoredEntityFinder interface {
+	// FindMonitoredEntities returns the IDs of all monitored entities matching the given entity selector
+	FindMonitoredEntities(entitySelector string) ([]string, error)
+}
+
+// RemoteObject is an object on the environment a config is deployed to, which is not necessarily managed by monaco
+type RemoteObject struct {
+	ID   string
+	Name string
+}
+
+// RemoteObjectFinder is used in parameter resolution to find existing objects on the environment a config is deployed to
+type RemoteObjectFinder interface {
+	// FindClassicObject returns the object of the given classic API with the given name. If no such object exists,
+	// false is returned.
+	FindClassicObject(api string, name string) (RemoteObject, bool, error)
+
+	// FindSettingsObjects returns all settings objects of the given schema and scope, whose values have all the given
+	// top-level fields
+	FindSettingsObjects(schemaId string, scope string, fields map[string]any) ([]RemoteObject, error)
+}
+
+// ContextProvider provides the context that requests made to resolve parameters are bound to, e.g. the context of a
+// deployment, so that they are canceled with it
+type ContextProvider interface {
+	Context() context.Context
+}
+
 // ResolveContext used to give some more information on the resolving phase
 type ResolveContext struct {
 	PropertyResolver PropertyResolver
@@ -47,6 +79,15 @@ type ResolveContext struct {
 
 	// resolved values of the current config
 	ResolvedParameterValues Properties
+
+	// finds monitored entities on the environment of the current config. it is nil if entities can not be looked up.
+	MonitoredEntityFinder MonitoredEntityFinder
+
+	// finds existing objects on the environment of the current config. it is nil if objects can not be looked up.
+	RemoteObjectFinder RemoteObjectFinder
+
+	// context that requests made to resolve the parameter are bound to. it is nil if no context is known.
+	Context context.Context
 }
 
 type Parameter interface {
@@ -88,6 +129,12 @@ type ParameterParserContext struct {
 	ParameterName string
 	// current value to parse
 	Value map[string]interface{}
+	// Folder of the config file the parameter is defined in. Relative paths in parameters are resolved against it.
+	Folder string
+	// EnvironmentParameters are the parameters defined in the manifest for the environment the config is loaded for
+	EnvironmentParameters map[string]interface{}
+	// Fs is the file system the config is loaded from. Parameters reading files read them from it.
+	Fs afero.Fs
 }
 
 type ParameterParserError struct {
@@ -0,0 +1,39 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parameter
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+)
+
+// SecretValue returns the resolved value of a parameter loading a secret. Like any other string value, it is escaped
+// for use in JSON templates. Both the raw and the escaped value are registered as secrets, so that they are masked in
+// logs and traffic logs.
+func SecretValue(val string) (interface{}, error) {
+	escaped, err := template.EscapeSpecialCharactersInValue(val, template.FullStringEscapeFunction)
+	if err != nil {
+		return nil, err
+	}
+
+	if s, ok := escaped.(string); ok {
+		secret.Register(val, s)
+	} else {
+		secret.Register(val)
+	}
+	return escaped, nil
+}
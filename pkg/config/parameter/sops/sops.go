@@ -0,0 +1,255 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sops
+
+import (
+	"bytes"
+	stdContext "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/spf13/afero"
+	"os"
+	"os/exec"
+	"path/filepath"
+	stdStrings "strings"
+	"sync"
+)
+
+// SopsParameterType specifies the type of the parameter used in config files
+const SopsParameterType = "sops"
+
+// EnvVarSopsBinary can be set to the path of the sops executable, if it is not available as 'sops' on the PATH
+const EnvVarSopsBinary = "MONACO_SOPS_BINARY"
+
+// cacheKey identifies an encrypted file
+type cacheKey struct {
+	fs   afero.Fs
+	path string
+}
+
+// cache holds the decrypted content of each file, so that every file is only decrypted once, no matter how many
+// parameters load values from it
+var cache = struct {
+	sync.Mutex
+	files map[cacheKey]map[string]any
+}{files: make(map[cacheKey]map[string]any)}
+
+var SopsParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeSopsParameter,
+	Deserializer: parseSopsParameter,
+}
+
+// SopsParameter defines a parameter loading a secret value from a SOPS encrypted YAML file.
+// The file is decrypted using the sops executable, which reads the decryption key (e.g. an age key from
+// SOPS_AGE_KEY_FILE) the same way as when it is run on its own. The value is masked in logs.
+// Each file is only decrypted once, so that parameters loading several keys of the same file do not decrypt it repeatedly.
+type SopsParameter struct {
+	// Path of the encrypted file as defined in the config. Relative paths are resolved against Folder.
+	Path string
+
+	// Key of the value in the file. Keys of nested values are separated by dots, e.g. `database.password`.
+	Key string
+
+	// Folder of the config file the parameter is defined in
+	Folder string
+
+	// fs is the file system the encrypted file is read from
+	fs afero.Fs
+}
+
+func New(fs afero.Fs, path string, key string, folder string) *SopsParameter {
+	return &SopsParameter{
+		Path:   path,
+		Key:    key,
+		Folder: folder,
+		fs:     fs,
+	}
+}
+
+// this forces the compiler to check if SopsParameter is of type Parameter
+var _ parameter.Parameter = (*SopsParameter)(nil)
+
+func (p *SopsParameter) GetType() string {
+	return SopsParameterType
+}
+
+func (p *SopsParameter) GetReferences() []parameter.ParameterReference {
+	// sops parameters cannot have references
+	return []parameter.ParameterReference{}
+}
+
+func (p *SopsParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	path := p.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.Folder, path)
+	}
+
+	ctx := context.Context
+	if ctx == nil {
+		ctx = stdContext.Background()
+	}
+
+	data, err := cachedFile(ctx, p.fs, path)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to decrypt `%s`: %s", p.Path, err))
+	}
+
+	val, found := lookup(data, p.Key)
+	if !found {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("file `%s` has no key `%s`", p.Path, p.Key))
+	}
+
+	if s, ok := val.(string); ok {
+		return parameter.SecretValue(s)
+	}
+	if _, isScalar := val.(json.Number); isScalar || val == nil {
+		return parameter.SecretValue(strings.ToString(val))
+	}
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to convert `%s` of `%s`: %s", p.Key, p.Path, err))
+	}
+	return parameter.SecretValue(string(b))
+}
+
+// cachedFile returns the decrypted content of the file at the given path. The file is only decrypted if it was not
+// decrypted before.
+func cachedFile(ctx stdContext.Context, fs afero.Fs, path string) (map[string]any, error) {
+	key := cacheKey{fs: fs, path: filepath.Clean(path)}
+
+	cache.Lock()
+	data, found := cache.files[key]
+	cache.Unlock()
+	if found {
+		return data, nil
+	}
+
+	data, err := decryptFile(ctx, fs, path)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Lock()
+	cache.files[key] = data
+	cache.Unlock()
+	return data, nil
+}
+
+// decryptFile decrypts the whole file at the given path, and returns its content
+func decryptFile(ctx stdContext.Context, fs afero.Fs, path string) (map[string]any, error) {
+	encrypted, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// sops can only decrypt files on disk, so the encrypted content is copied to a temporary file. The file keeps the
+	// extension of the original file, as sops detects the format of the file by it.
+	tmpFile, err := writeTempFile(encrypted, filepath.Ext(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare decryption: %w", err)
+	}
+	defer os.Remove(tmpFile)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, sopsBinary(), "--decrypt", "--output-type", "json", tmpFile)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && ctx.Err() == nil {
+			return nil, errors.New(stdStrings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("failed to run sops: %w", err)
+	}
+
+	var data map[string]any
+	d := json.NewDecoder(&stdout)
+	d.UseNumber()
+	if err := d.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted content: %w", err)
+	}
+	return data, nil
+}
+
+// lookup returns the value of the given key. Keys of nested values are separated by dots, e.g. `database.password`.
+func lookup(data map[string]any, key string) (any, bool) {
+	var val any = data
+	for _, k := range stdStrings.Split(key, ".") {
+		m, ok := val.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if val, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+	return val, true
+}
+
+// writeTempFile writes the data to a new temporary file with the given extension, and returns the path of the file
+func writeTempFile(data []byte, ext string) (string, error) {
+	f, err := os.CreateTemp("", "monaco-sops-*"+ext)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func sopsBinary() string {
+	if b, ok := os.LookupEnv(EnvVarSopsBinary); ok && b != "" {
+		return b
+	}
+	return "sops"
+}
+
+// parseSopsParameter parses a SopsParameter from a given context. it requires a `path` and a `key` field to be set.
+func parseSopsParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	path, ok := context.Value["path"]
+	if !ok || strings.ToString(path) == "" {
+		return nil, parameter.NewParameterParserError(context, "missing property `path`")
+	}
+
+	key, ok := context.Value["key"]
+	if !ok || strings.ToString(key) == "" {
+		return nil, parameter.NewParameterParserError(context, "missing property `key`")
+	}
+
+	return New(context.Fs, strings.ToString(path), strings.ToString(key), context.Folder), nil
+}
+
+func writeSopsParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	sopsParam, ok := context.Parameter.(*SopsParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `SopsParameter`")
+	}
+
+	return map[string]interface{}{
+		"path": sopsParam.Path,
+		"key":  sopsParam.Key,
+	}, nil
+}
@@ -0,0 +1,130 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sops_test
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/sops"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// givenFakeSops writes a script standing in for the sops executable, which prints the content of the "encrypted" file as
+// is, or fails if the content is 'invalid'. Each run is appended to the returned file.
+func givenFakeSops(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake sops executable is a shell script")
+	}
+
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "sops")
+	runs := filepath.Join(dir, "runs")
+	script := `#!/bin/sh
+echo "$1 $2 $3" >> "` + runs + `"
+content=$(cat "$4")
+if [ "$content" = "invalid" ]; then
+  echo "Error: no matching keys found" >&2
+  exit 128
+fi
+if [ "$content" = "slow" ]; then
+  exec sleep 5
+fi
+echo "$content"
+`
+	assert.NoError(t, os.WriteFile(bin, []byte(script), 0700))
+	t.Setenv(sops.EnvVarSopsBinary, bin)
+	return runs
+}
+
+func TestParseSopsParameter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	p, err := sops.SopsParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value:  map[string]interface{}{"path": "secrets.enc.yaml", "key": "db.password"},
+		Folder: "project",
+		Fs:     fs,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, sops.New(fs, "secrets.enc.yaml", "db.password", "project"), p)
+
+	_, err = sops.SopsParameterSerde.Deserializer(parameter.ParameterParserContext{Value: map[string]interface{}{"path": "secrets.enc.yaml"}})
+	assert.ErrorContains(t, err, "missing property `key`")
+}
+
+func TestWriteSopsParameter(t *testing.T) {
+	result, err := sops.SopsParameterSerde.Serializer(parameter.ParameterWriterContext{Parameter: sops.New(afero.NewMemMapFs(), "secrets.enc.yaml", "db.password", "project")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"path": "secrets.enc.yaml", "key": "db.password"}, result)
+}
+
+func TestResolveValue(t *testing.T) {
+	runs := givenFakeSops(t)
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "project/secrets.enc.yaml", []byte(`{"db": {"password": "sops-secret-value", "port": 5432}}`), 0600))
+
+	val, err := sops.New(fs, "secrets.enc.yaml", "db.password", "project").ResolveValue(parameter.ResolveContext{})
+	assert.NoError(t, err)
+	assert.Equal(t, "sops-secret-value", val, "the file must be read from the file system")
+	assert.Equal(t, "****", secret.MaskValues("sops-secret-value"))
+
+	val, err = sops.New(fs, "secrets.enc.yaml", "db.port", "project").ResolveValue(parameter.ResolveContext{})
+	assert.NoError(t, err)
+	assert.Equal(t, "5432", val)
+
+	_, err = sops.New(fs, "secrets.enc.yaml", "db.user", "project").ResolveValue(parameter.ResolveContext{})
+	assert.ErrorContains(t, err, "file `secrets.enc.yaml` has no key `db.user`")
+
+	out, err := os.ReadFile(runs)
+	assert.NoError(t, err)
+	assert.Equal(t, "--decrypt --output-type json\n", string(out), "the file must only be decrypted once")
+}
+
+func TestResolveValue_Canceled(t *testing.T) {
+	givenFakeSops(t)
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "project/slow.yaml", []byte("slow"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	_, err := sops.New(fs, "slow.yaml", "password", "project").ResolveValue(parameter.ResolveContext{Context: ctx})
+	assert.ErrorContains(t, err, "failed to run sops")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestResolveValue_DecryptionFails(t *testing.T) {
+	givenFakeSops(t)
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "project/invalid.yaml", []byte("invalid"), 0600))
+
+	_, err := sops.New(fs, "invalid.yaml", "password", "project").ResolveValue(parameter.ResolveContext{})
+	assert.ErrorContains(t, err, "failed to decrypt `invalid.yaml`: Error: no matching keys found")
+}
+
+func TestResolveValue_MissingFile(t *testing.T) {
+	_, err := sops.New(afero.NewMemMapFs(), "missing.yaml", "password", "project").ResolveValue(parameter.ResolveContext{})
+	assert.ErrorContains(t, err, "failed to decrypt `missing.yaml`: failed to read file")
+}
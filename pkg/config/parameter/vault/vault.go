@@ -0,0 +1,211 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vault
+
+import (
+	stdContext "context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"io"
+	"net/http"
+	"os"
+	stdStrings "strings"
+	"sync"
+	"time"
+)
+
+// VaultParameterType specifies the type of the parameter used in config files
+const VaultParameterType = "vault"
+
+const (
+	// EnvVarAddress is the environment variable holding the address of the secret store, e.g. https://vault.example.com:8200
+	EnvVarAddress = "VAULT_ADDR"
+	// EnvVarToken is the environment variable holding the token used to authenticate at the secret store
+	EnvVarToken = "VAULT_TOKEN"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// cacheKey identifies a secret in a secret store
+type cacheKey struct {
+	address string
+	path    string
+}
+
+// cache holds the key-value pairs of each secret read, so that every secret is only read once, no matter how many
+// parameters load values from it
+var cache = struct {
+	sync.Mutex
+	secrets map[cacheKey]map[string]any
+}{secrets: make(map[cacheKey]map[string]any)}
+
+var VaultParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeVaultParameter,
+	Deserializer: parseVaultParameter,
+}
+
+// VaultParameter defines a parameter loading a secret value from an HTTP secret store compatible with the HashiCorp
+// Vault HTTP API. The secret at Path is read with a GET request to `<VAULT_ADDR>/v1/<Path>`, authenticated with the
+// `X-Vault-Token` header. Secrets of both KV version 1 and 2 engines are supported. The value is masked in logs.
+// Each secret is only read once, so that parameters loading several keys of the same secret do not read it repeatedly.
+type VaultParameter struct {
+	// Path of the secret, e.g. `secret/data/monaco` for the KV version 2 engine mounted at `secret`
+	Path string
+
+	// Key of the value in the secret
+	Key string
+}
+
+func New(path string, key string) *VaultParameter {
+	return &VaultParameter{
+		Path: path,
+		Key:  key,
+	}
+}
+
+// this forces the compiler to check if VaultParameter is of type Parameter
+var _ parameter.Parameter = (*VaultParameter)(nil)
+
+func (p *VaultParameter) GetType() string {
+	return VaultParameterType
+}
+
+func (p *VaultParameter) GetReferences() []parameter.ParameterReference {
+	// vault parameters cannot have references
+	return []parameter.ParameterReference{}
+}
+
+func (p *VaultParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	address, found := os.LookupEnv(EnvVarAddress)
+	if !found || address == "" {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("environment variable `%s` not set", EnvVarAddress))
+	}
+
+	ctx := context.Context
+	if ctx == nil {
+		ctx = stdContext.Background()
+	}
+
+	data, err := cachedSecret(ctx, address, os.Getenv(EnvVarToken), p.Path)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to read secret `%s`: %s", p.Path, err))
+	}
+
+	val, found := data[p.Key]
+	if !found {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("secret `%s` has no key `%s`", p.Path, p.Key))
+	}
+
+	return parameter.SecretValue(strings.ToString(val))
+}
+
+// cachedSecret returns the key-value pairs of the secret at the given path. The secret is only read from the secret
+// store if it was not read before.
+func cachedSecret(ctx stdContext.Context, address string, token string, path string) (map[string]any, error) {
+	key := cacheKey{address: address, path: path}
+
+	cache.Lock()
+	data, found := cache.secrets[key]
+	cache.Unlock()
+	if found {
+		return data, nil
+	}
+
+	data, err := readSecret(ctx, address, token, path)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Lock()
+	cache.secrets[key] = data
+	cache.Unlock()
+	return data, nil
+}
+
+// readSecret reads the secret at the given path, and returns its key-value pairs
+func readSecret(ctx stdContext.Context, address string, token string, path string) (map[string]any, error) {
+	url := fmt.Sprintf("%s/v1/%s", stdStrings.TrimSuffix(address, "/"), stdStrings.TrimPrefix(path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		// the token grants access to all secrets, so it must never show up in logs either
+		secret.Register(token)
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("secret store responded with HTTP %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// secrets of the KV version 2 engine are nested in an additional data object next to their metadata
+	if nested, ok := response.Data["data"].(map[string]any); ok {
+		if _, ok := response.Data["metadata"]; ok {
+			return nested, nil
+		}
+	}
+	return response.Data, nil
+}
+
+// parseVaultParameter parses a VaultParameter from a given context. it requires a `path` and a `key` field to be set.
+func parseVaultParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	path, ok := context.Value["path"]
+	if !ok || strings.ToString(path) == "" {
+		return nil, parameter.NewParameterParserError(context, "missing property `path`")
+	}
+
+	key, ok := context.Value["key"]
+	if !ok || strings.ToString(key) == "" {
+		return nil, parameter.NewParameterParserError(context, "missing property `key`")
+	}
+
+	return New(strings.ToString(path), strings.ToString(key)), nil
+}
+
+func writeVaultParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	vaultParam, ok := context.Parameter.(*VaultParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `VaultParameter`")
+	}
+
+	return map[string]interface{}{
+		"path": vaultParam.Path,
+		"key":  vaultParam.Key,
+	}, nil
+}
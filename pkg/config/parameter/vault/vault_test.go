@@ -0,0 +1,126 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vault_test
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/vault"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func givenSecretStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/monaco":
+			_, _ = w.Write([]byte(`{"data": {"data": {"apiToken": "kv2-secret"}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/monaco":
+			_, _ = w.Write([]byte(`{"data": {"apiToken": "kv1-secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv(vault.EnvVarAddress, server.URL)
+	t.Setenv(vault.EnvVarToken, "token")
+}
+
+func TestParseVaultParameter(t *testing.T) {
+	p, err := vault.VaultParameterSerde.Deserializer(parameter.ParameterParserContext{
+		Value: map[string]interface{}{"path": "secret/data/monaco", "key": "apiToken"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, vault.New("secret/data/monaco", "apiToken"), p)
+
+	_, err = vault.VaultParameterSerde.Deserializer(parameter.ParameterParserContext{Value: map[string]interface{}{"key": "apiToken"}})
+	assert.ErrorContains(t, err, "missing property `path`")
+}
+
+func TestWriteVaultParameter(t *testing.T) {
+	result, err := vault.VaultParameterSerde.Serializer(parameter.ParameterWriterContext{Parameter: vault.New("secret/data/monaco", "apiToken")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"path": "secret/data/monaco", "key": "apiToken"}, result)
+}
+
+func TestResolveValue(t *testing.T) {
+	givenSecretStore(t)
+
+	val, err := vault.New("secret/data/monaco", "apiToken").ResolveValue(parameter.ResolveContext{})
+	assert.NoError(t, err)
+	assert.Equal(t, "kv2-secret", val)
+
+	val, err = vault.New("kv/monaco", "apiToken").ResolveValue(parameter.ResolveContext{})
+	assert.NoError(t, err)
+	assert.Equal(t, "kv1-secret", val)
+
+	assert.Equal(t, "**** ****", secret.MaskValues("kv1-secret kv2-secret"))
+}
+
+func TestResolveValue_Errors(t *testing.T) {
+	givenSecretStore(t)
+
+	_, err := vault.New("secret/data/monaco", "missing").ResolveValue(parameter.ResolveContext{})
+	assert.ErrorContains(t, err, "secret `secret/data/monaco` has no key `missing`")
+
+	_, err = vault.New("secret/data/missing", "apiToken").ResolveValue(parameter.ResolveContext{})
+	assert.ErrorContains(t, err, "secret store responded with HTTP 404")
+
+	t.Setenv(vault.EnvVarAddress, "")
+	_, err = vault.New("secret/data/monaco", "apiToken").ResolveValue(parameter.ResolveContext{})
+	assert.ErrorContains(t, err, "environment variable `VAULT_ADDR` not set")
+}
+
+func TestResolveValue_SecretsAreReadOnce(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"data": {"user": "monaco", "password": "cached-secret"}}`))
+	}))
+	defer server.Close()
+
+	t.Setenv(vault.EnvVarAddress, server.URL)
+	t.Setenv(vault.EnvVarToken, "cached-token")
+
+	for _, key := range []string{"user", "password", "password"} {
+		_, err := vault.New("kv/cached", key).ResolveValue(parameter.ResolveContext{})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, "****", secret.MaskValues("cached-token"), "token must be masked")
+}
+
+func TestResolveValue_Canceled(t *testing.T) {
+	givenSecretStore(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := vault.New("kv/monaco", "apiToken").ResolveValue(parameter.ResolveContext{Context: ctx})
+	assert.ErrorContains(t, err, "context canceled")
+}
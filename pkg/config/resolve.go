@@ -17,6 +17,7 @@
 package config
 
 import (
+	"context"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
@@ -33,6 +34,14 @@ func resolveValues(
 
 	properties := make(parameter.Properties)
 
+	// monitored entities and remote objects can only be looked up if the given EntityLookup is able to find them
+	finder, _ := entities.(parameter.MonitoredEntityFinder)
+	objectFinder, _ := entities.(parameter.RemoteObjectFinder)
+	var ctx context.Context
+	if p, ok := entities.(parameter.ContextProvider); ok {
+		ctx = p.Context()
+	}
+
 	for _, container := range parameters {
 		name := container.Name
 		param := container.Parameter
@@ -51,6 +60,9 @@ func resolveValues(
 			Environment:             c.Environment,
 			ParameterName:           name,
 			ResolvedParameterValues: properties,
+			MonitoredEntityFinder:   finder,
+			RemoteObjectFinder:      objectFinder,
+			Context:                 ctx,
 		})
 
 		if err != nil {
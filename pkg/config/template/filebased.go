@@ -26,6 +26,7 @@ import (
 
 var (
 	_ Template = (*FileBasedTemplate)(nil)
+	_ Partials = (*FileBasedTemplate)(nil)
 )
 
 // FileBasedTemplate is a JSON Template stored in a file - when it's Template.Content is accessed, that file is read.
@@ -35,6 +36,8 @@ type FileBasedTemplate struct {
 	fs afero.Fs
 	// path of the template file
 	path string
+	// partialsPath is the folder partial templates included by the template are loaded from
+	partialsPath string
 }
 
 func (t *FileBasedTemplate) ID() string {
@@ -49,6 +52,12 @@ func (t *FileBasedTemplate) Content() (string, error) {
 	return string(b), nil
 }
 
+// Partial reads the partial template with the given name from the partials folder of the template. As partials are
+// read whenever the template is rendered, changes to a partial change the rendered content of all templates including it.
+func (t *FileBasedTemplate) Partial(name string) (string, error) {
+	return readPartial(t.fs, t.partialsPath, name)
+}
+
 func (t *FileBasedTemplate) FilePath() string {
 	return t.path
 }
@@ -65,9 +74,16 @@ func (t *FileBasedTemplate) UpdateContent(newContent string) error {
 	return nil
 }
 
+// WithPartials sets the folder partial templates included by a FileBasedTemplate are loaded from
+func WithPartials(path string) func(*FileBasedTemplate) {
+	return func(t *FileBasedTemplate) {
+		t.partialsPath = filepath.Clean(strings.ReplaceAll(path, `\`, `/`))
+	}
+}
+
 // NewFileTemplate creates a FileBasedTemplate for a given afero.Fs and filepath.
 // If the file can not be accessed an error will be returned.
-func NewFileTemplate(fs afero.Fs, path string) (Template, error) {
+func NewFileTemplate(fs afero.Fs, path string, opts ...func(*FileBasedTemplate)) (Template, error) {
 	sanitizedPath := filepath.Clean(strings.ReplaceAll(path, `\`, `/`))
 
 	log.Debug("Loading template for %s", sanitizedPath)
@@ -82,6 +98,9 @@ func NewFileTemplate(fs afero.Fs, path string) (Template, error) {
 		fs:   fs,
 		path: sanitizedPath,
 	}
+	for _, o := range opts {
+		o(&template)
+	}
 
 	return &template, nil
 }
@@ -86,3 +86,77 @@ func TestLoadTemplate_WorksWithAnyPathSeparator(t *testing.T) {
 		})
 	}
 }
+
+func givenTemplateWithPartials(t *testing.T, content string, partials map[string]string) template.Template {
+	fs := afero.NewMemMapFs()
+	assert.NilError(t, afero.WriteFile(fs, "proj/api/template.json", []byte(content), 0644))
+	for name, c := range partials {
+		assert.NilError(t, afero.WriteFile(fs, filepath.Join("proj", template.PartialsFolder, name), []byte(c), 0644))
+	}
+
+	tmpl, err := template.NewFileTemplate(fs, "proj/api/template.json", template.WithPartials("proj/"+template.PartialsFolder))
+	assert.NilError(t, err)
+	return tmpl
+}
+
+func TestRender_IncludesPartials(t *testing.T) {
+	tmpl := givenTemplateWithPartials(t,
+		`{"tiles": [{{ include "tiles/markdown" (dict "title" .name "index" 1) }}, {{ include "owner.json" }}]}`,
+		map[string]string{
+			"tiles/markdown.json": `{"name": "{{ .title }}", "index": {{ .index }}, "markdown": {{ include "text" (dict "text" .title) }}}`,
+			"text.json":           `"# {{ .text }}"`,
+			"owner.json":          `{"owner": "{{ .owner }}"}`,
+		})
+
+	rendered, err := template.Render(tmpl, map[string]interface{}{"name": "Dashboard", "owner": "team"})
+	assert.NilError(t, err)
+	assert.Equal(t, rendered, `{"tiles": [{"name": "Dashboard", "index": 1, "markdown": "# Dashboard"}, {"owner": "team"}]}`)
+}
+
+func TestRender_PartialErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		partials map[string]string
+		wantErr  string
+	}{
+		{
+			name:     "include cycle",
+			content:  `{{ include "a" }}`,
+			partials: map[string]string{"a.json": `{{ include "b" }}`, "b.json": `{{ include "a" }}`},
+			wantErr:  "include cycle detected: a -> b -> a",
+		},
+		{
+			name:    "missing partial",
+			content: `{{ include "missing" }}`,
+			wantErr: `failed to read partial "missing"`,
+		},
+		{
+			name:    "partial outside of partials folder",
+			content: `{{ include "../api/template" }}`,
+			wantErr: `partial "../api/template" is not located in the partials folder`,
+		},
+		{
+			name:     "missing data",
+			content:  `{{ include "a" (dict "other" 1) }}`,
+			partials: map[string]string{"a.json": `{{ .value }}`},
+			wantErr:  `failed to render partial "a"`,
+		},
+		{
+			name:    "invalid dict",
+			content: `{{ include "a" (dict "key") }}`,
+			wantErr: "dict requires an even number of arguments",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := template.Render(givenTemplateWithPartials(t, tt.content, tt.partials), map[string]interface{}{})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRender_PartialsAreNotSupportedByInMemoryTemplates(t *testing.T) {
+	_, err := template.Render(template.NewInMemoryTemplate("id", `{{ include "a" }}`), map[string]interface{}{})
+	assert.ErrorContains(t, err, "partial templates can not be included")
+}
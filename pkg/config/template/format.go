@@ -0,0 +1,158 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"gopkg.in/yaml.v2"
+	"strings"
+)
+
+// jsoncExtension is the file extension of JSON templates with comments
+const jsoncExtension = ".jsonc"
+
+// toJSON converts the rendered content of the template file at the given path to JSON, based on its file extension.
+// YAML templates are converted to JSON, and comments and trailing commas are removed from JSONC templates.
+// The content of all other templates is returned unchanged.
+//
+// As string values of parameters are escaped for JSON, they need to be used in double-quoted strings in YAML templates,
+// e.g. `name: "{{ .name }}"`.
+func toJSON(path string, rendered string) (string, error) {
+	switch {
+	case files.IsYamlFileExtension(path):
+		return yamlToJSON(rendered)
+	case strings.HasSuffix(path, jsoncExtension):
+		return stripJSONC(rendered), nil
+	default:
+		return rendered, nil
+	}
+}
+
+func yamlToJSON(content string) (string, error) {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(content), &v); err != nil {
+		return "", fmt.Errorf("rendered template is not valid YAML: %w", err)
+	}
+
+	out := bytes.Buffer{}
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(toJSONValue(v)); err != nil {
+		return "", fmt.Errorf("failed to convert YAML template to JSON: %w", err)
+	}
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
+// toJSONValue converts maps decoded from YAML to maps with string keys, as only those can be marshalled to JSON
+func toJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[fmt.Sprint(k)] = toJSONValue(val)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, val := range v {
+			l[i] = toJSONValue(val)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// stripJSONC removes line and block comments, as well as trailing commas in objects and arrays from the given JSONC
+// content. Line breaks are kept, so that positions in errors still match the lines of the template.
+func stripJSONC(content string) string {
+	out := strings.Builder{}
+	// pendingComma holds a comma and the whitespace following it, until it is known whether the comma is trailing
+	pendingComma := strings.Builder{}
+
+	flushComma := func() {
+		out.WriteString(pendingComma.String())
+		pendingComma.Reset()
+	}
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+
+		switch {
+		case c == '"':
+			flushComma()
+			end := i + 1
+			for end < len(content) && content[end] != '"' {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(content) {
+				end = len(content) - 1
+			}
+			out.WriteString(content[i : end+1])
+			i = end
+
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			i-- // keep the line break
+
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				end = len(content) - i - 2
+			}
+			comment := content[i : i+2+end]
+			pendingOrOut(&pendingComma, &out).WriteString(strings.Repeat("\n", strings.Count(comment, "\n")))
+			i += end + 3
+
+		case c == ',':
+			flushComma()
+			pendingComma.WriteByte(c)
+
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			pendingOrOut(&pendingComma, &out).WriteByte(c)
+
+		case c == '}' || c == ']':
+			// the comma is trailing, so only the whitespace following it is kept
+			out.WriteString(strings.TrimPrefix(pendingComma.String(), ","))
+			pendingComma.Reset()
+			out.WriteByte(c)
+
+		default:
+			flushComma()
+			out.WriteByte(c)
+		}
+	}
+	flushComma()
+
+	return out.String()
+}
+
+// pendingOrOut returns pending if it is not empty, and out otherwise
+func pendingOrOut(pending *strings.Builder, out *strings.Builder) *strings.Builder {
+	if pending.Len() > 0 {
+		return pending
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/spf13/afero"
+	"gotest.tools/assert"
+	"testing"
+)
+
+func renderFile(t *testing.T, path string, content string, properties map[string]interface{}) (string, error) {
+	fs := afero.NewMemMapFs()
+	assert.NilError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+
+	tmpl, err := template.NewFileTemplate(fs, path)
+	assert.NilError(t, err)
+	return template.Render(tmpl, properties)
+}
+
+func TestRender_ConvertsYamlTemplatesToJSON(t *testing.T) {
+	for _, path := range []string{"proj/api/template.yaml", "proj/api/template.yml"} {
+		t.Run(path, func(t *testing.T) {
+			content := `
+name: "{{ .name }}"
+enabled: true
+threshold: 1.5
+tags: ["a", "<b>"]
+rules:
+  - key: 1
+    value: null
+`
+			rendered, err := renderFile(t, path, content, map[string]interface{}{"name": `My \"config\"`}) // string parameters are escaped for JSON
+			assert.NilError(t, err)
+			assert.Equal(t, rendered, `{"enabled":true,"name":"My \"config\"","rules":[{"key":1,"value":null}],"tags":["a","<b>"],"threshold":1.5}`)
+		})
+	}
+}
+
+func TestRender_ReturnsErrorForInvalidYamlTemplates(t *testing.T) {
+	_, err := renderFile(t, "proj/api/template.yaml", "name: [unclosed", map[string]interface{}{})
+	assert.ErrorContains(t, err, "rendered template is not valid YAML")
+}
+
+func TestRender_StripsCommentsAndTrailingCommasFromJSONCTemplates(t *testing.T) {
+	content := `{
+  // the name of the config
+  "name": "{{ .name }}", /* inline */
+  "url": "https://example.com/path//not-a-comment",
+  "list": [1, 2, /* last */ 3,],
+  "nested": {
+    "a": "with, comma }",
+  },
+}`
+	rendered, err := renderFile(t, "proj/api/template.jsonc", content, map[string]interface{}{"name": "n"})
+	assert.NilError(t, err)
+	assert.Equal(t, rendered, `{
+  
+  "name": "n", 
+  "url": "https://example.com/path//not-a-comment",
+  "list": [1, 2,  3],
+  "nested": {
+    "a": "with, comma }"
+  }
+}`)
+}
+
+func TestRender_KeepsJSONTemplatesUnchanged(t *testing.T) {
+	rendered, err := renderFile(t, "proj/api/template.json", `{"a": 1, /* not stripped */}`, map[string]interface{}{})
+	assert.NilError(t, err)
+	assert.Equal(t, rendered, `{"a": 1, /* not stripped */}`)
+}
@@ -0,0 +1,60 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"fmt"
+	"github.com/spf13/afero"
+	"path/filepath"
+	"strings"
+)
+
+// PartialsFolder is the folder of a project partial templates are loaded from
+const PartialsFolder = "_partials"
+
+// Partials is implemented by Templates that can include partial templates.
+// Partials are included in templates using `{{ include "name" }}`, which renders the partial with the properties of
+// the config, or `{{ include "name" (dict "key" value ...) }}`, which renders the partial with the given data.
+// Partials can include other partials, as long as the includes do not form a cycle.
+type Partials interface {
+	// Partial returns the content of the partial template with the given name
+	Partial(name string) (string, error)
+}
+
+// readPartial reads the partial template with the given name from the given folder. Names without a file extension
+// refer to JSON files, e.g. `tiles/markdown` is read from `<folder>/tiles/markdown.json`.
+func readPartial(fs afero.Fs, folder string, name string) (string, error) {
+	if folder == "" {
+		return "", fmt.Errorf("partial templates can not be included")
+	}
+
+	path := filepath.FromSlash(name)
+	if filepath.Ext(path) == "" {
+		path += ".json"
+	}
+
+	path = filepath.Join(folder, path)
+	if rel, err := filepath.Rel(folder, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("partial %q is not located in the partials folder %q", name, folder)
+	}
+
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read partial %q: %w", name, err)
+	}
+	return string(b), nil
+}
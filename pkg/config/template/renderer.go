@@ -17,24 +17,29 @@ package template
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"strings"
 	templ "text/template" // nosemgrep: go.lang.security.audit.xss.import-text-template.import-text-template
 )
 
 // Render tries to render a given template with the given properties and returns the
 // resulting string. if any error occurs during rendering, an error is returned.
+// If the template implements Partials, partial templates can be included in it. YAML and JSONC template files are
+// converted to JSON after rendering.
 func Render(template Template, properties map[string]interface{}) (string, error) {
 	content, err := template.Content()
 	if err != nil {
 		return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
 	}
 
-	// special handling to fix the case that a payload was fetched that after the download and processing
-	// results in three subsequent {. This can happen e.g. if the payload allows to have content embraced between
-	// curly braces like {"somekey" : "some {VALUE}"}
-	content = strings.ReplaceAll(content, "{{{", "{{\"{\"}}{{")
-	parsedTemplate, err := ParseTemplate(template.ID(), content)
+	partials, _ := template.(Partials)
+	r := renderer{
+		partials:   partials,
+		properties: properties,
+		parsed:     make(map[string]*templ.Template),
+	}
 
+	parsedTemplate, err := r.parse(template.ID(), content)
 	if err != nil {
 		return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
 	}
@@ -46,9 +51,109 @@ func Render(template Template, properties map[string]interface{}) (string, error
 		return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
 	}
 
+	if t, ok := template.(*FileBasedTemplate); ok {
+		rendered, err := toJSON(t.FilePath(), result.String())
+		if err != nil {
+			return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
+		}
+		return rendered, nil
+	}
+
+	return result.String(), nil
+}
+
+// renderer renders a single template, including the partial templates it includes
+type renderer struct {
+	partials   Partials
+	properties map[string]interface{}
+	// parsed holds the partials that were already parsed by their name
+	parsed map[string]*templ.Template
+	// includes are the names of the partials currently being rendered, used to detect include cycles
+	includes []string
+}
+
+func (r *renderer) parse(id, content string) (*templ.Template, error) {
+	// special handling to fix the case that a payload was fetched that after the download and processing
+	// results in three subsequent {. This can happen e.g. if the payload allows to have content embraced between
+	// curly braces like {"somekey" : "some {VALUE}"}
+	content = strings.ReplaceAll(content, "{{{", "{{\"{\"}}{{")
+
+	return templ.New(id).Option("missingkey=error").Funcs(templ.FuncMap{
+		"include": r.include,
+		"dict":    dict,
+	}).Parse(content)
+}
+
+// include renders the partial with the given name. It is rendered with the given data, or with the properties of the
+// config if no data is given.
+func (r *renderer) include(name string, data ...interface{}) (string, error) {
+	if r.partials == nil {
+		return "", fmt.Errorf("partial templates can not be included")
+	}
+	if len(data) > 1 {
+		return "", fmt.Errorf("partial %q must be included with at most one data argument, but got %d", name, len(data))
+	}
+
+	if slices.Contains(r.includes, name) {
+		return "", fmt.Errorf("include cycle detected: %s -> %s", strings.Join(r.includes, " -> "), name)
+	}
+
+	t, err := r.partial(name)
+	if err != nil {
+		return "", err
+	}
+
+	var d interface{} = r.properties
+	if len(data) == 1 {
+		d = data[0]
+	}
+
+	r.includes = append(r.includes, name)
+	defer func() { r.includes = r.includes[:len(r.includes)-1] }()
+
+	result := bytes.Buffer{}
+	if err := t.Execute(&result, d); err != nil {
+		return "", fmt.Errorf("failed to render partial %q: %w", name, err)
+	}
 	return result.String(), nil
 }
 
+// partial returns the parsed partial with the given name
+func (r *renderer) partial(name string) (*templ.Template, error) {
+	if t, found := r.parsed[name]; found {
+		return t, nil
+	}
+
+	content, err := r.partials.Partial(name)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := r.parse(name, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse partial %q: %w", name, err)
+	}
+	r.parsed[name] = t
+	return t, nil
+}
+
+// dict returns a map of the given key-value pairs, to pass data to included partials
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("dict requires an even number of arguments, but got %d", len(pairs))
+	}
+
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict keys must be strings, but key %d is %v", i/2, pairs[i])
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
+
 // ParseTemplate creates go Template with the given id from the given string content
 // in any error occurs creating the template, an erro is returned
 func ParseTemplate(id, content string) (*templ.Template, error) {
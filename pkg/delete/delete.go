@@ -48,6 +48,12 @@ type DeleteEntries = map[configurationType][]pointer.DeletePointer
 func Configs(ctx context.Context, clients ClientSet, apis api.APIs, automationResources map[string]config.AutomationResource, entriesToDelete DeleteEntries) error {
 	deleteErrors := 0
 	for entryType, entries := range entriesToDelete {
+		if ctx.Err() != nil {
+			log.WithCtxFields(ctx).WithFields(field.Type(entryType)).Warn("Skipped deletion of %d configuration(s) of type %q as the deletion was canceled.", len(entries), entryType)
+			deleteErrors += 1
+			continue
+		}
+
 		var err error
 		if targetApi, isClassicAPI := apis[entryType]; isClassicAPI {
 			err = classic.Delete(ctx, clients.Classic, targetApi, entries, entryType)
@@ -85,29 +91,30 @@ func Configs(ctx context.Context, clients ClientSet, apis api.APIs, automationRe
 // Parameters:
 //   - ctx (context.Context): The context in which the function operates.
 //   - clients (ClientSet): A set of API clients used to collect and delete configurations from an environment.
-func All(ctx context.Context, clients ClientSet, apis api.APIs) error {
+//   - protected (pointer.Protected): The configurations whose objects are not deleted, see ProtectedConfigs.
+func All(ctx context.Context, clients ClientSet, apis api.APIs, protected pointer.Protected) error {
 	errs := 0
 
-	if err := classic.DeleteAll(ctx, clients.Classic, apis); err != nil {
+	if err := classic.DeleteAll(ctx, clients.Classic, apis, protected); err != nil {
 		log.Error("Failed to delete all classic API configurations: %v", err)
 		errs++
 	}
 
-	if err := setting.DeleteAll(ctx, clients.Settings); err != nil {
+	if err := setting.DeleteAll(ctx, clients.Settings, protected); err != nil {
 		log.Error("Failed to delete all Settings 2.0 objects: %v", err)
 		errs++
 	}
 
 	if reflect.ValueOf(clients.Automation).IsNil() {
 		log.Warn("Skipped deletion of Automation configurations as API client was unavailable.")
-	} else if err := automation.DeleteAll(ctx, clients.Automation); err != nil {
+	} else if err := automation.DeleteAll(ctx, clients.Automation, protected); err != nil {
 		log.Error("Failed to delete all Automation configurations: %v", err)
 		errs++
 	}
 
 	if reflect.ValueOf(clients.Buckets).IsNil() {
 		log.Warn("Skipped deletion of Grail Bucket configurations as API client was unavailable.")
-	} else if err := bucket.DeleteAll(ctx, clients.Buckets); err != nil {
+	} else if err := bucket.DeleteAll(ctx, clients.Buckets, protected); err != nil {
 		log.Error("Failed to delete all Grail Bucket configurations: %v", err)
 		errs++
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
 	monacoREST "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
 	"github.com/stretchr/testify/assert"
@@ -63,7 +64,7 @@ func TestDeleteSettings_LegacyExternalID(t *testing.T) {
 			}, nil
 
 		})
-		c.EXPECT().DeleteSettings(gomock.Eq("12345")).Return(nil)
+		c.EXPECT().DeleteSettings(gomock.Any(), gomock.Eq("12345")).Return(nil)
 		entriesToDelete := DeleteEntries{
 			"builtin:alerting.profile": {
 				{
@@ -118,7 +119,7 @@ func TestDeleteSettings_LegacyExternalID(t *testing.T) {
 				Value:         nil,
 			},
 		}, nil)
-		c.EXPECT().DeleteSettings(gomock.Eq("12345")).Return(fmt.Errorf("WHOPS"))
+		c.EXPECT().DeleteSettings(gomock.Any(), gomock.Eq("12345")).Return(fmt.Errorf("WHOPS"))
 		entriesToDelete := DeleteEntries{
 			"builtin:alerting.profile": {
 				{
@@ -151,7 +152,7 @@ func TestDeleteSettings(t *testing.T) {
 			}, nil
 
 		})
-		c.EXPECT().DeleteSettings(gomock.Eq("12345")).Return(nil)
+		c.EXPECT().DeleteSettings(gomock.Any(), gomock.Eq("12345")).Return(nil)
 		entriesToDelete := DeleteEntries{
 			"builtin:alerting.profile": {
 				{
@@ -209,7 +210,7 @@ func TestDeleteSettings(t *testing.T) {
 				Value:         nil,
 			},
 		}, nil)
-		c.EXPECT().DeleteSettings(gomock.Eq("12345")).Return(fmt.Errorf("WHOPS"))
+		c.EXPECT().DeleteSettings(gomock.Any(), gomock.Eq("12345")).Return(fmt.Errorf("WHOPS"))
 		entriesToDelete := DeleteEntries{
 			"builtin:alerting.profile": {
 				{
@@ -244,7 +245,7 @@ func TestDeleteSettings(t *testing.T) {
 			}, nil
 
 		})
-		c.EXPECT().DeleteSettings(gomock.Eq("12345")).Times(0) // deletion should not be attempted for non-deletable objects
+		c.EXPECT().DeleteSettings(gomock.Any(), gomock.Eq("12345")).Times(0) // deletion should not be attempted for non-deletable objects
 		entriesToDelete := DeleteEntries{
 			"builtin:alerting.profile": {
 				{
@@ -612,7 +613,7 @@ func TestSplitConfigsForDeletion(t *testing.T) {
 			c.EXPECT().ListConfigs(gomock.Any(), a).Return(tc.args.values, nil)
 
 			for _, id := range tc.expect.ids {
-				c.EXPECT().DeleteConfigById(a, id)
+				c.EXPECT().DeleteConfigById(gomock.Any(), a, id)
 			}
 
 			err := Configs(context.TODO(), ClientSet{Classic: c}, apiMap, automationTypes, entriesToDelete)
@@ -638,3 +639,26 @@ func TestSplitConfigsForDeletionClientReturnsError(t *testing.T) {
 
 	assert.NotEmpty(t, errs, "an error should be returned")
 }
+
+func TestWithoutProtected(t *testing.T) {
+	var protected pointer.Protected
+	protected.Add(coordinate.Coordinate{Project: "p", Type: "builtin:test", ConfigId: "setting"}, "")
+	protected.Add(coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: "mz"}, "Protected MZ")
+
+	entries := DeleteEntries{
+		"builtin:test": {
+			{Project: "p", Type: "builtin:test", Identifier: "setting"},
+			{Project: "p", Type: "builtin:test", Identifier: "other"},
+		},
+		"management-zone": {
+			{Type: "management-zone", Identifier: "Protected MZ"},
+			{Type: "management-zone", Identifier: "Other MZ"},
+		},
+	}
+
+	got := WithoutProtected(context.TODO(), entries, api.NewAPIs(), protected)
+	assert.Equal(t, DeleteEntries{
+		"builtin:test":    {{Project: "p", Type: "builtin:test", Identifier: "other"}},
+		"management-zone": {{Type: "management-zone", Identifier: "Other MZ"}},
+	}, got)
+}
@@ -81,7 +81,7 @@ func Delete(ctx context.Context, c Client, automationResource config.AutomationR
 //
 // Returns:
 //   - error: After all deletions where attempted an error is returned if any attempt failed.
-func DeleteAll(ctx context.Context, c Client) error {
+func DeleteAll(ctx context.Context, c Client, protected pointer.Protected) error {
 	errs := 0
 
 	resources := []config.AutomationResource{config.Workflow, config.BusinessCalendar, config.SchedulingRule}
@@ -117,6 +117,11 @@ func DeleteAll(ctx context.Context, c Client) error {
 		logger.Info("Deleting %d objects of type %q...", len(objects), resource)
 		for _, o := range objects {
 			logger := logger.WithFields(field.F("object", o))
+			if protected.ContainsObjectID(o.ID) {
+				logger.Warn("Skipping deletion of %v with ID %q, as its configuration is marked with 'preventDestroy'", resource, o.ID)
+				continue
+			}
+
 			logger.Debug("Deleting Automation object with id %q...", o.ID)
 			resp, err := c.Delete(ctx, t, o.ID)
 			if err != nil {
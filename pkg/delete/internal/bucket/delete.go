@@ -72,7 +72,7 @@ func Delete(ctx context.Context, c Client, entries []pointer.DeletePointer) erro
 //
 // Returns:
 //   - error: After all deletions where attempted an error is returned if any attempt failed.
-func DeleteAll(ctx context.Context, c Client) error {
+func DeleteAll(ctx context.Context, c Client, protected pointer.Protected) error {
 	logger := log.WithCtxFields(ctx).WithFields(field.Type("bucket"))
 	logger.Info("Collecting Grail Bucket configurations...")
 
@@ -105,6 +105,11 @@ func DeleteAll(ctx context.Context, c Client) error {
 			continue
 		}
 
+		if protected.ContainsObjectID(bucketName.BucketName) {
+			logger.Warn("Skipping deletion of bucket %q, as its configuration is marked with 'preventDestroy'", bucketName.BucketName)
+			continue
+		}
+
 		result, err := c.Delete(ctx, bucketName.BucketName)
 		if err != nil {
 			logger.Error("Failed to delete bucket %q - network error: %v", bucketName.BucketName, err)
@@ -63,7 +63,7 @@ func Delete(ctx context.Context, client dtclient.Client, theApi api.API, entries
 		vLog := logger.WithFields(field.Coordinate(v.AsCoordinate()), field.F("value", v))
 
 		vLog.Debug("Deleting %s with ID %s", targetApi, v.ID)
-		if err := client.DeleteConfigById(theApi, v.ID); err != nil {
+		if err := client.DeleteConfigById(ctx, theApi, v.ID); err != nil {
 			vLog.Error("Failed to delete %s with ID %s: %v", theApi.ID, v.ID, err)
 			deleteErrs++
 		}
@@ -85,7 +85,7 @@ func Delete(ctx context.Context, client dtclient.Client, theApi api.API, entries
 //
 // Returns:
 //   - error: After all deletions where attempted an error is returned if any attempt failed.
-func DeleteAll(ctx context.Context, client dtclient.ConfigClient, apis api.APIs) error {
+func DeleteAll(ctx context.Context, client dtclient.ConfigClient, apis api.APIs, protected pointer.Protected) error {
 
 	errs := 0
 
@@ -102,8 +102,13 @@ func DeleteAll(ctx context.Context, client dtclient.ConfigClient, apis api.APIs)
 
 		for _, v := range values {
 			logger := logger.WithFields(field.F("value", v))
+			if protected.ContainsName(a.ID, v.Name) || protected.ContainsObjectID(v.Id) {
+				logger.Warn("Skipping deletion of %s:%s, as its configuration is marked with 'preventDestroy'", a.ID, v.Id)
+				continue
+			}
+
 			logger.Debug("Deleting config %s:%s...", a.ID, v.Id)
-			err := client.DeleteConfigById(a, v.Id)
+			err := client.DeleteConfigById(ctx, a, v.Id)
 
 			if err != nil {
 				logger.WithFields(field.Error(err)).Error("Failed to delete %s with ID %s: %v", a.ID, v.Id, err)
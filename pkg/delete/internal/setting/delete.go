@@ -71,7 +71,7 @@ func Delete(ctx context.Context, c dtclient.Client, entries []pointer.DeletePoin
 			}
 
 			logger.Debug("Deleting settings object with objectId %q.", obj.ObjectId)
-			err := c.DeleteSettings(obj.ObjectId)
+			err := c.DeleteSettings(ctx, obj.ObjectId)
 			if err != nil {
 				logger.Error("Failed to delete settings object with object ID %s: %v", obj.ObjectId, err)
 				deleteErrs++
@@ -94,10 +94,10 @@ func Delete(ctx context.Context, c dtclient.Client, entries []pointer.DeletePoin
 //
 // Returns:
 //   - error: After all deletions where attempted an error is returned if any attempt failed.
-func DeleteAll(ctx context.Context, c dtclient.SettingsClient) error {
+func DeleteAll(ctx context.Context, c dtclient.SettingsClient, protected pointer.Protected) error {
 	errs := 0
 
-	schemas, err := c.ListSchemas()
+	schemas, err := c.ListSchemas(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch settings schemas. No settings will be deleted. Reason: %w", err)
 	}
@@ -127,8 +127,13 @@ func DeleteAll(ctx context.Context, c dtclient.SettingsClient) error {
 				continue
 			}
 
+			if isProtected(setting, protected) {
+				logger.WithFields(field.F("object", setting)).Warn("Skipping deletion of settings object with objectId %q, as its configuration is marked with 'preventDestroy'", setting.ObjectId)
+				continue
+			}
+
 			logger.WithFields(field.F("object", setting)).Debug("Deleting settings object with objectId %q...", setting.ObjectId)
-			err := c.DeleteSettings(setting.ObjectId)
+			err := c.DeleteSettings(ctx, setting.ObjectId)
 			if err != nil {
 				logger.Error("Failed to delete settings object with object ID %s: %v", setting.ObjectId, err)
 				errs++
@@ -142,3 +147,12 @@ func DeleteAll(ctx context.Context, c dtclient.SettingsClient) error {
 
 	return nil
 }
+
+// isProtected returns whether the settings object was deployed for a protected config
+func isProtected(o dtclient.DownloadSettingsObject, protected pointer.Protected) bool {
+	if protected.ContainsObjectID(o.ObjectId) {
+		return true
+	}
+	c, err := idutils.ParseExternalID(o.ExternalId)
+	return err == nil && protected.ContainsCoordinate(c)
+}
@@ -0,0 +1,86 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pointer
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+// Protected holds the configs whose objects must never be deleted, as they are marked with 'preventDestroy'.
+// The zero value protects nothing.
+type Protected struct {
+	coordinates map[coordinate.Coordinate]struct{}
+	// names holds the names of protected classic configs per API
+	names map[string]map[string]struct{}
+	// objectIDs holds the known IDs of protected objects
+	objectIDs map[string]struct{}
+}
+
+// Add protects the object of the config with the given coordinate. The name is used to match classic configs, the
+// object IDs to match objects by their ID. Empty names and IDs are ignored.
+func (p *Protected) Add(c coordinate.Coordinate, name string, objectIDs ...string) {
+	if p.coordinates == nil {
+		p.coordinates = make(map[coordinate.Coordinate]struct{})
+		p.names = make(map[string]map[string]struct{})
+		p.objectIDs = make(map[string]struct{})
+	}
+
+	p.coordinates[c] = struct{}{}
+	if name != "" {
+		if p.names[c.Type] == nil {
+			p.names[c.Type] = make(map[string]struct{})
+		}
+		p.names[c.Type][name] = struct{}{}
+	}
+	for _, id := range objectIDs {
+		if id != "" {
+			p.objectIDs[id] = struct{}{}
+		}
+	}
+}
+
+// IsEmpty returns whether no config is protected
+func (p Protected) IsEmpty() bool {
+	return len(p.coordinates) == 0
+}
+
+// ContainsCoordinate returns whether the config with the given coordinate is protected
+func (p Protected) ContainsCoordinate(c coordinate.Coordinate) bool {
+	_, found := p.coordinates[c]
+	return found
+}
+
+// ContainsName returns whether a classic config of the given API with the given name is protected
+func (p Protected) ContainsName(api string, name string) bool {
+	_, found := p.names[api][name]
+	return found
+}
+
+// ContainsObjectID returns whether the object with the given ID is protected
+func (p Protected) ContainsObjectID(id string) bool {
+	_, found := p.objectIDs[id]
+	return found
+}
+
+// ContainsPointer returns whether the delete pointer refers to a protected config. Pointers of classic configs are
+// matched by name or object ID, as that is what their Identifier holds.
+func (p Protected) ContainsPointer(d DeletePointer, isClassic bool) bool {
+	if isClassic {
+		return p.ContainsName(d.Type, d.Identifier) || p.ContainsObjectID(d.Identifier)
+	}
+	return p.ContainsCoordinate(d.AsCoordinate())
+}
@@ -0,0 +1,140 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package delete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+)
+
+// ProtectedConfigs returns the configs of the given projects that are marked with 'preventDestroy' for the given
+// environment. Classic configs are protected by name as well, so their name must be resolvable without deploying any
+// other config. If the name of a protected classic config can not be determined, an error is returned, as its object
+// could be deleted otherwise.
+func ProtectedConfigs(projects []project.Project, environment string) (pointer.Protected, error) {
+	var protected pointer.Protected
+	var errs []error
+	for _, p := range projects {
+		p.ForEveryConfigInEnvironmentDo(environment, func(c config.Config) {
+			if !c.Lifecycle.PreventDestroy {
+				return
+			}
+
+			var name string
+			ids := []string{c.OriginObjectId}
+			switch c.Type.(type) {
+			case config.ClassicApiType:
+				n, err := protectedName(c)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to determine the name of protected config %s: %w", c.Coordinate, err))
+					return
+				}
+				name = n
+			case config.AutomationType:
+				ids = append(ids, idutils.GenerateUUIDFromCoordinate(c.Coordinate))
+			case config.BucketType:
+				ids = append(ids, idutils.GenerateBucketName(c.Coordinate))
+			}
+
+			protected.Add(c.Coordinate, name, ids...)
+		})
+	}
+	return protected, errors.Join(errs...)
+}
+
+// protectedName resolves the name of the given config. Parameters the name refers to are resolved as well, references
+// to other configs are not supported.
+func protectedName(c config.Config) (string, error) {
+	properties := make(parameter.Properties)
+	if err := resolveLocally(c, config.NameParameter, properties, map[string]struct{}{}); err != nil {
+		return "", err
+	}
+
+	name, ok := properties[config.NameParameter].(string)
+	if !ok || name == "" {
+		return "", fmt.Errorf("name is not a non-empty string")
+	}
+	return name, nil
+}
+
+// resolveLocally resolves the given parameter of the config and all parameters of the config it refers to into the
+// given properties
+func resolveLocally(c config.Config, name string, properties parameter.Properties, visiting map[string]struct{}) error {
+	if _, resolved := properties[name]; resolved {
+		return nil
+	}
+	if _, cyclic := visiting[name]; cyclic {
+		return fmt.Errorf("parameter %q refers to itself", name)
+	}
+	visiting[name] = struct{}{}
+
+	p, found := c.Parameters[name]
+	if !found {
+		return fmt.Errorf("parameter %q is not defined", name)
+	}
+
+	for _, ref := range p.GetReferences() {
+		if ref.Config != c.Coordinate {
+			return fmt.Errorf("parameter %q refers to config %s", name, ref.Config)
+		}
+		if err := resolveLocally(c, ref.Property, properties, visiting); err != nil {
+			return err
+		}
+	}
+
+	val, err := p.ResolveValue(parameter.ResolveContext{
+		ConfigCoordinate:        c.Coordinate,
+		Group:                   c.Group,
+		Environment:             c.Environment,
+		ParameterName:           name,
+		ResolvedParameterValues: properties,
+	})
+	if err != nil {
+		return err
+	}
+	properties[name] = val
+	return nil
+}
+
+// WithoutProtected returns the entries to delete without the ones that refer to protected configs
+func WithoutProtected(ctx context.Context, entriesToDelete DeleteEntries, apis api.APIs, protected pointer.Protected) DeleteEntries {
+	if protected.IsEmpty() {
+		return entriesToDelete
+	}
+
+	result := make(DeleteEntries, len(entriesToDelete))
+	for entryType, entries := range entriesToDelete {
+		_, isClassic := apis[entryType]
+		for _, e := range entries {
+			if protected.ContainsPointer(e, isClassic) {
+				log.WithCtxFields(ctx).WithFields(field.Type(entryType)).Warn("Skipping deletion of %s, as its configuration is marked with 'preventDestroy'", e)
+				continue
+			}
+			result[entryType] = append(result[entryType], e)
+		}
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package delete
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
+	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func givenProtectedProject(configs ...config.Config) []project.Project {
+	return []project.Project{{
+		Id:      "p",
+		Configs: project.ConfigsPerTypePerEnvironments{"env": project.ConfigsPerType{"management-zone": configs}},
+	}}
+}
+
+func givenProtectedConfig(id string, parameters config.Parameters) config.Config {
+	return config.Config{
+		Coordinate:  coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: id},
+		Type:        config.ClassicApiType{Api: "management-zone"},
+		Environment: "env",
+		Parameters:  parameters,
+		Lifecycle:   config.Lifecycle{PreventDestroy: true},
+	}
+}
+
+func TestProtectedConfigs_ResolvesNames(t *testing.T) {
+	t.Setenv("PROTECTED_MZ_NAME", "From Environment")
+
+	c := givenProtectedConfig("compound", config.Parameters{
+		"prefix": envParam.New("PROTECTED_MZ_NAME"),
+		"suffix": valueParam.New("MZ"),
+	})
+	name, err := compound.New(config.NameParameter, "{{ .prefix }} {{ .suffix }}", []parameter.ParameterReference{
+		{Config: c.Coordinate, Property: "prefix"},
+		{Config: c.Coordinate, Property: "suffix"},
+	})
+	assert.NoError(t, err)
+	c.Parameters[config.NameParameter] = name
+
+	protected, err := ProtectedConfigs(givenProtectedProject(
+		givenProtectedConfig("value", config.Parameters{config.NameParameter: valueParam.New("Plain MZ")}),
+		givenProtectedConfig("environment", config.Parameters{config.NameParameter: envParam.New("PROTECTED_MZ_NAME")}),
+		c,
+	), "env")
+	assert.NoError(t, err)
+	assert.True(t, protected.ContainsName("management-zone", "Plain MZ"))
+	assert.True(t, protected.ContainsName("management-zone", "From Environment"))
+	assert.True(t, protected.ContainsName("management-zone", "From Environment MZ"))
+}
+
+func TestProtectedConfigs_FailsIfNameCanNotBeDetermined(t *testing.T) {
+	tests := []struct {
+		name      string
+		parameter parameter.Parameter
+	}{
+		{"reference to other config", refParam.New("p", "management-zone", "other", "name")},
+		{"missing environment variable", envParam.New("PROTECTED_MZ_MISSING")},
+		{"not a string", valueParam.New(42)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protected, err := ProtectedConfigs(givenProtectedProject(
+				givenProtectedConfig("mz", config.Parameters{config.NameParameter: tt.parameter}),
+			), "env")
+			assert.ErrorContains(t, err, "failed to determine the name of protected config p:management-zone:mz")
+			assert.False(t, protected.ContainsCoordinate(coordinate.Coordinate{Project: "p", Type: "management-zone", ConfigId: "mz"}))
+		})
+	}
+}
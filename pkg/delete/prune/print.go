@@ -0,0 +1,59 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package prune
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Print writes a human-readable summary of the objects to prune per environment to w.
+// Environments are printed in alphabetical order.
+func Print(w io.Writer, orphans map[string]Orphans) error {
+	b := strings.Builder{}
+
+	envs := make([]string, 0, len(orphans))
+	for env := range orphans {
+		envs = append(envs, env)
+	}
+	slices.Sort(envs)
+
+	total := 0
+	for _, env := range envs {
+		if len(orphans[env]) == 0 {
+			continue
+		}
+
+		b.WriteString(fmt.Sprintf("Environment %q:\n", env))
+		for _, o := range orphans[env] {
+			b.WriteString(fmt.Sprintf("  - delete %s", o.Coordinate))
+			if o.Identifier != o.Coordinate.ConfigId {
+				b.WriteString(fmt.Sprintf(" (%s)", o.Identifier))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		total += len(orphans[env])
+	}
+
+	b.WriteString(fmt.Sprintf("Prune: %d to delete\n", total))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
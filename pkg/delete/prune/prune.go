@@ -0,0 +1,280 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package prune finds objects that monaco deployed to an environment, but which no longer correspond to any config.
+package prune
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/buckettools"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/internal/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const bucketType = "bucket"
+
+// Orphan is an object on an environment that was deployed for a config that no longer exists
+type Orphan struct {
+	// Coordinate of the config the object was deployed for
+	Coordinate coordinate.Coordinate
+	// Identifier is used to find the object when deleting it. It is the config ID for all types but classic configs,
+	// for which it is the ID of the object.
+	Identifier string
+}
+
+// Orphans are all objects to prune on an environment
+type Orphans []Orphan
+
+// DeleteEntries returns the delete pointers of the objects, which can be passed to delete.Configs to remove them
+func (o Orphans) DeleteEntries() delete.DeleteEntries {
+	entries := make(delete.DeleteEntries)
+	for _, orphan := range o {
+		c := orphan.Coordinate
+		entries[c.Type] = append(entries[c.Type], pointer.DeletePointer{Project: c.Project, Type: c.Type, Identifier: orphan.Identifier})
+	}
+	return entries
+}
+
+// Find returns the objects on the environment that were deployed by monaco for one of the given projects, but do not
+// correspond to any of their configs anymore. Objects of projects that are not given are never returned.
+//
+// Monaco-managed objects are identified by:
+//   - Settings 2.0 objects: the external ID generated from the config's coordinate
+//   - all types: the objects recorded in the deployment state, if a state is given. Automation objects and Grail
+//     Buckets are only returned if their ID or name was generated from the config's coordinate. Grail Buckets are
+//     only returned if they still exist.
+//
+// As bucket names can not be told apart from the names of buckets created by other means, buckets are never pruned
+// by their name alone.
+//
+// The returned objects are sorted by their coordinate.
+func Find(ctx context.Context, clients delete.ClientSet, apis api.APIs, projects []project.Project, environment string, s *state.State) (Orphans, error) {
+	f := finder{
+		projects: make(map[string]struct{}, len(projects)),
+		known:    make(map[coordinate.Coordinate]struct{}),
+		orphans:  make(map[coordinate.Coordinate]struct{}),
+	}
+	for _, p := range projects {
+		f.projects[p.Id] = struct{}{}
+		p.ForEveryConfigInEnvironmentDo(environment, func(c config.Config) {
+			f.known[c.Coordinate] = struct{}{}
+		})
+	}
+
+	if err := f.findSettings(ctx, clients.Settings); err != nil {
+		return nil, err
+	}
+
+	if s == nil {
+		log.WithCtxFields(ctx).Debug("Skipped searching Grail Bucket configurations to prune as no deployment state is given.")
+	} else if isNil(clients.Buckets) {
+		log.WithCtxFields(ctx).Warn("Skipped searching Grail Bucket configurations to prune as API client was unavailable.")
+	} else if err := f.findBuckets(ctx, clients.Buckets, recordedBuckets(environment, s)); err != nil {
+		return nil, err
+	}
+
+	if s != nil {
+		f.findRecorded(apis, environment, s)
+	}
+
+	return f.result(), nil
+}
+
+type finder struct {
+	// projects holds the IDs of all projects objects may be pruned for
+	projects map[string]struct{}
+	// known holds the coordinates of all configs that still exist
+	known map[coordinate.Coordinate]struct{}
+	// orphans holds the coordinates of all objects to prune
+	orphans map[coordinate.Coordinate]struct{}
+	// identifiers holds identifiers that differ from the config ID of the orphan's coordinate
+	identifiers map[coordinate.Coordinate]string
+}
+
+func (f *finder) add(c coordinate.Coordinate) bool {
+	if _, managed := f.projects[c.Project]; !managed {
+		return false
+	}
+	if _, exists := f.known[c]; exists {
+		return false
+	}
+	f.orphans[c] = struct{}{}
+	return true
+}
+
+func (f *finder) findSettings(ctx context.Context, c dtclient.SettingsClient) error {
+	schemas, err := c.ListSchemas(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch settings schemas: %w", err)
+	}
+
+	for _, schema := range schemas {
+		objects, err := c.ListSettings(ctx, schema.SchemaId, dtclient.ListSettingsOptions{
+			DiscardValue: true,
+			Filter: func(o dtclient.DownloadSettingsObject) bool {
+				return strings.HasPrefix(o.ExternalId, "monaco:")
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch settings objects of schema %q: %w", schema.SchemaId, err)
+		}
+
+		for _, o := range objects {
+			coord, err := idutils.ParseExternalID(o.ExternalId)
+			if err != nil {
+				log.WithCtxFields(ctx).WithFields(field.Type(schema.SchemaId), field.F("object", o)).Debug("Ignoring settings object %q: %v", o.ObjectId, err)
+				continue
+			}
+			if o.ModificationInfo != nil && !o.ModificationInfo.Deletable {
+				continue
+			}
+			f.add(coord)
+		}
+	}
+	return nil
+}
+
+func (f *finder) findBuckets(ctx context.Context, c bucket.Client, recorded map[string]coordinate.Coordinate) error {
+	if len(recorded) == 0 {
+		return nil
+	}
+
+	resp, err := c.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Grail Bucket configurations: %w", err)
+	}
+	if err, isErr := resp.AsAPIError(); isErr {
+		return fmt.Errorf("failed to fetch Grail Bucket configurations: %w", err)
+	}
+
+	for _, raw := range resp.All() {
+		name, err := bucketName(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse Grail Bucket configuration: %w", err)
+		}
+		if buckettools.IsDefault(name) {
+			continue
+		}
+
+		if coord, found := recorded[name]; found {
+			f.add(coord)
+		}
+	}
+	return nil
+}
+
+// recordedBuckets returns the coordinates of all buckets recorded in the state by their name. Buckets that were not
+// deployed with the name generated by idutils.GenerateBucketName are left out, as they are deleted by that name.
+func recordedBuckets(environment string, s *state.State) map[string]coordinate.Coordinate {
+	result := make(map[string]coordinate.Coordinate)
+	for _, c := range s.Coordinates(environment) {
+		if c.Type != bucketType {
+			continue
+		}
+
+		name := idutils.GenerateBucketName(c)
+		if entry, _ := s.Get(environment, c); entry.ObjectID == name {
+			result[name] = c
+		}
+	}
+	return result
+}
+
+// findRecorded adds all objects recorded in the state that no longer correspond to a config
+func (f *finder) findRecorded(apis api.APIs, environment string, s *state.State) {
+	for _, c := range s.Coordinates(environment) {
+		entry, _ := s.Get(environment, c)
+
+		if _, isClassic := apis[c.Type]; isClassic {
+			// classic configs are deleted by name or ID, as the name is not recorded the ID is used
+			if f.add(c) {
+				f.setIdentifier(c, entry.ObjectID)
+			}
+			continue
+		}
+
+		if c.Type == bucketType {
+			// buckets are only pruned if they still exist, see findBuckets
+			continue
+		}
+
+		if isAutomation(c.Type) && entry.ObjectID != idutils.GenerateUUIDFromCoordinate(c) {
+			// automation objects are deleted by their generated ID, objects with other IDs can not be pruned
+			continue
+		}
+
+		f.add(c)
+	}
+}
+
+func (f *finder) setIdentifier(c coordinate.Coordinate, identifier string) {
+	if f.identifiers == nil {
+		f.identifiers = make(map[coordinate.Coordinate]string)
+	}
+	f.identifiers[c] = identifier
+}
+
+func (f *finder) result() Orphans {
+	result := make(Orphans, 0, len(f.orphans))
+	for c := range f.orphans {
+		identifier := c.ConfigId
+		if id, found := f.identifiers[c]; found {
+			identifier = id
+		}
+		result = append(result, Orphan{Coordinate: c, Identifier: identifier})
+	}
+
+	slices.SortFunc(result, func(a, b Orphan) int {
+		return strings.Compare(a.Coordinate.String(), b.Coordinate.String())
+	})
+	return result
+}
+
+func isAutomation(t string) bool {
+	return t == string(config.Workflow) || t == string(config.BusinessCalendar) || t == string(config.SchedulingRule)
+}
+
+// isNil returns true if the given client is nil, or an interface holding a nil pointer
+func isNil(c any) bool {
+	if c == nil {
+		return true
+	}
+	v := reflect.ValueOf(c)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+func bucketName(raw []byte) (string, error) {
+	var b struct {
+		BucketName string `json:"bucketName"`
+	}
+	err := json.Unmarshal(raw, &b)
+	return b.BucketName, err
+}
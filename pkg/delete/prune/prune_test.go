@@ -0,0 +1,178 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package prune_test
+
+import (
+	"bytes"
+	"context"
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/buckets"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/prune"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+type bucketClient struct {
+	names []string
+}
+
+func (c bucketClient) List(_ context.Context) (buckets.ListResponse, error) {
+	objects := make([][]byte, len(c.names))
+	for i, n := range c.names {
+		objects[i] = []byte(`{"bucketName": "` + n + `"}`)
+	}
+	return buckets.ListResponse{{Response: coreapi.Response{StatusCode: 200}, Objects: objects}}, nil
+}
+
+func (c bucketClient) Delete(_ context.Context, _ string) (buckets.Response, error) {
+	panic("unexpected call")
+}
+
+func givenProjects() []project.Project {
+	return []project.Project{
+		{
+			Id: "project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:alerting.profile": []config.Config{
+						{Coordinate: coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "kept"}},
+					},
+					"bucket": []config.Config{
+						{Coordinate: coordinate.Coordinate{Project: "project", Type: "bucket", ConfigId: "kept-bucket"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func externalID(t *testing.T, c coordinate.Coordinate) string {
+	id, err := idutils.GenerateExternalID(c)
+	assert.NoError(t, err)
+	return id
+}
+
+func TestFind(t *testing.T) {
+	removedSetting := coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "removed"}
+	keptSetting := coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "kept"}
+	otherProjectSetting := coordinate.Coordinate{Project: "other", Type: "builtin:alerting.profile", ConfigId: "removed"}
+	removedWorkflow := coordinate.Coordinate{Project: "project", Type: "workflow", ConfigId: "removed-workflow"}
+	downloadedWorkflow := coordinate.Coordinate{Project: "project", Type: "workflow", ConfigId: "downloaded-workflow"}
+	removedDashboard := coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "removed-dashboard"}
+	removedBucket := coordinate.Coordinate{Project: "project", Type: "bucket", ConfigId: "removed-bucket"}
+	deletedBucket := coordinate.Coordinate{Project: "project", Type: "bucket", ConfigId: "deleted-bucket"}
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListSchemas(gomock.Any()).Return(dtclient.SchemaList{{SchemaId: "builtin:alerting.profile"}}, nil)
+	c.EXPECT().ListSettings(gomock.Any(), "builtin:alerting.profile", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+		var result []dtclient.DownloadSettingsObject
+		for _, o := range []dtclient.DownloadSettingsObject{
+			{ObjectId: "1", ExternalId: externalID(t, removedSetting)},
+			{ObjectId: "2", ExternalId: externalID(t, keptSetting)},
+			{ObjectId: "3", ExternalId: externalID(t, otherProjectSetting)},
+			{ObjectId: "4", ExternalId: "created-manually"},
+		} {
+			if opts.Filter(o) {
+				result = append(result, o)
+			}
+		}
+		return result, nil
+	})
+
+	s := state.New()
+	s.Put("env", removedWorkflow, state.Entry{ObjectID: idutils.GenerateUUIDFromCoordinate(removedWorkflow)})
+	s.Put("env", downloadedWorkflow, state.Entry{ObjectID: "origin-id"})
+	s.Put("env", removedDashboard, state.Entry{ObjectID: "dashboard-id"})
+	s.Put("env", keptSetting, state.Entry{ObjectID: "2"})
+	s.Put("env", removedBucket, state.Entry{ObjectID: "project_removed-bucket"})
+	s.Put("env", deletedBucket, state.Entry{ObjectID: "project_deleted-bucket"})
+
+	clients := delete.ClientSet{
+		Settings: c,
+		Buckets:  bucketClient{names: []string{"project_kept-bucket", "project_removed-bucket", "project_created-manually", "other_bucket", "default_logs"}},
+	}
+
+	orphans, err := prune.Find(context.TODO(), clients, api.APIs{"dashboard": api.API{ID: "dashboard"}}, givenProjects(), "env", s)
+	assert.NoError(t, err)
+	assert.Equal(t, prune.Orphans{
+		{Coordinate: removedBucket, Identifier: "removed-bucket"},
+		{Coordinate: removedSetting, Identifier: "removed"},
+		{Coordinate: removedDashboard, Identifier: "dashboard-id"},
+		{Coordinate: removedWorkflow, Identifier: "removed-workflow"},
+	}, orphans)
+
+	assert.Equal(t, delete.DeleteEntries{
+		"builtin:alerting.profile": {{Project: "project", Type: "builtin:alerting.profile", Identifier: "removed"}},
+		"bucket":                   {{Project: "project", Type: "bucket", Identifier: "removed-bucket"}},
+		"dashboard":                {{Project: "project", Type: "dashboard", Identifier: "dashboard-id"}},
+		"workflow":                 {{Project: "project", Type: "workflow", Identifier: "removed-workflow"}},
+	}, orphans.DeleteEntries())
+}
+
+func TestFind_OnlyPrunesRecordedBuckets(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListSchemas(gomock.Any()).Return(dtclient.SchemaList{}, nil).Times(2)
+
+	clients := delete.ClientSet{
+		Settings: c,
+		Buckets:  bucketClient{names: []string{"project_removed-bucket", "project_foo"}},
+	}
+
+	orphans, err := prune.Find(context.TODO(), clients, api.APIs{}, givenProjects(), "env", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, orphans, "buckets must not be pruned without a state")
+
+	s := state.New()
+	s.Put("env", coordinate.Coordinate{Project: "project", Type: "bucket", ConfigId: "removed-bucket"}, state.Entry{ObjectID: "project_removed-bucket"})
+	s.Put("env", coordinate.Coordinate{Project: "project", Type: "bucket", ConfigId: "downloaded-bucket"}, state.Entry{ObjectID: "project_foo"})
+
+	orphans, err = prune.Find(context.TODO(), clients, api.APIs{}, givenProjects(), "env", s)
+	assert.NoError(t, err)
+	assert.Equal(t, prune.Orphans{
+		{Coordinate: coordinate.Coordinate{Project: "project", Type: "bucket", ConfigId: "removed-bucket"}, Identifier: "removed-bucket"},
+	}, orphans, "buckets created by other means must survive")
+}
+
+func TestPrint(t *testing.T) {
+	orphans := map[string]prune.Orphans{
+		"env2": {},
+		"env1": {
+			{Coordinate: coordinate.Coordinate{Project: "p", Type: "builtin:alerting.profile", ConfigId: "removed"}, Identifier: "removed"},
+			{Coordinate: coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "dashboard"}, Identifier: "dashboard-id"},
+		},
+	}
+
+	out := bytes.Buffer{}
+	assert.NoError(t, prune.Print(&out, orphans))
+	assert.Equal(t, `Environment "env1":
+  - delete p:builtin:alerting.profile:removed
+  - delete p:dashboard:dashboard (dashboard-id)
+
+Prune: 2 to delete
+`, out.String())
+}
@@ -18,25 +18,35 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/concurrency"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/mutlierror"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/automation"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/validate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/report"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
 	clientErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
 	gonum "gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // DeployConfigsOptions defines additional options used by DeployConfigs
@@ -47,6 +57,36 @@ type DeployConfigsOptions struct {
 	// DryRun states that the deployment shall just run in dry-run mode, meaning
 	// that actual deployment of the configuration to a tenant will be skipped
 	DryRun bool
+	// State is used to look up the objects configs were previously deployed to, and records the objects configs are
+	// deployed to. It is optional and not used in dry-run mode.
+	// Configs whose rendered payload and parameter values are unchanged since their last deployment recorded in the
+	// State are not deployed again, unless Force is set.
+	State *state.State
+	// Force states that all configs are deployed, even if they are unchanged since their last deployment recorded in
+	// the State
+	Force bool
+	// Snapshot captures the objects the deployment updates before they are changed, and the objects it creates.
+	// It is optional and not used in dry-run mode.
+	Snapshot *snapshot.Snapshot
+	// Report records the result of deploying each config. It is optional.
+	Report *report.Report
+	// MaxConcurrentDeployments limits how many configs are deployed to an environment at once. If it is 0 or less,
+	// all configs that do not depend on each other are deployed at once.
+	MaxConcurrentDeployments int
+	// Hooks are run before and after configs are deployed to the environment of the given name. Hooks of configs are
+	// defined by the configs themselves. If a hook fails, the deployment of the environment or config fails.
+	// Hooks are not run in dry-run mode.
+	Hooks map[string]hook.Hooks
+	// Verify states how deployed objects are verified. Unless it is NoVerification, each deployed object is read back
+	// from the environment and compared to the rendered config, to find fields the API dropped or changed.
+	// Verification is not done in dry-run mode.
+	Verify Verification
+	// DeploymentEvent configures the Dynatrace event that is sent to each environment after configs were deployed to
+	// it, listing the configs that were changed. If it is nil, no event is sent. Events are not sent in dry-run mode.
+	DeploymentEvent *EventOptions
+	// MaxParallelEnvironments limits to how many environments configs are deployed at once. If it is 1 or less,
+	// environments are deployed one after another.
+	MaxParallelEnvironments int
 }
 
 type ClientSet struct {
@@ -54,6 +94,8 @@ type ClientSet struct {
 	Settings   dtclient.Client
 	Automation automation.Client
 	Bucket     bucket.Client
+	Events     dtclient.EventsClient
+	Entities   dtclient.EntitiesClient
 }
 
 var DummyClientSet = ClientSet{
@@ -61,6 +103,8 @@ var DummyClientSet = ClientSet{
 	Settings:   &dtclient.DummyClient{},
 	Automation: &automation.DummyClient{},
 	Bucket:     &bucket.DummyClient{},
+	Events:     &dtclient.DummyClient{},
+	Entities:   &dtclient.DummyClient{},
 }
 
 type EnvironmentInfo struct {
@@ -77,13 +121,35 @@ func (e EnvironmentClients) Names() []string {
 	return n
 }
 
+// environmentDeployment holds what is needed to deploy configs to a single environment
+type environmentDeployment struct {
+	env     EnvironmentInfo
+	clients ClientSet
+	opts    DeployConfigsOptions
+	// limiter limits how many configs are deployed to the environment at once
+	limiter *concurrency.Limiter
+	// entityFinder finds the monitored entities of the environment for entity parameters
+	entityFinder *MonitoredEntityFinder
+	// objectFinder finds existing objects of the environment for lookup parameters
+	objectFinder *RemoteObjectFinder
+}
+
 var (
 	lock sync.Mutex
 
 	skipError = errors.New("skip error")
 )
 
-func Deploy(projects []project.Project, environmentClients EnvironmentClients, opts DeployConfigsOptions) error {
+// Deploy deploys the configs of all projects to the given environments.
+// Once ctx is canceled, no further configs are deployed. API calls of configs that are already being deployed are not
+// canceled, but finish. All configs that were not deployed are reported as report.Canceled, and a summary of what was
+// and was not deployed is logged for each environment.
+func Deploy(ctx context.Context, projects []project.Project, environmentClients EnvironmentClients, opts DeployConfigsOptions) error {
+	if opts.Report == nil {
+		// a report is always needed to summarize canceled deployments
+		opts.Report = report.New()
+	}
+
 	g := graph.New(projects, environmentClients.Names())
 	deploymentErrors := make(deployErrors.EnvironmentDeploymentErrors)
 
@@ -94,24 +160,87 @@ func Deploy(projects []project.Project, environmentClients EnvironmentClients, o
 		errors.As(validationErrs, &deploymentErrors)
 	}
 
-	for env, clients := range environmentClients {
-		ctx := createContextWithEnvironment(env)
-		log.WithCtxFields(ctx).Info("Deploying configurations to environment %q...", env.Name)
-
-		sortedConfigs, err := g.GetIndependentlySortedConfigs(env.Name)
+	sortedConfigs := make(map[EnvironmentInfo][]graph.SortedComponent, len(environmentClients))
+	for env := range environmentClients {
+		components, err := g.GetIndependentlySortedConfigs(env.Name)
 		if err != nil {
 			return fmt.Errorf("failed to get independently sorted configs for environment %q: %w", env.Name, err)
 		}
+		sortedConfigs[env] = components
+	}
+
+	var errMutex sync.Mutex
+	var wg sync.WaitGroup
+	var aborted atomic.Bool
 
-		if err = deployComponents(ctx, sortedConfigs, clients); err != nil {
-			log.WithFields(field.Environment(env.Name, env.Group), field.Error(err)).Error("Deployment failed for environment %q: %v", env.Name, err)
-			deploymentErrors = deploymentErrors.Append(env.Name, err)
-			if !opts.ContinueOnErr && !opts.DryRun {
-				return deploymentErrors
+	// environments are deployed sequentially, unless more parallel environments are allowed
+	limiter := concurrency.NewLimiter(max(opts.MaxParallelEnvironments, 1))
+	defer limiter.Close()
+
+	for env, clients := range environmentClients {
+		d := environmentDeployment{
+			env:     env,
+			clients: clients,
+			opts:    opts,
+			limiter: concurrency.NewLimiter(opts.MaxConcurrentDeployments),
+		}
+		components := sortedConfigs[env]
+
+		wg.Add(1)
+		limiter.Execute(func() {
+			defer wg.Done()
+			defer d.limiter.Close()
+
+			// if deploying to an environment failed, deployments to environments that have not started yet are skipped
+			if aborted.Load() {
+				return
 			}
-		} else {
-			log.WithFields(field.Environment(env.Name, env.Group)).Info("Deployment successful for environment %q", env.Name)
+
+			ctx := createContextWithEnvironment(ctx, d.env)
+			d.entityFinder = NewMonitoredEntityFinder(context.WithoutCancel(ctx), d.clients.Entities)
+			d.objectFinder = NewRemoteObjectFinder(context.WithoutCancel(ctx), d.clients, d.opts.DryRun)
+			if ctx.Err() != nil {
+				log.WithCtxFields(ctx).Warn("Skipping deployment to environment %q, as the deployment was canceled", d.env.Name)
+				d.reportCanceled(components)
+				return
+			}
+
+			log.WithCtxFields(ctx).Info("Deploying configurations to environment %q...", d.env.Name)
+			d.opts.Report.Start(d.env.Name)
+
+			err := d.runEnvironmentHooks(ctx, hook.PreDeploy)
+			if err != nil {
+				d.reportComponents(components, "pre-deploy hook of environment failed", report.Skipped)
+			} else if err = deployComponents(ctx, components, d); err == nil && ctx.Err() == nil {
+				err = d.runEnvironmentHooks(ctx, hook.PostDeploy)
+			}
+			d.sendDeploymentEvent(ctx, err)
+
+			if err != nil {
+				log.WithFields(field.Environment(d.env.Name, d.env.Group), field.Error(err)).Error("Deployment failed for environment %q: %v", d.env.Name, err)
+
+				errMutex.Lock()
+				deploymentErrors = deploymentErrors.Append(d.env.Name, err)
+				errMutex.Unlock()
+
+				if !opts.ContinueOnErr && !opts.DryRun {
+					aborted.Store(true)
+				}
+			} else {
+				log.WithFields(field.Environment(d.env.Name, d.env.Group)).Info("Deployment successful for environment %q", d.env.Name)
+			}
+		})
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		logCanceledSummary(environmentClients, opts.Report)
+
+		err := fmt.Errorf("deployment was canceled: %w", context.Cause(ctx))
+		if len(deploymentErrors) != 0 {
+			return errors.Join(err, deploymentErrors)
 		}
+		return err
 	}
 
 	if len(deploymentErrors) != 0 {
@@ -121,7 +250,17 @@ func Deploy(projects []project.Project, environmentClients EnvironmentClients, o
 	return nil
 }
 
-func deployComponents(ctx context.Context, components []graph.SortedComponent, clients ClientSet) error {
+// logCanceledSummary logs for each environment how many configs were deployed, failed, and were not deployed because
+// the deployment was canceled
+func logCanceledSummary(environmentClients EnvironmentClients, r *report.Report) {
+	for env := range environmentClients {
+		applied := r.Count(env.Name, report.Deployed) + r.Count(env.Name, report.Unchanged)
+		log.WithFields(field.Environment(env.Name, env.Group)).Warn("Deployment to environment %q was canceled: %d configuration(s) applied, %d failed, %d not applied",
+			env.Name, applied, r.Count(env.Name, report.Failed), r.Count(env.Name, report.Canceled))
+	}
+}
+
+func deployComponents(ctx context.Context, components []graph.SortedComponent, d environmentDeployment) error {
 	log.WithCtxFields(ctx).Info("Deploying %d independent configuration sets in parallel...", len(components))
 	errCount := 0
 	errChan := make(chan error, len(components))
@@ -130,7 +269,7 @@ func deployComponents(ctx context.Context, components []graph.SortedComponent, c
 	// Iterate over components and launch a goroutine for each component deployment.
 	for i := range components {
 		go func(ctx context.Context, component graph.SortedComponent) {
-			errChan <- deployGraph(ctx, component.Graph, clients, resolvedEntities)
+			errChan <- deployGraph(ctx, component.Graph, d, resolvedEntities)
 		}(context.WithValue(ctx, log.CtxGraphComponentId{}, log.CtxValGraphComponentId(i)), components[i])
 	}
 
@@ -153,7 +292,7 @@ func deployComponents(ctx context.Context, components []graph.SortedComponent, c
 	return nil
 }
 
-func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clients ClientSet, resolvedEntities *entities.EntityMap) error {
+func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, d environmentDeployment, resolvedEntities *entities.EntityMap) error {
 	g := simple.NewDirectedGraph()
 	gonum.Copy(g, configGraph)
 
@@ -166,7 +305,7 @@ func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clients
 		for _, root := range roots {
 			node := root.(graph.ConfigNode)
 			go func(ctx context.Context, node graph.ConfigNode) {
-				errChan <- deployNode(ctx, node, configGraph, clients, resolvedEntities)
+				errChan <- deployNode(ctx, node, configGraph, d, resolvedEntities)
 			}(context.WithValue(ctx, log.CtxKeyCoord{}, node.Config.Coordinate), node)
 		}
 
@@ -192,14 +331,35 @@ func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, clients
 	return nil
 }
 
-func deployNode(ctx context.Context, n graph.ConfigNode, configGraph graph.ConfigGraph, clients ClientSet, resolvedEntities *entities.EntityMap) error {
-	resolvedEntity, err := deployConfig(ctx, n.Config, clients, resolvedEntities)
+func deployNode(ctx context.Context, n graph.ConfigNode, configGraph graph.ConfigGraph, d environmentDeployment, resolvedEntities *entities.EntityMap) error {
+	var resolvedEntity entities.ResolvedEntity
+	var err error
+	var canceled bool
+	d.limiter.ExecuteBlocking(func() {
+		// configs that are waiting to be deployed when the deployment is canceled are not deployed anymore
+		if ctx.Err() != nil {
+			canceled = true
+			return
+		}
+
+		start := time.Now()
+		var unchanged bool
+		var mismatches []report.Mismatch
+		resolvedEntity, unchanged, mismatches, err = deployConfig(ctx, n.Config, d, resolvedEntities)
+		d.report(n.Config.Coordinate, time.Since(start), resolvedEntity, unchanged, mismatches, err)
+	})
+
+	if canceled {
+		log.WithCtxFields(ctx).WithFields(field.StatusDeploymentSkipped()).Warn("Skipping deployment of config, as the deployment was canceled")
+		d.reportNotDeployed(n.Config.Coordinate, "deployment was canceled", report.Canceled)
+		return nil
+	}
 
 	if err != nil {
 		failed := !errors.Is(err, skipError)
 
 		lock.Lock()
-		removeChildren(ctx, n, n, configGraph, failed)
+		removeChildren(ctx, d, n, n, configGraph, failed)
 		lock.Unlock()
 
 		if failed {
@@ -213,7 +373,7 @@ func deployNode(ctx context.Context, n graph.ConfigNode, configGraph graph.Confi
 	return nil
 }
 
-func removeChildren(ctx context.Context, parent, root graph.ConfigNode, configGraph graph.ConfigGraph, failed bool) {
+func removeChildren(ctx context.Context, d environmentDeployment, parent, root graph.ConfigNode, configGraph graph.ConfigGraph, failed bool) {
 
 	children := configGraph.From(parent.ID())
 	for children.Next() {
@@ -238,33 +398,82 @@ func removeChildren(ctx context.Context, parent, root graph.ConfigNode, configGr
 		} else {
 			l.Warn("Skipping deployment of %v, as it depends on %v which %s", childCfg.Coordinate, parent.Config.Coordinate, reason)
 		}
+		status := report.Skipped
+		if failed {
+			status = report.ParentFailed
+		}
+		d.reportNotDeployed(childCfg.Coordinate, fmt.Sprintf("depends on %v which %s", parent.Config.Coordinate, reason), status)
 
-		removeChildren(ctx, child, root, configGraph, failed)
+		removeChildren(ctx, d, child, root, configGraph, failed)
 
 		configGraph.RemoveNode(child.ID())
 	}
 }
 
-func deployConfig(ctx context.Context, c *config.Config, clients ClientSet, resolvedEntities config.EntityLookup) (entities.ResolvedEntity, error) {
+// deployConfig deploys a single config and returns the entity it resolved to. Configs that are unchanged since their
+// last deployment are not deployed again, which is signaled by the returned bool. If the deployment is verified, the
+// fields of the deployed object that differ from the rendered config are returned as well.
+func deployConfig(ctx context.Context, c *config.Config, d environmentDeployment, resolvedEntities *entities.EntityMap) (entities.ResolvedEntity, bool, []report.Mismatch, error) {
+	// pre-deploy hooks are canceled with the deployment, as nothing was changed yet. API calls that were started are
+	// not canceled, so that no config is left partially deployed.
+	hookCtx := ctx
+	ctx = context.WithoutCancel(ctx)
+
 	if c.Skip {
 		log.WithCtxFields(ctx).WithFields(field.StatusDeploymentSkipped()).Info("Skipping deployment of config")
-		return entities.ResolvedEntity{}, skipError //fake resolved entity that "old" deploy creates is never needed, as we don't even try to deploy dependencies of skipped configs (so no reference will ever be attempted to resolve)
+		return entities.ResolvedEntity{}, false, nil, skipError //fake resolved entity that "old" deploy creates is never needed, as we don't even try to deploy dependencies of skipped configs (so no reference will ever be attempted to resolve)
 	}
 
-	properties, errs := c.ResolveParameterValues(resolvedEntities)
+	properties, errs := c.ResolveParameterValues(EntityLookup{EntityMap: resolvedEntities, MonitoredEntityFinder: d.entityFinder, RemoteObjectFinder: d.objectFinder, Ctx: ctx})
 	if len(errs) > 0 {
 		err := mutlierror.New(errs...)
 		log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Invalid configuration - failed to resolve parameter values: %v", err)
-		return entities.ResolvedEntity{}, err
+		return entities.ResolvedEntity{}, false, nil, err
 	}
 
 	renderedConfig, err := c.Render(properties)
 	if err != nil {
 		log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Invalid configuration - failed to render JSON template: %v", err)
-		return entities.ResolvedEntity{}, err
+		return entities.ResolvedEntity{}, false, nil, err
+	}
+
+	hash := d.configHash(renderedConfig, properties)
+	if resolvedEntity, unchanged := d.unchangedEntity(ctx, c, properties, hash); unchanged {
+		log.WithCtxFields(ctx).WithFields(field.StatusDeploymentSkipped()).Info("Skipping deployment of config, as it is unchanged since its last deployment")
+		return resolvedEntity, true, nil, nil
+	}
+
+	c = d.withRecordedObjectID(ctx, c)
+
+	if resolvedEntity, exists, err := d.createOnlyEntity(ctx, c, properties, renderedConfig); err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Failed to look up existing object of create-only config: %v", err)
+		return entities.ResolvedEntity{}, false, nil, err
+	} else if exists {
+		log.WithCtxFields(ctx).WithFields(field.StatusDeploymentSkipped()).Info("Skipping deployment of config, as it is create-only and its object %q already exists", resolvedEntity.ObjectID)
+		d.record(c, hash, resolvedEntity)
+		return resolvedEntity, true, nil, nil
+	}
+
+	if renderedConfig, err = d.withIgnoredChanges(ctx, c, properties, renderedConfig); err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Failed to keep ignored changes of existing object: %v", err)
+		return entities.ResolvedEntity{}, false, nil, err
 	}
 
 	log.WithCtxFields(ctx).WithFields(field.StatusDeploying()).Info("Deploying config")
+
+	if err := d.runConfigHooks(hookCtx, c, hook.PreDeploy, ""); err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Deployment failed - %v", err)
+		return entities.ResolvedEntity{}, false, nil, err
+	}
+
+	existed, err := d.capture(ctx, c, properties, renderedConfig)
+	if err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Failed to capture existing object in snapshot: %v", err)
+		return entities.ResolvedEntity{}, false, nil, err
+	}
+
+	clients := d.clients
+
 	var resolvedEntity entities.ResolvedEntity
 	var deployErr error
 	switch c.Type.(type) {
@@ -272,7 +481,7 @@ func deployConfig(ctx context.Context, c *config.Config, clients ClientSet, reso
 		resolvedEntity, deployErr = setting.Deploy(ctx, clients.Settings, properties, renderedConfig, c)
 
 	case config.ClassicApiType:
-		resolvedEntity, deployErr = classic.Deploy(ctx, clients.Classic, api.NewAPIs(), properties, renderedConfig, c)
+		resolvedEntity, deployErr = classic.Deploy(ctx, clients.Classic, api.NewAPIs(), properties, renderedConfig, c, d.recordedObjectID(c))
 
 	case config.AutomationType:
 		resolvedEntity, deployErr = automation.Deploy(ctx, clients.Automation, properties, renderedConfig, c)
@@ -288,13 +497,190 @@ func deployConfig(ctx context.Context, c *config.Config, clients ClientSet, reso
 		var responseErr clientErrors.RespError
 		if errors.As(deployErr, &responseErr) {
 			logResponseError(ctx, responseErr)
-			return entities.ResolvedEntity{}, responseErr
+			return entities.ResolvedEntity{}, false, nil, responseErr
 		}
 
 		log.WithCtxFields(ctx).WithFields(field.Error(deployErr)).Error("Deployment failed - Monaco Error: %v", deployErr)
-		return entities.ResolvedEntity{}, deployErr
+		return entities.ResolvedEntity{}, false, nil, deployErr
+	}
+
+	if !existed {
+		d.captureCreated(c, resolvedEntity)
+	}
+
+	mismatches, err := d.verify(ctx, c, resolvedEntity, renderedConfig)
+	if err != nil {
+		d.recordFailed(c, resolvedEntity)
+		return entities.ResolvedEntity{}, false, mismatches, err
+	}
+
+	if err := d.runConfigHooks(ctx, c, hook.PostDeploy, resolvedEntity.ObjectID); err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Deployment failed - %v", err)
+		d.recordFailed(c, resolvedEntity)
+		return entities.ResolvedEntity{}, false, mismatches, err
+	}
+
+	d.record(c, hash, resolvedEntity)
+	return resolvedEntity, false, mismatches, nil
+}
+
+// withRecordedObjectID returns a copy of the config that is tied to the object recorded for it in the deployment state.
+// If no object is recorded, or the config is already tied to an object, the config is returned as is. Classic configs
+// are not tied to objects by their OriginObjectId, so the recorded object is passed to them explicitly instead.
+func (d environmentDeployment) withRecordedObjectID(ctx context.Context, c *config.Config) *config.Config {
+	tied := withObjectID(c, d.recordedObjectID(c))
+	if tied != c {
+		log.WithCtxFields(ctx).Debug("Using object %q recorded in deployment state", tied.OriginObjectId)
+	}
+	return tied
+}
+
+// withObjectID returns a copy of the config that is tied to the object with the given ID, see withRecordedObjectID
+func withObjectID(c *config.Config, objectID string) *config.Config {
+	if _, isClassic := c.Type.(config.ClassicApiType); isClassic || c.OriginObjectId != "" || objectID == "" {
+		return c
+	}
+
+	tied := *c
+	tied.OriginObjectId = objectID
+	return &tied
+}
+
+// recordedObjectID returns the ID of the object recorded for the config in the deployment state, or an empty string if
+// no object is recorded
+func (d environmentDeployment) recordedObjectID(c *config.Config) string {
+	if d.opts.State == nil || d.opts.DryRun {
+		return ""
+	}
+
+	entry, _ := d.opts.State.Get(d.env.Name, c.Coordinate)
+	return entry.ObjectID
+}
+
+// configHash returns the hash of the rendered config as recorded in the deployment state, or an empty string if no
+// state is used
+func (d environmentDeployment) configHash(renderedConfig string, properties parameter.Properties) string {
+	if d.opts.State == nil {
+		return ""
+	}
+	return d.opts.State.ConfigHash(renderedConfig, properties)
+}
+
+// unchangedEntity returns the entity of a config whose hash and schema version equal the ones recorded in the
+// deployment state. The entity is built from the recorded object without calling any API, so that configs depending on
+// the unchanged config can still reference it.
+func (d environmentDeployment) unchangedEntity(ctx context.Context, c *config.Config, properties parameter.Properties, hash string) (entities.ResolvedEntity, bool) {
+	if d.opts.State == nil || d.opts.DryRun || d.opts.Force {
+		return entities.ResolvedEntity{}, false
+	}
+
+	entry, found := d.opts.State.Get(d.env.Name, c.Coordinate)
+	if !found || entry.ObjectID == "" || entry.PayloadHash != hash || entry.SchemaVersion != schemaVersion(c) {
+		return entities.ResolvedEntity{}, false
+	}
+
+	resolvedEntity, err := ExistingEntity(c, properties, entry.ObjectID)
+	if err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err)).Debug("Failed to resolve ID of unchanged config - deploying it: %v", err)
+		return entities.ResolvedEntity{}, false
+	}
+	return resolvedEntity, true
+}
+
+// ExistingEntity returns the entity of a config that is not deployed, as its object already exists with the given ID
+func ExistingEntity(c *config.Config, properties parameter.Properties, objectID string) (entities.ResolvedEntity, error) {
+	id := objectID
+	if _, ok := c.Type.(config.SettingsType); ok {
+		var err error
+		if id, err = setting.EntityID(c, objectID); err != nil {
+			return entities.ResolvedEntity{}, err
+		}
+	}
+
+	name := objectID
+	if configName, err := extract.ConfigName(c, properties); err == nil {
+		name = configName
+		properties[config.NameParameter] = name
+	}
+	properties[config.IdParameter] = id
+
+	return entities.ResolvedEntity{
+		EntityName: name,
+		ObjectID:   objectID,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+	}, nil
+}
+
+// record stores the object a config was deployed to in the deployment state
+func (d environmentDeployment) record(c *config.Config, hash string, resolvedEntity entities.ResolvedEntity) {
+	if d.opts.State == nil || d.opts.DryRun || resolvedEntity.ObjectID == "" {
+		return
+	}
+
+	d.opts.State.Put(d.env.Name, c.Coordinate, state.Entry{
+		ObjectID:      resolvedEntity.ObjectID,
+		SchemaVersion: schemaVersion(c),
+		PayloadHash:   hash,
+	})
+}
+
+// recordFailed records the object of a config that was created or updated, but failed after it was deployed. No payload
+// hash is recorded, so that the config is deployed again by the next deployment.
+func (d environmentDeployment) recordFailed(c *config.Config, resolvedEntity entities.ResolvedEntity) {
+	d.record(c, "", resolvedEntity)
+}
+
+// schemaVersion returns the schema version of Settings 2.0 configs, and an empty string for all other configs
+func schemaVersion(c *config.Config) string {
+	if t, ok := c.Type.(config.SettingsType); ok {
+		return t.SchemaVersion
+	}
+	return ""
+}
+
+// report records the result of deploying a config in the deployment report
+func (d environmentDeployment) report(c coordinate.Coordinate, duration time.Duration, resolvedEntity entities.ResolvedEntity, unchanged bool, mismatches []report.Mismatch, err error) {
+	if d.opts.Report == nil {
+		return
+	}
+
+	rec := report.Record{Coordinate: c, Duration: duration, Mismatches: mismatches}
+	switch {
+	case errors.Is(err, skipError):
+		rec.Status, rec.Reason = report.Skipped, "config is skipped"
+	case err != nil:
+		rec.Status, rec.Error = report.Failed, report.NewError(err)
+	case unchanged:
+		rec.Status, rec.ObjectID = report.Unchanged, resolvedEntity.ObjectID
+	default:
+		rec.Status, rec.ObjectID = report.Deployed, resolvedEntity.ObjectID
+	}
+	d.opts.Report.Add(d.env.Name, rec)
+}
+
+// reportNotDeployed records a config that was not deployed with the given status
+func (d environmentDeployment) reportNotDeployed(c coordinate.Coordinate, reason string, status report.Status) {
+	if d.opts.Report == nil {
+		return
+	}
+
+	d.opts.Report.Add(d.env.Name, report.Record{Coordinate: c, Status: status, Reason: reason})
+}
+
+// reportCanceled records all configs of the given components as not deployed, as the deployment was canceled
+func (d environmentDeployment) reportCanceled(components []graph.SortedComponent) {
+	d.reportComponents(components, "deployment was canceled", report.Canceled)
+}
+
+// reportComponents records all configs of the given components as not deployed with the given status
+func (d environmentDeployment) reportComponents(components []graph.SortedComponent, reason string, status report.Status) {
+	for _, component := range components {
+		nodes := component.Graph.Nodes()
+		for nodes.Next() {
+			d.reportNotDeployed(nodes.Node().(graph.ConfigNode).Config.Coordinate, reason, status)
+		}
 	}
-	return resolvedEntity, nil
 }
 
 // logResponseError prints user-friendly messages based on the response errors status
@@ -312,6 +698,6 @@ func logResponseError(ctx context.Context, responseErr clientErrors.RespError) {
 	log.WithCtxFields(ctx).WithFields(field.Error(responseErr), field.StatusDeploymentFailed()).Error("Deployment failed - Dynatrace API call unsuccessful: %v", responseErr)
 }
 
-func createContextWithEnvironment(env EnvironmentInfo) context.Context {
-	return context.WithValue(context.TODO(), log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+func createContextWithEnvironment(ctx context.Context, env EnvironmentInfo) context.Context {
+	return context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
 }
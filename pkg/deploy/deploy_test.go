@@ -17,23 +17,39 @@
 package deploy_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/entity"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/lookup"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/report"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
 	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	clientErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 var dashboardApi = api.API{ID: "dashboard", URLPath: "dashboard", DeprecatedBy: "dashboard-v2"}
@@ -97,7 +113,7 @@ func TestDeployConfigGraph_SingleConfig(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: clientSet,
 	}
 
-	errors := deploy.Deploy(p, c, deploy.DeployConfigsOptions{})
+	errors := deploy.Deploy(context.TODO(), p, c, deploy.DeployConfigsOptions{})
 
 	assert.Emptyf(t, errors, "errors: %v", errors)
 
@@ -160,7 +176,7 @@ func TestDeployConfigGraph_SettingShouldFailUpsert(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c},
 	}
 
-	errors := deploy.Deploy(p, clients, deploy.DeployConfigsOptions{})
+	errors := deploy.Deploy(context.TODO(), p, clients, deploy.DeployConfigsOptions{})
 	assert.NotEmpty(t, errors)
 }
 
@@ -181,7 +197,7 @@ func TestDeployConfigGraph_DoesNotFailOnEmptyConfigs(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.DummyClientSet,
 	}
 
-	errors := deploy.Deploy(p, c, deploy.DeployConfigsOptions{})
+	errors := deploy.Deploy(context.TODO(), p, c, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -193,7 +209,7 @@ func TestDeployConfigGraph_DoesNotFailOnEmptyProject(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.DummyClientSet,
 	}
 
-	errors := deploy.Deploy(p, c, deploy.DeployConfigsOptions{})
+	errors := deploy.Deploy(context.TODO(), p, c, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -203,7 +219,7 @@ func TestDeployConfigGraph_DoesNotFailNilProject(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.DummyClientSet,
 	}
 
-	errors := deploy.Deploy(nil, c, deploy.DeployConfigsOptions{})
+	errors := deploy.Deploy(context.TODO(), nil, c, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -229,7 +245,7 @@ func TestDeployConfigGraph_DoesNotDeploySkippedConfig(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: clientSet,
 	}
 
-	errors := deploy.Deploy(p, c, deploy.DeployConfigsOptions{})
+	errors := deploy.Deploy(context.TODO(), p, c, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 	createdEntities, found := dummyClient.GetEntries(api.NewAPIs()["dashboard"])
 	assert.False(t, found, "expected NO entries for dashboard API to exist")
@@ -276,10 +292,755 @@ func TestDeployConfigGraph_DeploysSetting(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c},
 	}
 
-	errors := deploy.Deploy(p, clients, deploy.DeployConfigsOptions{})
+	errors := deploy.Deploy(context.TODO(), p, clients, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
+func TestDeployConfigGraph_UsesAndRecordsState(t *testing.T) {
+	knownCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "known"}
+	newCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "new"}
+
+	newSetting := func(coord coordinate.Coordinate) config.Config {
+		return config.Config{
+			Template:   testutils.GenerateDummyTemplate(t),
+			Coordinate: coord,
+			Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+			Parameters: config.Parameters{
+				config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+			},
+		}
+	}
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+		if obj.Coordinate == knownCoordinate {
+			assert.Equal(t, "recorded-id", obj.OriginObjectId, "object recorded in state should be used")
+			return dtclient.DynatraceEntity{Id: "recorded-id"}, nil
+		}
+		assert.Empty(t, obj.OriginObjectId)
+		return dtclient.DynatraceEntity{Id: "created-id"}, nil
+	})
+
+	p := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{newSetting(knownCoordinate), newSetting(newCoordinate)},
+				},
+			},
+		},
+	}
+
+	clients := deploy.EnvironmentClients{
+		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c},
+	}
+
+	s := state.New()
+	s.Put("env", knownCoordinate, state.Entry{ObjectID: "recorded-id"})
+
+	errs := deploy.Deploy(context.TODO(), p, clients, deploy.DeployConfigsOptions{State: s})
+	assert.Emptyf(t, errs, "there should be no errors (errors: %v)", errs)
+
+	known, found := s.Get("env", knownCoordinate)
+	assert.True(t, found)
+	assert.Equal(t, state.Entry{ObjectID: "recorded-id", SchemaVersion: "1.2.3", PayloadHash: s.ConfigHash("{}", map[string]interface{}{config.ScopeParameter: "tenant"})}, known)
+
+	created, found := s.Get("env", newCoordinate)
+	assert.True(t, found)
+	assert.Equal(t, "created-id", created.ObjectID)
+}
+
+func TestDeployConfigGraph_SkipsUnchangedConfigs(t *testing.T) {
+	unchangedCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "unchanged"}
+	dependentCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "dependent"}
+
+	configs := []config.Config{
+		{
+			Template:   testutils.GenerateDummyTemplate(t),
+			Coordinate: unchangedCoordinate,
+			Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+			Parameters: config.Parameters{
+				config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+			},
+		},
+		{
+			Template:   testutils.GenerateDummyTemplate(t),
+			Coordinate: dependentCoordinate,
+			Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+			Parameters: config.Parameters{
+				config.ScopeParameter: reference.New("proj", "builtin:test", "unchanged", config.IdParameter),
+			},
+		},
+	}
+	p := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{"builtin:test": configs},
+			},
+		},
+	}
+
+	givenState := func() *state.State {
+		s := state.New()
+		s.Put("env", unchangedCoordinate, state.Entry{
+			ObjectID:      "recorded-id",
+			SchemaVersion: "1.2.3",
+			PayloadHash:   s.ConfigHash("{}", map[string]interface{}{config.ScopeParameter: "tenant"}),
+		})
+		return s
+	}
+
+	t.Run("unchanged configs are not deployed but can be referenced", func(t *testing.T) {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			assert.Equal(t, dependentCoordinate, obj.Coordinate)
+			assert.Equal(t, "recorded-id", obj.Scope)
+			return dtclient.DynatraceEntity{Id: "dependent-id"}, nil
+		})
+
+		r := report.New()
+		errs := deploy.Deploy(context.TODO(), p, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{State: givenState(), Report: r})
+		assert.Emptyf(t, errs, "there should be no errors (errors: %v)", errs)
+
+		records := r.Records("env")
+		for i := range records {
+			records[i].Duration = 0
+		}
+		assert.Equal(t, []report.Record{
+			{Coordinate: dependentCoordinate, Status: report.Deployed, ObjectID: "dependent-id"},
+			{Coordinate: unchangedCoordinate, Status: report.Unchanged, ObjectID: "recorded-id"},
+		}, records)
+	})
+
+	t.Run("changed schema version is deployed", func(t *testing.T) {
+		s := givenState()
+		entry, _ := s.Get("env", unchangedCoordinate)
+		entry.SchemaVersion = "1.0.0"
+		s.Put("env", unchangedCoordinate, entry)
+
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(dtclient.DynatraceEntity{Id: "recorded-id"}, nil)
+
+		errs := deploy.Deploy(context.TODO(), p, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{State: s})
+		assert.Emptyf(t, errs, "there should be no errors (errors: %v)", errs)
+	})
+
+	t.Run("force deploys unchanged configs", func(t *testing.T) {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(dtclient.DynatraceEntity{Id: "recorded-id"}, nil)
+
+		errs := deploy.Deploy(context.TODO(), p, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{State: givenState(), Force: true})
+		assert.Emptyf(t, errs, "there should be no errors (errors: %v)", errs)
+	})
+}
+
+func TestDeployConfigGraph_Lifecycle(t *testing.T) {
+	coord := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "config"}
+	givenProjects := func(lifecycle config.Lifecycle) []project.Project {
+		return []project.Project{
+			{
+				Id: "proj",
+				Configs: project.ConfigsPerTypePerEnvironments{
+					"env": project.ConfigsPerType{
+						"builtin:test": []config.Config{{
+							Template:   template.NewInMemoryTemplate("template", `{"name": "local", "rules": [{"enabled": true, "id": 42}], "other": 2}`),
+							Coordinate: coord,
+							Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+							Parameters: config.Parameters{
+								config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+							},
+							Lifecycle: lifecycle,
+						}},
+					},
+				},
+			},
+		}
+	}
+	existing := dtclient.DownloadSettingsObject{ObjectId: "existing-id", Value: []byte(`{"name": "remote", "rules": [{"enabled": false, "id": 1}], "other": 1}`)}
+
+	t.Run("create-only configs are not updated", func(t *testing.T) {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().FindSettingsObject(gomock.Any(), gomock.Any()).Return(existing, true, nil)
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		r := report.New()
+		errs := deploy.Deploy(context.TODO(), givenProjects(config.Lifecycle{CreateOnly: true}), deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{Report: r})
+		assert.NoError(t, errs)
+		assert.Equal(t, 1, r.Count("env", report.Unchanged))
+	})
+
+	t.Run("create-only configs are created", func(t *testing.T) {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().FindSettingsObject(gomock.Any(), gomock.Any()).Return(dtclient.DownloadSettingsObject{}, false, nil)
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(dtclient.DynatraceEntity{Id: "new-id"}, nil)
+
+		errs := deploy.Deploy(context.TODO(), givenProjects(config.Lifecycle{CreateOnly: true}), deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{})
+		assert.NoError(t, errs)
+	})
+
+	t.Run("values of ignored changes are kept", func(t *testing.T) {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().FindSettingsObject(gomock.Any(), gomock.Any()).Return(existing, true, nil)
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			assert.JSONEq(t, `{"name": "remote", "rules": [{"enabled": false, "id": 42}], "other": 2}`, string(obj.Content))
+			return dtclient.DynatraceEntity{Id: "existing-id"}, nil
+		})
+
+		errs := deploy.Deploy(context.TODO(), givenProjects(config.Lifecycle{IgnoreChanges: []string{"name", "rules.0.enabled", "missing.path"}}), deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{})
+		assert.NoError(t, errs)
+	})
+}
+
+func TestDeployConfigGraph_Verify(t *testing.T) {
+	givenProjects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{{
+						Template:   template.NewInMemoryTemplate("template", `{"name": "test", "enabled": true, "rules": [{"id": 1}]}`),
+						Coordinate: coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "config"},
+						Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+						Parameters: config.Parameters{
+							config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+						},
+					}},
+				},
+			},
+		},
+	}
+	givenClient := func(t *testing.T) *dtclient.MockClient {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{Id: "object-id"}, nil)
+		c.EXPECT().GetSettingById(gomock.Any(), "object-id").Return(&dtclient.DownloadSettingsObject{
+			ObjectId: "object-id",
+			Value:    []byte(`{"name": "test", "rules": [{"id": 2}], "defaulted": 42}`),
+		}, nil)
+		return c
+	}
+	expectedMismatches := []report.Mismatch{
+		{Path: "enabled", Kind: report.Dropped, Expected: true},
+		{Path: "rules[0].id", Kind: report.Changed, Expected: float64(1), Actual: float64(2)},
+	}
+
+	t.Run("mismatches are reported as warnings", func(t *testing.T) {
+		r := report.New()
+		errs := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: givenClient(t)}}, deploy.DeployConfigsOptions{Report: r, Verify: deploy.VerifyWarn})
+		assert.NoError(t, errs)
+
+		records := r.Records("env")
+		assert.Len(t, records, 1)
+		assert.Equal(t, report.Deployed, records[0].Status)
+		assert.Equal(t, expectedMismatches, records[0].Mismatches)
+	})
+
+	t.Run("mismatches fail the config", func(t *testing.T) {
+		r := report.New()
+		errs := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: givenClient(t)}}, deploy.DeployConfigsOptions{Report: r, Verify: deploy.VerifyError})
+		assert.Error(t, errs)
+
+		records := r.Records("env")
+		assert.Len(t, records, 1)
+		assert.Equal(t, report.Failed, records[0].Status)
+		assert.Contains(t, records[0].Error.Message, `deployed object "object-id" differs from the rendered config`)
+		assert.Equal(t, expectedMismatches, records[0].Mismatches)
+	})
+
+	t.Run("objects of failed configs are recorded in the state", func(t *testing.T) {
+		s := state.New()
+		errs := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: givenClient(t)}}, deploy.DeployConfigsOptions{State: s, Verify: deploy.VerifyError})
+		assert.Error(t, errs)
+
+		entry, found := s.Get("env", coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "config"})
+		assert.True(t, found)
+		assert.Equal(t, state.Entry{ObjectID: "object-id", SchemaVersion: "1.2.3"}, entry, "no payload hash must be recorded, so that the config is deployed again")
+	})
+
+	t.Run("nothing is verified in dry-run mode", func(t *testing.T) {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{Id: "object-id"}, nil)
+		c.EXPECT().GetSettingById(gomock.Any(), gomock.Any()).Times(0)
+
+		errs := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{DryRun: true, Verify: deploy.VerifyError})
+		assert.NoError(t, errs)
+	})
+}
+
+func TestDeploy_Hooks(t *testing.T) {
+	var events []string
+	failPhase := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		events = append(events, strings.Join([]string{payload["phase"], payload["configId"], payload["objectId"]}, ":"))
+		if payload["phase"] == failPhase {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	hooks := hook.Hooks{PreDeploy: []hook.Hook{{URL: server.URL}}, PostDeploy: []hook.Hook{{URL: server.URL}}}
+	givenProjects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{{
+						Template:   template.NewInMemoryTemplate("template", `{}`),
+						Coordinate: coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "config"},
+						Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+						Parameters: config.Parameters{
+							config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+						},
+						Hooks: hooks,
+					}},
+				},
+			},
+		},
+	}
+
+	t.Run("hooks run around environments and configs", func(t *testing.T) {
+		events, failPhase = nil, ""
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{Id: "object-id"}, nil)
+
+		err := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{Hooks: map[string]hook.Hooks{"env": hooks}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pre-deploy::", "pre-deploy:config:", "post-deploy:config:object-id", "post-deploy::"}, events)
+	})
+
+	t.Run("failed pre-deploy hooks of environments skip all configs", func(t *testing.T) {
+		events, failPhase = nil, "pre-deploy"
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		r := report.New()
+		err := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{Hooks: map[string]hook.Hooks{"env": hooks}, Report: r})
+		assert.ErrorContains(t, err, "pre-deploy hook")
+		assert.Equal(t, []string{"pre-deploy::"}, events)
+		assert.Equal(t, 1, r.Count("env", report.Skipped))
+	})
+
+	t.Run("failed post-deploy hooks of configs fail the config", func(t *testing.T) {
+		events, failPhase = nil, "post-deploy"
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{Id: "object-id"}, nil)
+
+		r := report.New()
+		err := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{Report: r})
+		assert.Error(t, err)
+		assert.Equal(t, []string{"pre-deploy:config:", "post-deploy:config:object-id"}, events)
+		assert.Equal(t, 1, r.Count("env", report.Failed))
+	})
+
+	t.Run("pre-deploy hooks of configs are canceled with the deployment", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		release := make(chan struct{})
+		hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cancel()
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		}))
+		defer hanging.Close()
+		defer close(release)
+
+		projects := []project.Project{{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{"env": project.ConfigsPerType{
+			"builtin:test": []config.Config{func() config.Config {
+				c := givenProjects[0].Configs["env"]["builtin:test"][0]
+				c.Hooks = hook.Hooks{PreDeploy: []hook.Hook{{URL: hanging.URL}}}
+				return c
+			}()},
+		}}}}
+
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		start := time.Now()
+		err := deploy.Deploy(ctx, projects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{})
+		assert.ErrorContains(t, err, "deployment was canceled")
+		assert.Less(t, time.Since(start), hook.DefaultTimeout)
+	})
+
+	t.Run("hooks are not run in dry-run mode", func(t *testing.T) {
+		events, failPhase = nil, ""
+
+		err := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.DummyClientSet}, deploy.DeployConfigsOptions{Hooks: map[string]hook.Hooks{"env": hooks}, DryRun: true})
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
+
+func TestDeploy_SendsDeploymentEvent(t *testing.T) {
+	givenProjects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{{
+						Template:   template.NewInMemoryTemplate("template", `{}`),
+						Coordinate: coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "config"},
+						Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+						Parameters: config.Parameters{
+							config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+						},
+					}},
+				},
+			},
+		},
+	}
+	eventOptions := &deploy.EventOptions{EntitySelector: "type(HOST)", Revision: "abc", Operator: "jane"}
+
+	t.Run("event lists changed configs", func(t *testing.T) {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{Id: "object-id"}, nil)
+		events := dtclient.NewMockEventsClient(gomock.NewController(t))
+		events.EXPECT().SendEvent(gomock.Any(), dtclient.Event{
+			EventType:      dtclient.EventTypeCustomConfiguration,
+			Title:          "Monaco deployment",
+			EntitySelector: "type(HOST)",
+			Properties: map[string]string{
+				"environment":    "env",
+				"status":         "succeeded",
+				"changedConfigs": "proj:builtin:test:config",
+				"changedCount":   "1",
+				"revision":       "abc",
+				"operator":       "jane",
+			},
+		}).Return(nil)
+
+		err := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c, Events: events}}, deploy.DeployConfigsOptions{DeploymentEvent: eventOptions})
+		assert.NoError(t, err)
+	})
+
+	t.Run("failing to send the event does not fail the deployment", func(t *testing.T) {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{Id: "object-id"}, nil)
+		events := dtclient.NewMockEventsClient(gomock.NewController(t))
+		events.EXPECT().SendEvent(gomock.Any(), gomock.Any()).Return(fmt.Errorf("failed"))
+
+		err := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c, Events: events}}, deploy.DeployConfigsOptions{DeploymentEvent: eventOptions})
+		assert.NoError(t, err)
+	})
+
+	t.Run("no event is sent if no config was changed", func(t *testing.T) {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{}, fmt.Errorf("failed"))
+		events := dtclient.NewMockEventsClient(gomock.NewController(t))
+		events.EXPECT().SendEvent(gomock.Any(), gomock.Any()).Times(0)
+
+		err := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c, Events: events}}, deploy.DeployConfigsOptions{DeploymentEvent: eventOptions})
+		assert.Error(t, err)
+	})
+
+	t.Run("no event is sent in dry-run mode", func(t *testing.T) {
+		events := dtclient.NewMockEventsClient(gomock.NewController(t))
+		events.EXPECT().SendEvent(gomock.Any(), gomock.Any()).Times(0)
+
+		clients := deploy.DummyClientSet
+		clients.Events = events
+		err := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: clients}, deploy.DeployConfigsOptions{DeploymentEvent: eventOptions, DryRun: true})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDeployConfigGraph_CapturesSnapshot(t *testing.T) {
+	existingCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "existing"}
+	newCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "new"}
+
+	newSetting := func(coord coordinate.Coordinate) config.Config {
+		return config.Config{
+			Template:   testutils.GenerateDummyTemplate(t),
+			Coordinate: coord,
+			Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+			Parameters: config.Parameters{
+				config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+			},
+		}
+	}
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().FindSettingsObject(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ any, obj dtclient.SettingsObject) (dtclient.DownloadSettingsObject, bool, error) {
+		if obj.Coordinate == existingCoordinate {
+			return dtclient.DownloadSettingsObject{ObjectId: "existing-id", Value: []byte(`{"old":true}`)}, true, nil
+		}
+		return dtclient.DownloadSettingsObject{}, false, nil
+	})
+	c.EXPECT().GetSettingById(gomock.Any(), "existing-id").Return(&dtclient.DownloadSettingsObject{ObjectId: "existing-id", ExternalId: "external-id", Scope: "tenant", SchemaVersion: "1.0.0"}, nil)
+	c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+		if obj.Coordinate == existingCoordinate {
+			return dtclient.DynatraceEntity{Id: "existing-id"}, nil
+		}
+		return dtclient.DynatraceEntity{Id: "created-id"}, nil
+	})
+
+	p := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{newSetting(existingCoordinate), newSetting(newCoordinate)},
+				},
+			},
+		},
+	}
+
+	clients := deploy.EnvironmentClients{
+		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c},
+	}
+
+	snap := snapshot.New()
+	errs := deploy.Deploy(context.TODO(), p, clients, deploy.DeployConfigsOptions{Snapshot: snap})
+	assert.Emptyf(t, errs, "there should be no errors (errors: %v)", errs)
+
+	assert.ElementsMatch(t, []snapshot.Object{
+		{
+			Coordinate:    existingCoordinate,
+			Kind:          config.SettingsTypeId,
+			API:           "builtin:test",
+			ObjectID:      "existing-id",
+			Scope:         "tenant",
+			SchemaVersion: "1.0.0",
+			ExternalID:    "external-id",
+			Payload:       []byte(`{"old":true}`),
+		},
+		{
+			Coordinate: newCoordinate,
+			Kind:       config.SettingsTypeId,
+			API:        "builtin:test",
+			ObjectID:   "created-id",
+			Created:    true,
+		},
+	}, snap.Objects("env"))
+}
+
+func TestDeployConfigGraph_ReportsResults(t *testing.T) {
+	parentCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "parent"}
+	childCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "child"}
+	skippedCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "skipped"}
+	deployedCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "deployed"}
+
+	newSetting := func(coord coordinate.Coordinate) config.Config {
+		return config.Config{
+			Template:   testutils.GenerateDummyTemplate(t),
+			Coordinate: coord,
+			Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+			Parameters: config.Parameters{
+				config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+			},
+		}
+	}
+	child := newSetting(childCoordinate)
+	child.Parameters["parent"] = &parameter.DummyParameter{
+		References: []parameter.ParameterReference{{Config: parentCoordinate, Property: "id"}},
+	}
+	skipped := newSetting(skippedCoordinate)
+	skipped.Skip = true
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ any, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+		if obj.Coordinate == parentCoordinate {
+			return dtclient.DynatraceEntity{}, clientErrors.RespError{Reason: "rejected", StatusCode: 400, Body: "invalid"}
+		}
+		return dtclient.DynatraceEntity{Id: "deployed-id"}, nil
+	})
+
+	p := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{newSetting(parentCoordinate), child, skipped, newSetting(deployedCoordinate)},
+				},
+			},
+		},
+	}
+
+	clients := deploy.EnvironmentClients{
+		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c},
+	}
+
+	r := report.New()
+	errs := deploy.Deploy(context.TODO(), p, clients, deploy.DeployConfigsOptions{Report: r, ContinueOnErr: true})
+	assert.Error(t, errs)
+
+	records := r.Records("env")
+	assert.Len(t, records, 4)
+	for i := range records {
+		records[i].Duration = 0
+	}
+	assert.Equal(t, []report.Record{
+		{Coordinate: childCoordinate, Status: report.ParentFailed, Reason: "depends on proj:builtin:test:parent which failed to deploy"},
+		{Coordinate: deployedCoordinate, Status: report.Deployed, ObjectID: "deployed-id"},
+		{Coordinate: parentCoordinate, Status: report.Failed, Error: &report.Error{Message: "rejected (HTTP 400): invalid", StatusCode: 400, Body: "invalid"}},
+		{Coordinate: skippedCoordinate, Status: report.Skipped, Reason: "config is skipped"},
+	}, records)
+}
+
+func TestDeploy_Canceled(t *testing.T) {
+	parentCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "parent"}
+	childCoordinate := coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "child"}
+
+	newSetting := func(coord coordinate.Coordinate) config.Config {
+		return config.Config{
+			Template:   testutils.GenerateDummyTemplate(t),
+			Coordinate: coord,
+			Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+			Parameters: config.Parameters{
+				config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+			},
+		}
+	}
+	child := newSetting(childCoordinate)
+	child.Parameters["parent"] = &parameter.DummyParameter{
+		References: []parameter.ParameterReference{{Config: parentCoordinate, Property: "id"}},
+	}
+
+	p := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{newSetting(parentCoordinate), child},
+				},
+			},
+		},
+	}
+
+	t.Run("no configs are deployed if canceled before the deployment", func(t *testing.T) {
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		ctx, cancel := context.WithCancelCause(context.TODO())
+		cancel(fmt.Errorf("interrupted"))
+
+		r := report.New()
+		err := deploy.Deploy(ctx, p, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{Report: r})
+		assert.ErrorContains(t, err, "deployment was canceled: interrupted")
+		assert.Equal(t, 2, r.Count("env", report.Canceled))
+		assert.False(t, r.Started("env"))
+	})
+
+	t.Run("configs being deployed finish, remaining configs are not deployed", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(context.TODO())
+
+		c := dtclient.NewMockClient(gomock.NewController(t))
+		c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, _ dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+			cancel(fmt.Errorf("interrupted"))
+			assert.NoError(t, ctx.Err(), "API calls in progress must not be canceled")
+			return dtclient.DynatraceEntity{Id: "parent-id"}, nil
+		})
+
+		r := report.New()
+		err := deploy.Deploy(ctx, p, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c}}, deploy.DeployConfigsOptions{Report: r})
+		assert.ErrorContains(t, err, "deployment was canceled: interrupted")
+		assert.True(t, r.Started("env"))
+
+		records := r.Records("env")
+		for i := range records {
+			records[i].Duration = 0
+		}
+		assert.Equal(t, []report.Record{
+			{Coordinate: childCoordinate, Status: report.Canceled, Reason: "deployment was canceled"},
+			{Coordinate: parentCoordinate, Status: report.Deployed, ObjectID: "parent-id"},
+		}, records)
+	})
+}
+
+func TestDeployConfigGraph_LimitsConcurrentDeployments(t *testing.T) {
+	configs := make([]config.Config, 5)
+	for i := range configs {
+		configs[i] = config.Config{
+			Template:   testutils.GenerateDummyTemplate(t),
+			Coordinate: coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: fmt.Sprintf("config-%d", i)},
+			Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+			Parameters: config.Parameters{
+				config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+			},
+		}
+	}
+
+	var running, maxRunning atomic.Int32
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(len(configs)).DoAndReturn(func(_ any, _ dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return dtclient.DynatraceEntity{Id: "id"}, nil
+	})
+
+	p := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{"builtin:test": configs},
+			},
+		},
+	}
+	clients := deploy.EnvironmentClients{
+		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c},
+	}
+
+	errs := deploy.Deploy(context.TODO(), p, clients, deploy.DeployConfigsOptions{MaxConcurrentDeployments: 2})
+	assert.NoError(t, errs)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
+
+func TestDeployConfigGraph_DeploysEnvironmentsInParallel(t *testing.T) {
+	newConfig := func() config.Config {
+		return config.Config{
+			Template:   testutils.GenerateDummyTemplate(t),
+			Coordinate: coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "config"},
+			Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+			Parameters: config.Parameters{
+				config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+			},
+		}
+	}
+
+	// both environments must be deployed at the same time for either deployment to finish
+	var started sync.WaitGroup
+	started.Add(2)
+	upsert := func(_ any, _ dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+		started.Done()
+		started.Wait()
+		return dtclient.DynatraceEntity{Id: "id"}, nil
+	}
+
+	c1 := dtclient.NewMockClient(gomock.NewController(t))
+	c1.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(upsert)
+	c2 := dtclient.NewMockClient(gomock.NewController(t))
+	c2.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(upsert)
+
+	p := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env1": project.ConfigsPerType{"builtin:test": []config.Config{newConfig()}},
+				"env2": project.ConfigsPerType{"builtin:test": []config.Config{newConfig()}},
+			},
+		},
+	}
+	clients := deploy.EnvironmentClients{
+		deploy.EnvironmentInfo{Name: "env1"}: deploy.ClientSet{Settings: c1},
+		deploy.EnvironmentInfo{Name: "env2"}: deploy.ClientSet{Settings: c2},
+	}
+
+	errs := deploy.Deploy(context.TODO(), p, clients, deploy.DeployConfigsOptions{MaxParallelEnvironments: 2})
+	assert.NoError(t, errs)
+}
+
 func TestDeployConfigsTargetingClassicConfigUnique(t *testing.T) {
 	theConfigName := "theConfigName"
 	theApiName := "management-zone"
@@ -321,7 +1082,7 @@ func TestDeployConfigsTargetingClassicConfigUnique(t *testing.T) {
 		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Classic: client},
 	}
 
-	errors := deploy.Deploy(p, clients, deploy.DeployConfigsOptions{})
+	errors := deploy.Deploy(context.TODO(), p, clients, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -366,7 +1127,7 @@ func TestDeployConfigsTargetingClassicConfigNonUniqueWithExistingCfgsOfSameName(
 		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Classic: client},
 	}
 
-	errors := deploy.Deploy(p, clients, deploy.DeployConfigsOptions{})
+	errors := deploy.Deploy(context.TODO(), p, clients, deploy.DeployConfigsOptions{})
 	assert.Emptyf(t, errors, "there should be no errors (errors: %v)", errors)
 }
 
@@ -418,7 +1179,7 @@ func TestDeployConfigsWithDeploymentErrors(t *testing.T) {
 
 	t.Run("deployment error - always continues on error", func(t *testing.T) {
 
-		err := deploy.Deploy(p, c, deploy.DeployConfigsOptions{}) // continues even without option set
+		err := deploy.Deploy(context.TODO(), p, c, deploy.DeployConfigsOptions{}) // continues even without option set
 		assert.Error(t, err)
 
 		envErrs := make(errors.EnvironmentDeploymentErrors)
@@ -545,7 +1306,7 @@ func TestDeployConfigGraph_DoesNotDeployConfigsDependingOnSkippedConfigs(t *test
 		deploy.EnvironmentInfo{Name: environmentName}: clientSet,
 	}
 
-	errs := deploy.Deploy(projects, clients, deploy.DeployConfigsOptions{})
+	errs := deploy.Deploy(context.TODO(), projects, clients, deploy.DeployConfigsOptions{})
 	assert.NoError(t, errs)
 	assert.Zero(t, dummyClient.CreatedObjects())
 }
@@ -663,7 +1424,7 @@ func TestDeployConfigGraph_DeploysIndependentConfigurations(t *testing.T) {
 		deploy.EnvironmentInfo{Name: environmentName}: clientSet,
 	}
 
-	errs := deploy.Deploy(projects, clients, deploy.DeployConfigsOptions{})
+	errs := deploy.Deploy(context.TODO(), projects, clients, deploy.DeployConfigsOptions{})
 	assert.NoError(t, errs)
 
 	dashboards, found := dummyClient.GetEntries(api.NewAPIs()["dashboard"])
@@ -785,7 +1546,7 @@ func TestDeployConfigGraph_DeploysIndependentConfigurations_IfContinuingAfterFai
 		deploy.EnvironmentInfo{Name: environmentName}: clientSet,
 	}
 
-	errs := deploy.Deploy(projects, clients, deploy.DeployConfigsOptions{ContinueOnErr: true})
+	errs := deploy.Deploy(context.TODO(), projects, clients, deploy.DeployConfigsOptions{ContinueOnErr: true})
 	assert.Len(t, errs, 1)
 
 	dashboards, found := dummyClient.GetEntries(api.NewAPIs()["dashboard"])
@@ -1167,7 +1928,7 @@ func TestDeployConfigsValidatesClassicAPINames(t *testing.T) {
 				deploy.EnvironmentInfo{Name: "env2"}: deploy.DummyClientSet,
 			}
 
-			err := deploy.Deploy(tc.given, c, deploy.DeployConfigsOptions{})
+			err := deploy.Deploy(context.TODO(), tc.given, c, deploy.DeployConfigsOptions{})
 			if len(tc.wantErrsContain) == 0 {
 				assert.NoError(t, err)
 			} else {
@@ -1258,7 +2019,7 @@ func TestDeployConfigGraph_CollectsAllErrors(t *testing.T) {
 	}
 
 	t.Run("stop on error - returns validation errors", func(t *testing.T) {
-		errs := deploy.Deploy(p, c, deploy.DeployConfigsOptions{})
+		errs := deploy.Deploy(context.TODO(), p, c, deploy.DeployConfigsOptions{})
 		assert.Error(t, errs)
 
 		var envErrs errors.EnvironmentDeploymentErrors
@@ -1269,7 +2030,7 @@ func TestDeployConfigGraph_CollectsAllErrors(t *testing.T) {
 	})
 
 	t.Run("continue on error - returns validation and deployment", func(t *testing.T) {
-		errs := deploy.Deploy(p, c, deploy.DeployConfigsOptions{ContinueOnErr: true})
+		errs := deploy.Deploy(context.TODO(), p, c, deploy.DeployConfigsOptions{ContinueOnErr: true})
 		assert.Error(t, errs)
 
 		var envErrs errors.EnvironmentDeploymentErrors
@@ -1282,3 +2043,83 @@ func TestDeployConfigGraph_CollectsAllErrors(t *testing.T) {
 	})
 
 }
+
+func TestDeploy_ResolvesEntityParameters(t *testing.T) {
+	givenConfig := func(id string) config.Config {
+		return config.Config{
+			Template:   template.NewInMemoryTemplate("template", `{"host": "{{ .host }}"}`),
+			Coordinate: coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: id},
+			Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+			Parameters: config.Parameters{
+				config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+				"host":                entity.New(`type(HOST),entityName.equals("my-host")`, false),
+			},
+		}
+	}
+	givenProjects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{givenConfig("a"), givenConfig("b")},
+				},
+			},
+		},
+	}
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+		assert.JSONEq(t, `{"host": "HOST-1"}`, string(obj.Content))
+		return dtclient.DynatraceEntity{Id: obj.Coordinate.ConfigId}, nil
+	}).Times(2)
+	entities := dtclient.NewMockEntitiesClient(gomock.NewController(t))
+	entities.EXPECT().ListMonitoredEntities(gomock.Any(), `type(HOST),entityName.equals("my-host")`).Return([]dtclient.MonitoredEntity{{EntityId: "HOST-1"}}, nil).Times(1)
+
+	err := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Settings: c, Entities: entities}}, deploy.DeployConfigsOptions{})
+	assert.NoError(t, err, "the entity selector must only be looked up once")
+}
+
+func TestDeploy_ResolvesLookupParameters(t *testing.T) {
+	givenProjects := []project.Project{
+		{
+			Id: "proj",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:test": []config.Config{{
+						Template:   template.NewInMemoryTemplate("template", `{"profile": "{{ .profile.id }}", "zone": "{{ .zone.id }}", "zoneName": "{{ .zone.name }}"}`),
+						Coordinate: coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "config"},
+						Type:       config.SettingsType{SchemaId: "builtin:test", SchemaVersion: "1.2.3"},
+						Parameters: config.Parameters{
+							config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+							"profile":             lookup.NewClassic("alerting-profile", "Default"),
+							"zone":                lookup.NewSettings("builtin:management-zones", "environment", map[string]any{"rules": 1}),
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ConfigExistsByName(gomock.Any(), gomock.Any(), "Default").Return(true, "profile-id", nil)
+	c.EXPECT().ListSettings(gomock.Any(), "builtin:management-zones", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+		var found []dtclient.DownloadSettingsObject
+		for _, o := range []dtclient.DownloadSettingsObject{
+			{ObjectId: "other-scope", Scope: "HOST-1", Value: []byte(`{"name": "zone", "rules": 1}`)},
+			{ObjectId: "other-value", Scope: "environment", Value: []byte(`{"name": "other", "rules": 2}`)},
+			{ObjectId: "zone-id", Scope: "environment", Value: []byte(`{"name": "zone", "rules": 1}`)},
+		} {
+			if opts.Filter(o) {
+				found = append(found, o)
+			}
+		}
+		return found, nil
+	})
+	c.EXPECT().UpsertSettings(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+		assert.JSONEq(t, `{"profile": "profile-id", "zone": "zone-id", "zoneName": "zone"}`, string(obj.Content))
+		return dtclient.DynatraceEntity{Id: "object-id"}, nil
+	})
+
+	err := deploy.Deploy(context.TODO(), givenProjects, deploy.EnvironmentClients{deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Classic: c, Settings: c}}, deploy.DeployConfigsOptions{})
+	assert.NoError(t, err)
+}
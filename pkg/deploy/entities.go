@@ -0,0 +1,216 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"reflect"
+	"sync"
+)
+
+// EntityLookup combines the entities resolved while deploying configs with a MonitoredEntityFinder and a
+// RemoteObjectFinder, so that reference, entity and lookup parameters can be resolved. Parameters making requests, like
+// vault parameters, are resolved within Ctx.
+type EntityLookup struct {
+	*entities.EntityMap
+	*MonitoredEntityFinder
+	*RemoteObjectFinder
+	Ctx context.Context
+}
+
+// this forces the compiler to check if EntityLookup is of type parameter.ContextProvider
+var _ parameter.ContextProvider = EntityLookup{}
+
+// Context returns the context parameters are resolved within. If no context is set, context.Background is returned.
+func (l EntityLookup) Context() context.Context {
+	if l.Ctx == nil {
+		return context.Background()
+	}
+	return l.Ctx
+}
+
+// MonitoredEntityFinder finds the monitored entities matching entity selectors on a single environment.
+// The IDs found for each selector are cached, so that each selector is only looked up once per environment.
+// It is safe for concurrent use.
+type MonitoredEntityFinder struct {
+	ctx    context.Context
+	client dtclient.EntitiesClient
+
+	lock  sync.Mutex
+	cache map[string][]string
+}
+
+// this forces the compiler to check if MonitoredEntityFinder is of type parameter.MonitoredEntityFinder
+var _ parameter.MonitoredEntityFinder = (*MonitoredEntityFinder)(nil)
+
+// NewMonitoredEntityFinder returns a MonitoredEntityFinder looking up entities using the given client. If client is
+// nil, no entities can be found.
+func NewMonitoredEntityFinder(ctx context.Context, client dtclient.EntitiesClient) *MonitoredEntityFinder {
+	return &MonitoredEntityFinder{
+		ctx:    ctx,
+		client: client,
+		cache:  make(map[string][]string),
+	}
+}
+
+func (f *MonitoredEntityFinder) FindMonitoredEntities(entitySelector string) ([]string, error) {
+	if f.client == nil {
+		return nil, errors.New("no client for the entities API is available")
+	}
+
+	// the lock is held during the lookup, so that concurrent lookups of the same selector only call the API once
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if ids, cached := f.cache[entitySelector]; cached {
+		return ids, nil
+	}
+
+	log.WithCtxFields(f.ctx).Debug("Looking up monitored entities matching %q", entitySelector)
+	found, err := f.client.ListMonitoredEntities(f.ctx, entitySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(found))
+	for i, e := range found {
+		ids[i] = e.EntityId
+	}
+	f.cache[entitySelector] = ids
+	return ids, nil
+}
+
+// dummyObjectID is the ID of all objects found by a RemoteObjectFinder in dry-run mode
+const dummyObjectID = "DUMMY-OBJECT-ID"
+
+// RemoteObjectFinder finds existing objects on a single environment, which do not need to be managed by monaco.
+// In dry-run mode, a single object with a fake ID is found for each lookup.
+type RemoteObjectFinder struct {
+	ctx     context.Context
+	clients ClientSet
+	apis    api.APIs
+	dryRun  bool
+}
+
+// this forces the compiler to check if RemoteObjectFinder is of type parameter.RemoteObjectFinder
+var _ parameter.RemoteObjectFinder = (*RemoteObjectFinder)(nil)
+
+// NewRemoteObjectFinder returns a RemoteObjectFinder looking up objects using the given clients
+func NewRemoteObjectFinder(ctx context.Context, clients ClientSet, dryRun bool) *RemoteObjectFinder {
+	return &RemoteObjectFinder{
+		ctx:     ctx,
+		clients: clients,
+		apis:    api.NewAPIs(),
+		dryRun:  dryRun,
+	}
+}
+
+func (f *RemoteObjectFinder) FindClassicObject(apiID string, name string) (parameter.RemoteObject, bool, error) {
+	if f.dryRun {
+		return parameter.RemoteObject{ID: dummyObjectID, Name: name}, true, nil
+	}
+
+	a, found := f.apis[apiID]
+	if !found {
+		return parameter.RemoteObject{}, false, fmt.Errorf("unknown API %q", apiID)
+	}
+	if f.clients.Classic == nil {
+		return parameter.RemoteObject{}, false, errors.New("no client for classic APIs is available")
+	}
+
+	exists, id, err := f.clients.Classic.ConfigExistsByName(f.ctx, a, name)
+	if err != nil || !exists {
+		return parameter.RemoteObject{}, false, err
+	}
+	return parameter.RemoteObject{ID: id, Name: name}, true, nil
+}
+
+func (f *RemoteObjectFinder) FindSettingsObjects(schemaId string, scope string, fields map[string]any) ([]parameter.RemoteObject, error) {
+	if f.dryRun {
+		return []parameter.RemoteObject{{ID: dummyObjectID}}, nil
+	}
+	if f.clients.Settings == nil {
+		return nil, errors.New("no client for settings is available")
+	}
+
+	expected, err := normalize(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := f.clients.Settings.ListSettings(f.ctx, schemaId, dtclient.ListSettingsOptions{
+		Filter: func(o dtclient.DownloadSettingsObject) bool {
+			return o.Scope == scope && hasFields(o.Value, expected)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]parameter.RemoteObject, len(found))
+	for i, o := range found {
+		objects[i] = parameter.RemoteObject{ID: o.ObjectId, Name: settingsName(o.Value)}
+	}
+	return objects, nil
+}
+
+// normalize returns the given fields as they would be unmarshalled from JSON, so that they can be compared to the
+// fields of settings values
+func normalize(fields map[string]any) (map[string]any, error) {
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fields to match: %w", err)
+	}
+
+	var normalized map[string]any
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fields to match: %w", err)
+	}
+	return normalized, nil
+}
+
+// hasFields returns whether the given settings value has all the expected top-level fields
+func hasFields(value json.RawMessage, expected map[string]any) bool {
+	var actual map[string]any
+	if err := json.Unmarshal(value, &actual); err != nil {
+		return false
+	}
+
+	for k, v := range expected {
+		if a, found := actual[k]; !found || !reflect.DeepEqual(a, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// settingsName returns the `name` field of the given settings value, or an empty string if it has none
+func settingsName(value json.RawMessage) string {
+	var v struct {
+		Name string `json:"name"`
+	}
+	_ = json.Unmarshal(value, &v)
+	return v.Name
+}
@@ -0,0 +1,96 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/report"
+	"strconv"
+	"strings"
+)
+
+// EventOptions configure the deployment event sent to each environment after configs were deployed to it
+type EventOptions struct {
+	// EntitySelector selects the entities the event is attached to. If it is empty, the event is not attached to any
+	// entity.
+	EntitySelector string
+	// Revision of the deployed configs, e.g. a git commit hash. It is optional.
+	Revision string
+	// Operator is the user running the deployment. It is optional.
+	Operator string
+}
+
+// deploymentEventTitle is the title of deployment events
+const deploymentEventTitle = "Monaco deployment"
+
+// sendDeploymentEvent sends a deployment event listing all configs that were deployed to the environment. No event is
+// sent if no config was deployed, or if the deployment is a dry-run. Failing to send the event does not fail the
+// deployment, but is logged as a warning.
+func (d environmentDeployment) sendDeploymentEvent(ctx context.Context, deploymentErr error) {
+	if d.opts.DeploymentEvent == nil || d.opts.DryRun || d.clients.Events == nil {
+		return
+	}
+
+	var changed []string
+	for _, rec := range d.opts.Report.Records(d.env.Name) {
+		if rec.Status == report.Deployed {
+			changed = append(changed, rec.Coordinate.String())
+		}
+	}
+	if len(changed) == 0 {
+		log.WithCtxFields(ctx).Debug("Not sending a deployment event to environment %q, as no configuration was changed", d.env.Name)
+		return
+	}
+
+	// the event is sent even if the deployment was canceled, to record the configs that were changed nonetheless
+	ctx = context.WithoutCancel(ctx)
+	if err := d.clients.Events.SendEvent(ctx, newDeploymentEvent(d.env, *d.opts.DeploymentEvent, changed, deploymentErr)); err != nil {
+		log.WithFields(field.Environment(d.env.Name, d.env.Group), field.Error(err)).Warn("Failed to send deployment event to environment %q: %v", d.env.Name, err)
+		return
+	}
+	log.WithCtxFields(ctx).Info("Sent deployment event for %d changed configuration(s) to environment %q", len(changed), d.env.Name)
+}
+
+func newDeploymentEvent(env EnvironmentInfo, opts EventOptions, changed []string, deploymentErr error) dtclient.Event {
+	status := "succeeded"
+	if deploymentErr != nil {
+		status = "failed"
+	}
+
+	properties := map[string]string{
+		"environment":    env.Name,
+		"status":         status,
+		"changedConfigs": strings.Join(changed, "\n"),
+		"changedCount":   strconv.Itoa(len(changed)),
+	}
+	if opts.Revision != "" {
+		properties["revision"] = opts.Revision
+	}
+	if opts.Operator != "" {
+		properties["operator"] = opts.Operator
+	}
+
+	return dtclient.Event{
+		EventType:      dtclient.EventTypeCustomConfiguration,
+		Title:          deploymentEventTitle,
+		EntitySelector: opts.EntitySelector,
+		Properties:     properties,
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package hook runs the hooks defined in package github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook
+// before and after deployments to environments and of single configs.
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/webhook"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	configHook "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Event describes the deployment a hook is run for
+type Event struct {
+	// Phase the hook is run in
+	Phase configHook.Phase
+	// Environment that is deployed to
+	Environment string
+	// Group of the Environment
+	Group string
+	// Coordinate of the deployed config. It is nil for hooks of environments.
+	Coordinate *coordinate.Coordinate
+	// ObjectID is the ID of the deployed object. It is only set for PostDeploy hooks of configs.
+	ObjectID string
+}
+
+// Run runs the hooks one after another. It stops at the first hook that fails, and returns its error.
+func Run(ctx context.Context, hooks []configHook.Hook, e Event) error {
+	for _, h := range hooks {
+		log.WithCtxFields(ctx).Info("Running %s hook %s", e.Phase, h)
+		if err := run(ctx, h, e); err != nil {
+			return fmt.Errorf("%s hook %s failed: %w", e.Phase, h, err)
+		}
+	}
+	return nil
+}
+
+// run runs the hook for the given Event
+func run(ctx context.Context, h configHook.Hook, e Event) error {
+	timeout := h.Timeout
+	if timeout == 0 {
+		timeout = configHook.DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if len(h.Command) > 0 {
+		return runCommand(ctx, h, e)
+	}
+	return callWebhook(ctx, h, e)
+}
+
+// runCommand runs the command of the hook. The Event is passed to the command as environment variables.
+func runCommand(ctx context.Context, h configHook.Hook, e Event) error {
+	cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
+	cmd.Env = append(os.Environ(), e.environ()...)
+
+	out, err := cmd.CombinedOutput()
+	log.WithCtxFields(ctx).Debug("Output of hook %s:\n%s", h, out)
+	if err != nil {
+		if output := strings.TrimSpace(string(out)); output != "" {
+			return fmt.Errorf("%w: %s", err, output)
+		}
+		return err
+	}
+	return nil
+}
+
+// environ returns the environment variables describing the Event
+func (e Event) environ() []string {
+	env := []string{
+		"MONACO_HOOK_PHASE=" + string(e.Phase),
+		"MONACO_ENVIRONMENT=" + e.Environment,
+		"MONACO_ENVIRONMENT_GROUP=" + e.Group,
+	}
+	if e.Coordinate != nil {
+		env = append(env,
+			"MONACO_PROJECT="+e.Coordinate.Project,
+			"MONACO_CONFIG_TYPE="+e.Coordinate.Type,
+			"MONACO_CONFIG_ID="+e.Coordinate.ConfigId,
+		)
+	}
+	if e.ObjectID != "" {
+		env = append(env, "MONACO_OBJECT_ID="+e.ObjectID)
+	}
+	return env
+}
+
+// webhookPayload is the JSON body sent to webhooks
+type webhookPayload struct {
+	Phase       configHook.Phase `json:"phase"`
+	Environment string           `json:"environment"`
+	Group       string           `json:"group"`
+	Project     string           `json:"project,omitempty"`
+	Type        string           `json:"type,omitempty"`
+	ConfigId    string           `json:"configId,omitempty"`
+	ObjectID    string           `json:"objectId,omitempty"`
+}
+
+// callWebhook sends the Event as JSON to the webhook of the hook. The hook fails unless the webhook responds with a
+// 2xx status code.
+func callWebhook(ctx context.Context, h configHook.Hook, e Event) error {
+	p := webhookPayload{Phase: e.Phase, Environment: e.Environment, Group: e.Group, ObjectID: e.ObjectID}
+	if e.Coordinate != nil {
+		p.Project, p.Type, p.ConfigId = e.Coordinate.Project, e.Coordinate.Type, e.Coordinate.ConfigId
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	return webhook.Post(ctx, h.URL, h.Headers, body)
+}
@@ -0,0 +1,97 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hook_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	configHook "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/hook"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestRun_Webhook(t *testing.T) {
+	var received []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		received = append(received, payload)
+
+		if payload["phase"] == string(configHook.PostDeploy) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("smoke test failed"))
+		}
+	}))
+	defer server.Close()
+
+	hooks := []configHook.Hook{{URL: server.URL, Headers: map[string]configHook.HeaderValue{"Authorization": {Value: "Bearer token"}}}}
+	coord := coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "c"}
+
+	err := hook.Run(context.TODO(), hooks, hook.Event{Phase: configHook.PreDeploy, Environment: "env", Group: "group"})
+	assert.NoError(t, err)
+
+	err = hook.Run(context.TODO(), hooks, hook.Event{Phase: configHook.PostDeploy, Environment: "env", Group: "group", Coordinate: &coord, ObjectID: "object-id"})
+	assert.ErrorContains(t, err, "webhook responded with HTTP 500: smoke test failed")
+
+	assert.Equal(t, []map[string]string{
+		{"phase": "pre-deploy", "environment": "env", "group": "group"},
+		{"phase": "post-deploy", "environment": "env", "group": "group", "project": "p", "type": "dashboard", "configId": "c", "objectId": "object-id"},
+	}, received)
+}
+
+func TestRun_WebhookHeaderFromEnvironment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	hooks := []configHook.Hook{{URL: server.URL, Headers: map[string]configHook.HeaderValue{"Authorization": {Type: configHook.TypeEnvironment, Value: "HOOK_TEST_TOKEN"}}}}
+	e := hook.Event{Phase: configHook.PreDeploy, Environment: "env", Group: "group"}
+
+	err := hook.Run(context.TODO(), hooks, e)
+	assert.ErrorContains(t, err, `failed to resolve header "Authorization": environment variable "HOOK_TEST_TOKEN" could not be found`)
+
+	t.Setenv("HOOK_TEST_TOKEN", "Bearer secret-token")
+	assert.NoError(t, hook.Run(context.TODO(), hooks, e))
+}
+
+func TestRun_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires a POSIX shell")
+	}
+
+	coord := coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "c"}
+	e := hook.Event{Phase: configHook.PostDeploy, Environment: "env", Group: "group", Coordinate: &coord, ObjectID: "object-id"}
+
+	check := []string{"sh", "-c", `test "$MONACO_HOOK_PHASE:$MONACO_ENVIRONMENT:$MONACO_ENVIRONMENT_GROUP:$MONACO_PROJECT:$MONACO_CONFIG_TYPE:$MONACO_CONFIG_ID:$MONACO_OBJECT_ID" = "post-deploy:env:group:p:dashboard:c:object-id"`}
+	assert.NoError(t, hook.Run(context.TODO(), []configHook.Hook{{Command: check}}, e))
+
+	err := hook.Run(context.TODO(), []configHook.Hook{{Command: []string{"sh", "-c", "echo maintenance window failed; exit 3"}}}, e)
+	assert.ErrorContains(t, err, "exit status 3: maintenance window failed")
+
+	err = hook.Run(context.TODO(), []configHook.Hook{{Command: []string{"sleep", "5"}, Timeout: 10 * time.Millisecond}}, e)
+	assert.Error(t, err)
+}
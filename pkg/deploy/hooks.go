@@ -0,0 +1,48 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
+	hookRunner "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/hook"
+)
+
+// runEnvironmentHooks runs the hooks of the given phase that are defined for the environment
+func (d environmentDeployment) runEnvironmentHooks(ctx context.Context, p hook.Phase) error {
+	return d.runHooks(ctx, d.opts.Hooks[d.env.Name].Of(p), hookRunner.Event{Phase: p, Environment: d.env.Name, Group: d.env.Group})
+}
+
+// runConfigHooks runs the hooks of the given phase that are defined for the config. The objectID is only known after
+// the config was deployed.
+func (d environmentDeployment) runConfigHooks(ctx context.Context, c *config.Config, p hook.Phase, objectID string) error {
+	return d.runHooks(ctx, c.Hooks.Of(p), hookRunner.Event{Phase: p, Environment: d.env.Name, Group: d.env.Group, Coordinate: &c.Coordinate, ObjectID: objectID})
+}
+
+// runHooks runs the given hooks, unless the deployment is a dry-run
+func (d environmentDeployment) runHooks(ctx context.Context, hooks []hook.Hook, e hookRunner.Event) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+	if d.opts.DryRun {
+		log.WithCtxFields(ctx).Debug("Skipping %d %s hook(s) in dry-run mode", len(hooks), e.Phase)
+		return nil
+	}
+	return hookRunner.Run(ctx, hooks, e)
+}
@@ -29,10 +29,12 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"net/http"
 )
 
 //go:generate mockgen -source=automation.go -destination=automation_mock.go -package=automation automationClient
 type Client interface {
+	Get(ctx context.Context, resourceType automationAPI.ResourceType, id string) (result automation.Response, err error)
 	Upsert(ctx context.Context, resourceType automationAPI.ResourceType, id string, data []byte) (result automation.Response, err error)
 }
 
@@ -41,6 +43,12 @@ var _ Client = (*DummyClient)(nil)
 type DummyClient struct {
 }
 
+func (c *DummyClient) Get(_ context.Context, _ automationAPI.ResourceType, _ string) (automation.Response, error) {
+	return automation.Response{
+		StatusCode: http.StatusNotFound,
+	}, nil
+}
+
 func (c *DummyClient) Upsert(_ context.Context, _ automationAPI.ResourceType, id string, _ []byte) (automation.Response, error) {
 	return automation.Response{
 		StatusCode: 200,
@@ -54,13 +62,7 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 		return entities.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.AutomationType{}.ID(), c.Type.ID()))
 	}
 
-	var id string
-
-	if c.OriginObjectId != "" {
-		id = c.OriginObjectId
-	} else {
-		id = idutils.GenerateUUIDFromCoordinate(c.Coordinate)
-	}
+	id := ObjectID(c)
 
 	resourceType, err := automationutils.ClientResourceTypeFromConfigType(t.Resource)
 	if err != nil {
@@ -90,6 +92,7 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 	properties[config.IdParameter] = obj.ID
 	resolved := entities.ResolvedEntity{
 		EntityName: name,
+		ObjectID:   obj.ID,
 		Coordinate: c.Coordinate,
 		Properties: properties,
 		Skip:       false,
@@ -97,3 +100,11 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 	return resolved, nil
 
 }
+
+// ObjectID returns the ID of the automation object the given config is deployed to
+func ObjectID(c *config.Config) string {
+	if c.OriginObjectId != "" {
+		return c.OriginObjectId
+	}
+	return idutils.GenerateUUIDFromCoordinate(c.Coordinate)
+}
@@ -0,0 +1,55 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package automation
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/automationutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+	"net/http"
+)
+
+// Lookup fetches the existing automation object that Deploy would update for the given config.
+// If no such object exists, false is returned.
+func Lookup(ctx context.Context, client Client, c *config.Config) (remote.Object, bool, error) {
+	t, ok := c.Type.(config.AutomationType)
+	if !ok {
+		return remote.Object{}, false, fmt.Errorf("config was not of expected type %q, but %q", config.AutomationType{}.ID(), c.Type.ID())
+	}
+
+	id := ObjectID(c)
+
+	resourceType, err := automationutils.ClientResourceTypeFromConfigType(t.Resource)
+	if err != nil {
+		return remote.Object{}, false, err
+	}
+
+	resp, err := client.Get(ctx, resourceType, id)
+	if err != nil {
+		return remote.Object{}, false, fmt.Errorf("failed to get automation object of type %s with id %s: %w", t.Resource, id, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return remote.Object{}, false, nil
+	}
+	if err, isErr := resp.AsAPIError(); isErr {
+		return remote.Object{}, false, fmt.Errorf("failed to get automation object of type %s with id %s: %w", t.Resource, id, err)
+	}
+
+	return remote.Object{ID: id, Payload: resp.Data}, true, nil
+}
@@ -32,6 +32,7 @@ import (
 )
 
 type Client interface {
+	Get(ctx context.Context, bucketName string) (buckets.Response, error)
 	Upsert(ctx context.Context, bucketName string, data []byte) (buckets.Response, error)
 }
 
@@ -39,6 +40,12 @@ var _ Client = (*DummyClient)(nil)
 
 type DummyClient struct{}
 
+func (c DummyClient) Get(_ context.Context, _ string) (buckets.Response, error) {
+	return buckets.Response{
+		StatusCode: http.StatusNotFound,
+	}, nil
+}
+
 func (c DummyClient) Upsert(_ context.Context, id string, data []byte) (response buckets.Response, err error) {
 	return buckets.Response{
 		StatusCode: http.StatusOK,
@@ -47,13 +54,7 @@ func (c DummyClient) Upsert(_ context.Context, id string, data []byte) (response
 }
 
 func Deploy(ctx context.Context, client Client, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
-	var bucketName string
-
-	if c.OriginObjectId != "" {
-		bucketName = c.OriginObjectId
-	} else {
-		bucketName = idutils.GenerateBucketName(c.Coordinate)
-	}
+	bucketName := BucketName(c)
 
 	// create new context to carry logger
 	ctx = logr.NewContext(ctx, log.WithCtxFields(ctx).GetLogr())
@@ -69,7 +70,16 @@ func Deploy(ctx context.Context, client Client, properties parameter.Properties,
 
 	return entities.ResolvedEntity{
 		EntityName: bucketName,
+		ObjectID:   bucketName,
 		Coordinate: c.Coordinate,
 		Properties: properties,
 	}, nil
 }
+
+// BucketName returns the name of the bucket the given config is deployed to
+func BucketName(c *config.Config) string {
+	if c.OriginObjectId != "" {
+		return c.OriginObjectId
+	}
+	return idutils.GenerateBucketName(c.Coordinate)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"testing"
 )
 
@@ -39,6 +40,10 @@ type testClient struct {
 	assertAndRespondFunc assertAndRespond
 }
 
+func (c testClient) Get(_ context.Context, _ string) (buckets.Response, error) {
+	return buckets.Response{StatusCode: http.StatusNotFound}, nil
+}
+
 func (c testClient) Upsert(_ context.Context, bucketName string, data []byte) (buckets.Response, error) {
 	return c.assertAndRespondFunc(c.t, bucketName, data)
 }
@@ -77,6 +82,7 @@ func TestDeploy(t *testing.T) {
 			},
 			entities.ResolvedEntity{
 				EntityName: "proj_my-bucket",
+				ObjectID:   "proj_my-bucket",
 				Coordinate: testCoord,
 				Properties: parameter.Properties{
 					config.IdParameter: "proj_my-bucket",
@@ -103,6 +109,7 @@ func TestDeploy(t *testing.T) {
 			},
 			entities.ResolvedEntity{
 				EntityName: "PreExistingBucket",
+				ObjectID:   "PreExistingBucket",
 				Coordinate: testCoord,
 				Properties: parameter.Properties{
 					config.IdParameter: "PreExistingBucket",
@@ -0,0 +1,49 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bucket
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+	clientErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"github.com/go-logr/logr"
+	"net/http"
+)
+
+// Lookup fetches the existing bucket that Deploy would update for the given config.
+// If no such bucket exists, false is returned.
+func Lookup(ctx context.Context, client Client, c *config.Config) (remote.Object, bool, error) {
+	bucketName := BucketName(c)
+
+	// create new context to carry logger
+	ctx = logr.NewContext(ctx, log.WithCtxFields(ctx).GetLogr())
+	resp, err := client.Get(ctx, bucketName)
+	if err != nil {
+		return remote.Object{}, false, fmt.Errorf("failed to get bucket with bucketName %q: %w", bucketName, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return remote.Object{}, false, nil
+	}
+	if !resp.IsSuccess() {
+		return remote.Object{}, false, clientErrors.NewRespErr(fmt.Sprintf("failed to get bucket with bucketName %q", bucketName), clientErrors.Response{Body: resp.Data, StatusCode: resp.StatusCode})
+	}
+
+	return remote.Object{ID: bucketName, Payload: resp.Data}, true, nil
+}
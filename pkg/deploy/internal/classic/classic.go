@@ -18,6 +18,7 @@ package classic
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
@@ -26,11 +27,15 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"net/http"
 )
 
-func Deploy(ctx context.Context, configClient dtclient.ConfigClient, apis api.APIs, properties parameter.Properties, renderedConfig string, conf *config.Config) (entities.ResolvedEntity, error) {
+// Deploy deploys the given classic config. If the config was deployed before, recordedObjectID is the ID of its object
+// recorded in the deployment state, and the object is updated by this ID as long as it still exists.
+func Deploy(ctx context.Context, configClient dtclient.ConfigClient, apis api.APIs, properties parameter.Properties, renderedConfig string, conf *config.Config, recordedObjectID string) (entities.ResolvedEntity, error) {
 	t, ok := conf.Type.(config.ClassicApiType)
 	if !ok {
 		return entities.ResolvedEntity{}, fmt.Errorf("config was not of expected type %q, but %q", config.ClassicApiTypeId, conf.Type.ID())
@@ -50,15 +55,24 @@ func Deploy(ctx context.Context, configClient dtclient.ConfigClient, apis api.AP
 		log.WithCtxFields(ctx).Warn("API for \"%s\" is deprecated! Please consider migrating to \"%s\"!", apiToDeploy.ID, apiToDeploy.DeprecatedBy)
 	}
 
+	recordedObjectExists, err := objectExists(ctx, configClient, apiToDeploy, recordedObjectID)
+	if err != nil {
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(conf, err.Error()).WithError(err)
+	}
+
 	var dtEntity dtclient.DynatraceEntity
-	if apiToDeploy.NonUniqueName {
+	switch {
+	case recordedObjectExists:
+		// the object is known, so it is updated by its ID regardless of its current name
+		dtEntity, err = configClient.UpsertConfigByNonUniqueNameAndId(ctx, apiToDeploy, recordedObjectID, configName, []byte(renderedConfig), true)
+	case apiToDeploy.NonUniqueName:
 		dtEntity, err = upsertNonUniqueNameConfig(ctx, configClient, apiToDeploy, conf, configName, renderedConfig)
-	} else {
+	default:
 		dtEntity, err = configClient.UpsertConfigByName(ctx, apiToDeploy, configName, []byte(renderedConfig))
 	}
 
 	if err != nil {
-		return entities.ResolvedEntity{}, errors.NewConfigDeployErr(conf, err.Error()).WithError(err)
+		return entities.ResolvedEntity{}, deployErrors.NewConfigDeployErr(conf, err.Error()).WithError(err)
 	}
 
 	properties[config.IdParameter] = dtEntity.Id
@@ -66,6 +80,7 @@ func Deploy(ctx context.Context, configClient dtclient.ConfigClient, apis api.AP
 
 	return entities.ResolvedEntity{
 		EntityName: dtEntity.Name,
+		ObjectID:   dtEntity.Id,
 		Coordinate: conf.Coordinate,
 		Properties: properties,
 		Skip:       false,
@@ -73,6 +88,17 @@ func Deploy(ctx context.Context, configClient dtclient.ConfigClient, apis api.AP
 }
 
 func upsertNonUniqueNameConfig(ctx context.Context, client dtclient.ConfigClient, apiToDeploy api.API, conf *config.Config, configName string, renderedConfig string) (dtclient.DynatraceEntity, error) {
+	entityUuid := nonUniqueNameEntityID(conf)
+
+	duplicate, err := isDuplicate(conf)
+	if err != nil {
+		return dtclient.DynatraceEntity{}, err
+	}
+	return client.UpsertConfigByNonUniqueNameAndId(ctx, apiToDeploy, entityUuid, configName, []byte(renderedConfig), duplicate)
+}
+
+// nonUniqueNameEntityID returns the ID a config of a non-unique-name API is deployed with
+func nonUniqueNameEntityID(conf *config.Config) string {
 	configID := conf.Coordinate.ConfigId
 	projectId := conf.Coordinate.Project
 
@@ -82,21 +108,52 @@ func upsertNonUniqueNameConfig(ctx context.Context, client dtclient.ConfigClient
 	if !isUUIDOrMeID {
 		entityUuid = idutils.GenerateUUIDFromConfigId(projectId, configID)
 	}
+	return entityUuid
+}
 
-	// check if we are dealing with a non-unique name configuration that appears multiple times
-	// in a monaco project. if that's the case, we need to handle it differently, by setting the
-	// duplicate parameter accordingly
-	var duplicate bool
-	if val, exists := conf.Parameters[config.NonUniqueNameConfigDuplicationParameter]; exists {
-		resolvedVal, err := val.ResolveValue(parameter.ResolveContext{})
-		if err != nil {
-			return dtclient.DynatraceEntity{}, err
-		}
-		resolvedValBool, ok := resolvedVal.(bool)
-		if !ok {
-			return dtclient.DynatraceEntity{}, err
-		}
-		duplicate = resolvedValBool
+// isDuplicate checks if we are dealing with a non-unique name configuration that appears multiple times
+// in a monaco project. if that's the case, we need to handle it differently, by setting the
+// duplicate parameter accordingly
+func isDuplicate(conf *config.Config) (bool, error) {
+	val, exists := conf.Parameters[config.NonUniqueNameConfigDuplicationParameter]
+	if !exists {
+		return false, nil
 	}
-	return client.UpsertConfigByNonUniqueNameAndId(ctx, apiToDeploy, entityUuid, configName, []byte(renderedConfig), duplicate)
+
+	resolvedVal, err := val.ResolveValue(parameter.ResolveContext{})
+	if err != nil {
+		return false, err
+	}
+	resolvedValBool, ok := resolvedVal.(bool)
+	if !ok {
+		return false, fmt.Errorf("parameter %q is not a boolean", config.NonUniqueNameConfigDuplicationParameter)
+	}
+	return resolvedValBool, nil
+}
+
+// objectExists returns whether an object with the given ID exists on the environment. Single configuration APIs do not
+// have object IDs, so false is always returned for them, as well as for empty IDs.
+func objectExists(ctx context.Context, client dtclient.ConfigClient, a api.API, objectID string) (bool, error) {
+	_, exists, err := readObject(ctx, client, a, objectID)
+	return exists, err
+}
+
+// readObject reads the object with the given ID by its ID, so that the whole API does not need to be listed. If the
+// object does not exist, false is returned. Single configuration APIs do not have object IDs, so false is always
+// returned for them, as well as for empty IDs.
+func readObject(ctx context.Context, client dtclient.ConfigClient, a api.API, objectID string) ([]byte, bool, error) {
+	if objectID == "" || a.SingleConfiguration {
+		return nil, false, nil
+	}
+
+	payload, err := client.ReadConfigById(ctx, a, objectID)
+	var respErr rest.RespError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+		log.WithCtxFields(ctx).Debug("Recorded object %q of config does not exist - searching for the object to update by name", objectID)
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read recorded object %q: %w", objectID, err)
+	}
+	return payload, true, nil
 }
@@ -27,7 +27,10 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
 	"testing"
 )
 
@@ -60,7 +63,7 @@ func TestDeployConfigShouldFailOnAnAlreadyKnownEntityName(t *testing.T) {
 	}
 	entityMap := entities.New()
 	entityMap.Put(entities.ResolvedEntity{EntityName: name, Coordinate: coordinate.Coordinate{Type: "dashboard"}})
-	_, errors := Deploy(context.TODO(), client, testApiMap, nil, "", &conf)
+	_, errors := Deploy(context.TODO(), client, testApiMap, nil, "", &conf, "")
 
 	assert.NotEmpty(t, errors)
 }
@@ -112,7 +115,7 @@ func TestDeployConfigShouldFailCyclicParameterDependencies(t *testing.T) {
 		Skip:        false,
 	}
 
-	_, errors := Deploy(context.TODO(), client, testApiMap, nil, "", &conf)
+	_, errors := Deploy(context.TODO(), client, testApiMap, nil, "", &conf, "")
 	assert.NotEmpty(t, errors)
 }
 
@@ -133,7 +136,7 @@ func TestDeployConfigShouldFailOnMissingNameParameter(t *testing.T) {
 		Skip:        false,
 	}
 
-	_, errors := Deploy(context.TODO(), client, testApiMap, nil, "", &conf)
+	_, errors := Deploy(context.TODO(), client, testApiMap, nil, "", &conf, "")
 	assert.NotEmpty(t, errors)
 }
 
@@ -170,7 +173,7 @@ func TestDeployConfigShouldFailOnReferenceOnUnknownConfig(t *testing.T) {
 		Skip:        false,
 	}
 
-	_, errors := Deploy(context.TODO(), client, testApiMap, nil, "", &conf)
+	_, errors := Deploy(context.TODO(), client, testApiMap, nil, "", &conf, "")
 	assert.NotEmpty(t, errors)
 }
 
@@ -209,6 +212,57 @@ func TestDeployConfigShouldFailOnReferenceOnSkipConfig(t *testing.T) {
 		Skip:        false,
 	}
 
-	_, errors := Deploy(context.TODO(), client, testApiMap, nil, "", &conf)
+	_, errors := Deploy(context.TODO(), client, testApiMap, nil, "", &conf, "")
 	assert.NotEmpty(t, errors)
 }
+
+func TestDeployConfigUpdatesRecordedObjectById(t *testing.T) {
+	conf := config.Config{
+		Type:       config.ClassicApiType{Api: "dashboard"},
+		Template:   testutils.GenerateDummyTemplate(t),
+		Coordinate: coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+	}
+	properties := parameter.Properties{config.NameParameter: "renamed-dashboard"}
+
+	t.Run("existing object is updated by its ID", func(t *testing.T) {
+		client := dtclient.NewMockClient(gomock.NewController(t))
+		client.EXPECT().ReadConfigById(gomock.Any(), dashboardApi, "dashboard-id").Return([]byte(`{"name": "old-name"}`), nil)
+		client.EXPECT().UpsertConfigByNonUniqueNameAndId(gomock.Any(), dashboardApi, "dashboard-id", "renamed-dashboard", []byte("{}"), true).
+			Return(dtclient.DynatraceEntity{Id: "dashboard-id", Name: "renamed-dashboard"}, nil)
+
+		resolved, err := Deploy(context.TODO(), client, testApiMap, properties, "{}", &conf, "dashboard-id")
+		assert.NoError(t, err)
+		assert.Equal(t, "dashboard-id", resolved.ObjectID)
+	})
+
+	t.Run("missing object falls back to upsert by name", func(t *testing.T) {
+		client := dtclient.NewMockClient(gomock.NewController(t))
+		client.EXPECT().ReadConfigById(gomock.Any(), dashboardApi, "dashboard-id").Return(nil, rest.RespError{StatusCode: http.StatusNotFound})
+		client.EXPECT().UpsertConfigByName(gomock.Any(), dashboardApi, "renamed-dashboard", []byte("{}")).
+			Return(dtclient.DynatraceEntity{Id: "new-id", Name: "renamed-dashboard"}, nil)
+
+		resolved, err := Deploy(context.TODO(), client, testApiMap, properties, "{}", &conf, "dashboard-id")
+		assert.NoError(t, err)
+		assert.Equal(t, "new-id", resolved.ObjectID)
+	})
+
+	t.Run("failure to read the recorded object is returned", func(t *testing.T) {
+		client := dtclient.NewMockClient(gomock.NewController(t))
+		client.EXPECT().ReadConfigById(gomock.Any(), dashboardApi, "dashboard-id").Return(nil, rest.RespError{StatusCode: http.StatusInternalServerError})
+
+		_, err := Deploy(context.TODO(), client, testApiMap, properties, "{}", &conf, "dashboard-id")
+		assert.ErrorContains(t, err, `failed to read recorded object "dashboard-id"`)
+	})
+
+	t.Run("origin object ID of downloaded config is not used", func(t *testing.T) {
+		downloaded := conf
+		downloaded.OriginObjectId = "dashboard-id"
+
+		client := dtclient.NewMockClient(gomock.NewController(t))
+		client.EXPECT().UpsertConfigByName(gomock.Any(), dashboardApi, "renamed-dashboard", []byte("{}")).
+			Return(dtclient.DynatraceEntity{Id: "dashboard-id", Name: "renamed-dashboard"}, nil)
+
+		_, err := Deploy(context.TODO(), client, testApiMap, properties, "{}", &downloaded, "")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,129 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package classic
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+)
+
+// Lookup searches the environment for the existing config that Deploy would update for the given config.
+// If no such config exists, false is returned. The recordedObjectID is handled the same way as by Deploy.
+func Lookup(ctx context.Context, configClient dtclient.ConfigClient, apis api.APIs, properties parameter.Properties, conf *config.Config, recordedObjectID string) (remote.Object, bool, error) {
+	if t, ok := conf.Type.(config.ClassicApiType); ok && apis.Contains(t.Api) {
+		a := apis[t.Api]
+		// the recorded object is read directly, instead of checking its existence and reading it afterward
+		payload, exists, err := readObject(ctx, configClient, a, recordedObjectID)
+		if err != nil {
+			return remote.Object{}, false, err
+		}
+		if exists {
+			return remote.Object{ID: recordedObjectID, Payload: payload}, true, nil
+		}
+	}
+
+	id, found, err := LookupID(ctx, configClient, apis, properties, conf, "")
+	if err != nil || !found {
+		return remote.Object{}, false, err
+	}
+
+	a := apis[conf.Type.(config.ClassicApiType).Api]
+	payload, err := configClient.ReadConfigById(ctx, a, id)
+	if err != nil {
+		return remote.Object{}, false, fmt.Errorf("failed to read existing config %s: %w", id, err)
+	}
+
+	return remote.Object{ID: id, Payload: payload}, true, nil
+}
+
+// LookupID returns the ID of the existing config that Deploy would update for the given config, without reading the
+// config itself. Configs of single configuration APIs do not have IDs, so the ID of their API is returned.
+// If no such config exists, false is returned.
+func LookupID(ctx context.Context, configClient dtclient.ConfigClient, apis api.APIs, properties parameter.Properties, conf *config.Config, recordedObjectID string) (string, bool, error) {
+	t, ok := conf.Type.(config.ClassicApiType)
+	if !ok {
+		return "", false, fmt.Errorf("config was not of expected type %q, but %q", config.ClassicApiTypeId, conf.Type.ID())
+	}
+
+	a, found := apis[t.Api]
+	if !found {
+		return "", false, fmt.Errorf("unknown api `%s`. this is most likely a bug", t.Api)
+	}
+
+	configName, err := extract.ConfigName(conf, properties)
+	if err != nil {
+		return "", false, err
+	}
+
+	recordedObjectExists, err := objectExists(ctx, configClient, a, recordedObjectID)
+	if err != nil {
+		return "", false, err
+	}
+
+	var id string
+	switch {
+	case recordedObjectExists:
+		id, found = recordedObjectID, true
+	case a.SingleConfiguration:
+		// single configuration APIs always exist and are updated without an ID
+		id, found = a.ID, true
+	case a.NonUniqueName:
+		id, found, err = lookupNonUniqueNameConfig(ctx, configClient, a, conf, configName)
+	default:
+		found, id, err = configClient.ConfigExistsByName(ctx, a, configName)
+	}
+	return id, found, err
+}
+
+// lookupNonUniqueNameConfig mirrors the rules of dtclient.ConfigClient.UpsertConfigByNonUniqueNameAndId to find the
+// ID of the config that would be updated.
+func lookupNonUniqueNameConfig(ctx context.Context, client dtclient.ConfigClient, a api.API, conf *config.Config, configName string) (string, bool, error) {
+	entityUuid := nonUniqueNameEntityID(conf)
+
+	duplicate, err := isDuplicate(conf)
+	if err != nil {
+		return "", false, err
+	}
+
+	values, err := client.ListConfigs(ctx, a)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to query existing entities: %w", err)
+	}
+
+	var sameName []dtclient.Value
+	for _, v := range values {
+		if v.Name != configName {
+			continue
+		}
+		if v.Id == entityUuid {
+			return entityUuid, true, nil
+		}
+		sameName = append(sameName, v)
+	}
+
+	if featureflags.UpdateNonUniqueByNameIfSingleOneExists().Enabled() && len(sameName) == 1 && !duplicate {
+		return sameName[0].Id, true, nil
+	}
+	return "", false, nil
+}
@@ -0,0 +1,88 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package classic
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	conf := &config.Config{
+		Type:       config.ClassicApiType{Api: "dashboard"},
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "dashboard-1"},
+	}
+	properties := parameter.Properties{config.NameParameter: "my-dashboard"}
+
+	t.Run("existing config is read by its ID", func(t *testing.T) {
+		client := dtclient.NewMockClient(gomock.NewController(t))
+		client.EXPECT().ConfigExistsByName(gomock.Any(), dashboardApi, "my-dashboard").Return(true, "dashboard-id", nil)
+		client.EXPECT().ReadConfigById(gomock.Any(), dashboardApi, "dashboard-id").Return([]byte(`{"name": "my-dashboard"}`), nil)
+
+		obj, found, err := Lookup(context.TODO(), client, testApiMap, properties, conf, "")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, remote.Object{ID: "dashboard-id", Payload: []byte(`{"name": "my-dashboard"}`)}, obj)
+	})
+
+	t.Run("missing config is not found", func(t *testing.T) {
+		client := dtclient.NewMockClient(gomock.NewController(t))
+		client.EXPECT().ConfigExistsByName(gomock.Any(), dashboardApi, "my-dashboard").Return(false, "", nil)
+
+		_, found, err := Lookup(context.TODO(), client, testApiMap, properties, conf, "")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("recorded object is read by its ID", func(t *testing.T) {
+		client := dtclient.NewMockClient(gomock.NewController(t))
+		client.EXPECT().ReadConfigById(gomock.Any(), dashboardApi, "recorded-id").Return([]byte(`{}`), nil)
+
+		obj, found, err := Lookup(context.TODO(), client, testApiMap, properties, conf, "recorded-id")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "recorded-id", obj.ID)
+	})
+
+	t.Run("non-unique name config is found by its generated ID", func(t *testing.T) {
+		nonUniqueApi := api.API{ID: "dashboard", URLPath: "dashboard", NonUniqueName: true}
+		generatedID := idutils.GenerateUUIDFromConfigId("project", "dashboard-1")
+
+		client := dtclient.NewMockClient(gomock.NewController(t))
+		client.EXPECT().ListConfigs(gomock.Any(), nonUniqueApi).Return([]dtclient.Value{
+			{Id: "other-id", Name: "my-dashboard"},
+			{Id: generatedID, Name: "my-dashboard"},
+		}, nil)
+		client.EXPECT().ReadConfigById(gomock.Any(), nonUniqueApi, generatedID).Return([]byte(`{}`), nil)
+
+		obj, found, err := Lookup(context.TODO(), client, api.APIs{"dashboard": nonUniqueApi}, properties, conf, "")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, generatedID, obj.ID)
+	})
+}
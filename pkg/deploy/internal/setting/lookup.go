@@ -0,0 +1,40 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package setting
+
+import (
+	"context"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+)
+
+// Lookup searches the environment for the existing settings object that Deploy would update for the given config.
+// The payload of the returned object is the object's value. If no such object exists, false is returned.
+func Lookup(ctx context.Context, settingsClient dtclient.SettingsClient, properties parameter.Properties, renderedConfig string, c *config.Config) (remote.Object, bool, error) {
+	settingsObj, err := newSettingsObject(properties, renderedConfig, c)
+	if err != nil {
+		return remote.Object{}, false, err
+	}
+
+	obj, found, err := settingsClient.FindSettingsObject(ctx, settingsObj)
+	if err != nil || !found {
+		return remote.Object{}, false, err
+	}
+	return remote.Object{ID: obj.ObjectId, Payload: obj.Value}, true, nil
+}
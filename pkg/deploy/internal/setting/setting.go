@@ -33,24 +33,10 @@ import (
 )
 
 func Deploy(ctx context.Context, settingsClient dtclient.SettingsClient, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
-	t, ok := c.Type.(config.SettingsType)
-	if !ok {
-		return entities.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.SettingsTypeId, c.Type.ID()))
-	}
-
-	scope, err := extractScope(properties)
+	settingsObj, err := newSettingsObject(properties, renderedConfig, c)
 	if err != nil {
 		return entities.ResolvedEntity{}, err
 	}
-
-	settingsObj := dtclient.SettingsObject{
-		Coordinate:     c.Coordinate,
-		SchemaId:       t.SchemaId,
-		SchemaVersion:  t.SchemaVersion,
-		Scope:          scope,
-		Content:        []byte(renderedConfig),
-		OriginObjectId: c.OriginObjectId,
-	}
 	upsertOptions := makeUpsertOptions(c)
 
 	dtEntity, err := settingsClient.UpsertSettings(ctx, settingsObj, upsertOptions)
@@ -65,7 +51,7 @@ func Deploy(ctx context.Context, settingsClient dtclient.SettingsClient, propert
 		log.WithCtxFields(ctx).Debug("failed to extract name for Settings 2.0 object %q - ID will be used", dtEntity.Id)
 	}
 
-	properties[config.IdParameter], err = getEntityID(c, dtEntity)
+	properties[config.IdParameter], err = EntityID(c, dtEntity.Id)
 	if err != nil {
 		return entities.ResolvedEntity{}, errors.NewConfigDeployErr(c, err.Error()).WithError(err)
 	}
@@ -74,6 +60,7 @@ func Deploy(ctx context.Context, settingsClient dtclient.SettingsClient, propert
 
 	return entities.ResolvedEntity{
 		EntityName: name,
+		ObjectID:   dtEntity.Id,
 		Coordinate: c.Coordinate,
 		Properties: properties,
 		Skip:       false,
@@ -81,6 +68,27 @@ func Deploy(ctx context.Context, settingsClient dtclient.SettingsClient, propert
 
 }
 
+func newSettingsObject(properties parameter.Properties, renderedConfig string, c *config.Config) (dtclient.SettingsObject, error) {
+	t, ok := c.Type.(config.SettingsType)
+	if !ok {
+		return dtclient.SettingsObject{}, errors.NewConfigDeployErr(c, fmt.Sprintf("config was not of expected type %q, but %q", config.SettingsTypeId, c.Type.ID()))
+	}
+
+	scope, err := extractScope(properties)
+	if err != nil {
+		return dtclient.SettingsObject{}, err
+	}
+
+	return dtclient.SettingsObject{
+		Coordinate:     c.Coordinate,
+		SchemaId:       t.SchemaId,
+		SchemaVersion:  t.SchemaVersion,
+		Scope:          scope,
+		Content:        []byte(renderedConfig),
+		OriginObjectId: c.OriginObjectId,
+	}, nil
+}
+
 func makeUpsertOptions(c *config.Config) dtclient.UpsertSettingsOptions {
 	// SPECIAL HANDLING: if settings config to be deployed has a reference to a "bucket" definition
 	// we need to drastically increase the retry settings for the upsert operation, as it could take
@@ -123,14 +131,15 @@ func extractScope(properties parameter.Properties) (string, error) {
 	}
 }
 
-func getEntityID(c *config.Config, e dtclient.DynatraceEntity) (string, error) {
+// EntityID returns the ID that configurations referencing the settings object with the given object ID resolve.
+func EntityID(c *config.Config, objectID string) (string, error) {
 	if c.Coordinate.Type == "builtin:management-zones" && featureflags.ManagementZoneSettingsNumericIDs().Enabled() {
-		numID, err := idutils.GetNumericIDForObjectID(e.Id)
+		numID, err := idutils.GetNumericIDForObjectID(objectID)
 		if err != nil {
-			return "", fmt.Errorf("failed to extract numeric ID for Management Zone Setting with object ID %q: %w", e.Id, err)
+			return "", fmt.Errorf("failed to extract numeric ID for Management Zone Setting with object ID %q: %w", objectID, err)
 		}
 		return fmt.Sprintf("%d", numID), nil
 	}
 
-	return e.Id, nil
+	return objectID, nil
 }
@@ -0,0 +1,167 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"strconv"
+	"strings"
+)
+
+// createOnlyEntity returns the entity of the existing object of a create-only config. If the config is not create-only,
+// or its object does not exist yet, false is returned and the config needs to be deployed.
+func (d environmentDeployment) createOnlyEntity(ctx context.Context, c *config.Config, properties parameter.Properties, renderedConfig string) (entities.ResolvedEntity, bool, error) {
+	if !c.Lifecycle.CreateOnly || d.opts.DryRun {
+		return entities.ResolvedEntity{}, false, nil
+	}
+
+	obj, found, err := d.lookup(ctx, c, properties, renderedConfig)
+	if err != nil || !found {
+		return entities.ResolvedEntity{}, false, err
+	}
+
+	resolvedEntity, err := ExistingEntity(c, properties, obj.ID)
+	if err != nil {
+		return entities.ResolvedEntity{}, false, err
+	}
+	return resolvedEntity, true, nil
+}
+
+// withIgnoredChanges returns the rendered config with the values of all JSON fields whose changes are ignored replaced
+// by their values in the existing object. If the object does not exist yet, the rendered config is returned as is.
+func (d environmentDeployment) withIgnoredChanges(ctx context.Context, c *config.Config, properties parameter.Properties, renderedConfig string) (string, error) {
+	if len(c.Lifecycle.IgnoreChanges) == 0 || d.opts.DryRun {
+		return renderedConfig, nil
+	}
+
+	obj, found, err := d.lookup(ctx, c, properties, renderedConfig)
+	if err != nil || !found {
+		return renderedConfig, err
+	}
+
+	log.WithCtxFields(ctx).Debug("Keeping values of %v of existing object %q", c.Lifecycle.IgnoreChanges, obj.ID)
+	return WithIgnoredChanges(c, renderedConfig, obj.Payload)
+}
+
+// WithIgnoredChanges returns the rendered config with the values of all JSON fields whose changes are ignored replaced
+// by their values in the given payload of the existing object, like a deployment does. Planning a deployment and
+// detecting drift use it to compare configs like they are deployed.
+func WithIgnoredChanges(c *config.Config, renderedConfig string, existing []byte) (string, error) {
+	if len(c.Lifecycle.IgnoreChanges) == 0 {
+		return renderedConfig, nil
+	}
+	return keepValues(renderedConfig, existing, c.Lifecycle.IgnoreChanges)
+}
+
+// keepValues replaces the values of the given paths in the payload by their values in the existing payload. Paths that
+// do not exist in the existing payload are left as they are in the payload.
+func keepValues(payload string, existing []byte, paths []string) (string, error) {
+	local, err := decode([]byte(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse payload: %w", err)
+	}
+	remote, err := decode(existing)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse payload of existing object: %w", err)
+	}
+
+	for _, path := range paths {
+		segments := strings.Split(path, ".")
+		value, found := valueAt(remote, segments)
+		if !found {
+			continue
+		}
+
+		if local, err = withValueAt(local, segments, value); err != nil {
+			return "", fmt.Errorf("failed to keep value of %q: %w", path, err)
+		}
+	}
+
+	result, err := json.Marshal(local)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize payload: %w", err)
+	}
+	return string(result), nil
+}
+
+// decode parses the JSON data, keeping numbers as they are to not lose the precision of large integers
+func decode(data []byte) (any, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var v any
+	err := d.Decode(&v)
+	return v, err
+}
+
+// valueAt returns the value at the given path segments, where numeric segments index arrays
+func valueAt(node any, segments []string) (any, bool) {
+	for _, s := range segments {
+		switch n := node.(type) {
+		case map[string]any:
+			v, found := n[s]
+			if !found {
+				return nil, false
+			}
+			node = v
+		case []any:
+			i, err := strconv.Atoi(s)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, false
+			}
+			node = n[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// withValueAt sets the value at the given path segments, and returns the modified node. Missing objects along the path
+// are created, array elements need to exist.
+func withValueAt(node any, segments []string, value any) (any, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+
+	s, rest := segments[0], segments[1:]
+	switch n := node.(type) {
+	case nil:
+		child, err := withValueAt(nil, rest, value)
+		return map[string]any{s: child}, err
+	case map[string]any:
+		child, err := withValueAt(n[s], rest, value)
+		n[s] = child
+		return n, err
+	case []any:
+		i, err := strconv.Atoi(s)
+		if err != nil || i < 0 || i >= len(n) {
+			return nil, fmt.Errorf("%q is not an index of an array with %d elements", s, len(n))
+		}
+		n[i], err = withValueAt(n[i], rest, value)
+		return n, err
+	default:
+		return nil, fmt.Errorf("%q can not be set, as its parent is neither an object nor an array", s)
+	}
+}
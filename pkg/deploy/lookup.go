@@ -0,0 +1,78 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/remote"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
+)
+
+// LookupObject searches the environment for the existing object that deploying the config would update.
+// The recordedObjectID is the ID of the object recorded for the config in the deployment state, or an empty string if
+// no object is recorded. Like in a deployment, a recorded object takes precedence over objects found by name.
+// If no such object exists, false is returned.
+func LookupObject(ctx context.Context, c *config.Config, clients ClientSet, properties parameter.Properties, renderedConfig string, recordedObjectID string) (remote.Object, bool, error) {
+	return lookupObject(ctx, withObjectID(c, recordedObjectID), clients, properties, renderedConfig, recordedObjectID)
+}
+
+// lookup searches the environment for the existing object of the config, taking the object recorded for the config in
+// the deployment state into account
+func (d environmentDeployment) lookup(ctx context.Context, c *config.Config, properties parameter.Properties, renderedConfig string) (remote.Object, bool, error) {
+	return lookupObject(ctx, c, d.clients, properties, renderedConfig, d.recordedObjectID(c))
+}
+
+func lookupObject(ctx context.Context, c *config.Config, clients ClientSet, properties parameter.Properties, renderedConfig string, recordedObjectID string) (remote.Object, bool, error) {
+	switch c.Type.(type) {
+	case config.SettingsType:
+		return setting.Lookup(ctx, clients.Settings, properties, renderedConfig, c)
+	case config.ClassicApiType:
+		return classic.Lookup(ctx, clients.Classic, api.NewAPIs(), properties, c, recordedObjectID)
+	case config.AutomationType:
+		return automation.Lookup(ctx, clients.Automation, c)
+	case config.BucketType:
+		return bucket.Lookup(ctx, clients.Bucket, c)
+	default:
+		return remote.Object{}, false, fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
+	}
+}
+
+// LookupObjectID returns the ID of the existing object that deploying the config would update, without reading the
+// object itself. The IDs of automation objects and buckets are derived from the config, so they are returned whether the
+// object exists or not. For all other configs, false is returned if no such object exists.
+func LookupObjectID(ctx context.Context, c *config.Config, clients ClientSet, properties parameter.Properties, renderedConfig string) (string, bool, error) {
+	switch c.Type.(type) {
+	case config.SettingsType:
+		obj, found, err := setting.Lookup(ctx, clients.Settings, properties, renderedConfig, c)
+		return obj.ID, found, err
+	case config.ClassicApiType:
+		return classic.LookupID(ctx, clients.Classic, api.NewAPIs(), properties, c, "")
+	case config.AutomationType:
+		return automation.ObjectID(c), true, nil
+	case config.BucketType:
+		return bucket.BucketName(c), true, nil
+	default:
+		return "", false, fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
+	}
+}
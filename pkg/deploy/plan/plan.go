@@ -0,0 +1,322 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package plan calculates which changes a deployment would apply to Dynatrace environments, without changing anything.
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/mutlierror"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/extract"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/setting"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/validate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	classicDownload "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"sync"
+)
+
+// Action describes what deploying a config would do on an environment
+type Action string
+
+const (
+	// Create marks configs for which no object exists yet
+	Create Action = "create"
+	// Update marks configs for which an object exists, but differs from the rendered config
+	Update Action = "update"
+	// Unchanged marks configs for which an object exists that matches the rendered config, or that are create-only and
+	// therefore not updated
+	Unchanged Action = "unchanged"
+	// Skip marks configs that would not be deployed, either because they are skipped, or because a config they depend on
+	// would not be deployed
+	Skip Action = "skip"
+	// Error marks configs for which the change could not be determined
+	Error Action = "error"
+)
+
+// Entry is the planned change for a single config on a single environment
+type Entry struct {
+	// Coordinate of the config
+	Coordinate coordinate.Coordinate
+	// Action the deployment would take
+	Action Action
+	// RemoteID is the ID of the existing object on the environment, if one exists
+	RemoteID string
+	// Differences are the changes an Update would apply to the existing object
+	Differences []jsonutils.Difference
+	// Err describes why the change could not be planned, if Action is Error
+	Err error
+}
+
+// Plan holds the planned changes for each environment, keyed by environment name
+type Plan map[string][]Entry
+
+// Count returns how many entries of the plan have the given Action
+func (p Plan) Count(a Action) int {
+	count := 0
+	for _, entries := range p {
+		for _, e := range entries {
+			if e.Action == a {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// HasChanges returns true if applying the plan would create or update any object
+func (p Plan) HasChanges() bool {
+	return p.Count(Create) > 0 || p.Count(Update) > 0
+}
+
+// New calculates the Plan for deploying the given projects to the given environments.
+// Parameters are resolved and templates are rendered like for a deployment, but instead of upserting configs, the
+// objects they would update are looked up on the environment and compared to the rendered config.
+// As configs that would be created do not yet have an ID, references to them resolve to a placeholder value.
+// The deployment state is optional. If it is given, objects recorded in it are looked up by their ID first, like in a
+// deployment.
+//
+// If the change of any config can not be determined, or ctx is canceled, an error is returned in addition to the Plan.
+func New(ctx context.Context, projects []project.Project, environmentClients deploy.EnvironmentClients, st *state.State) (Plan, error) {
+	if err := validate.Validate(projects); err != nil {
+		return nil, err
+	}
+
+	g := graph.New(projects, environmentClients.Names())
+	p := make(Plan, len(environmentClients))
+	errs := make(deployErrors.EnvironmentDeploymentErrors)
+
+	for env, clients := range environmentClients {
+		if ctx.Err() != nil {
+			return p, fmt.Errorf("planning was canceled: %w", context.Cause(ctx))
+		}
+
+		ctx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+		log.WithCtxFields(ctx).Info("Planning deployment of configurations to environment %q...", env.Name)
+
+		components, err := g.GetIndependentlySortedConfigs(env.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get independently sorted configs for environment %q: %w", env.Name, err)
+		}
+
+		p[env.Name] = planComponents(ctx, components, clients, recordedObjectIDs(st, env.Name))
+
+		for _, e := range p[env.Name] {
+			if e.Action == Error {
+				errs = errs.Append(env.Name, fmt.Errorf("failed to plan deployment of %s: %w", e.Coordinate, e.Err))
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		return p, fmt.Errorf("planning was canceled: %w", context.Cause(ctx))
+	}
+	if len(errs) > 0 {
+		return p, errs
+	}
+	return p, nil
+}
+
+func planComponents(ctx context.Context, components []graph.SortedComponent, clients deploy.ClientSet, recordedObjectID func(coordinate.Coordinate) string) []Entry {
+	var entries []Entry
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	resolvedEntities := deploy.EntityLookup{
+		EntityMap:             entities.New(),
+		MonitoredEntityFinder: deploy.NewMonitoredEntityFinder(ctx, clients.Entities),
+		RemoteObjectFinder:    deploy.NewRemoteObjectFinder(ctx, clients, false),
+		Ctx:                   ctx,
+	}
+	for i := range components {
+		wg.Add(1)
+		go func(ctx context.Context, component graph.SortedComponent) {
+			defer wg.Done()
+			e := planComponent(ctx, component, clients, resolvedEntities, recordedObjectID)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			entries = append(entries, e...)
+		}(context.WithValue(ctx, log.CtxGraphComponentId{}, log.CtxValGraphComponentId(i)), components[i])
+	}
+	wg.Wait()
+
+	return entries
+}
+
+// planComponent plans the changes of all configs of a component in their sorted order.
+// Like a deployment, configs depending on a config that would not be deployed are skipped.
+func planComponent(ctx context.Context, component graph.SortedComponent, clients deploy.ClientSet, resolvedEntities deploy.EntityLookup, recordedObjectID func(coordinate.Coordinate) string) []Entry {
+	entries := make([]Entry, 0, len(component.SortedNodes))
+	notDeployed := make(map[int64]struct{})
+
+	for _, node := range component.SortedNodes {
+		n := node.(graph.ConfigNode)
+		ctx := context.WithValue(ctx, log.CtxKeyCoord{}, n.Config.Coordinate)
+
+		if parent, found := notDeployedParent(component, n, notDeployed); found {
+			log.WithCtxFields(ctx).Debug("Skipping %v, as it depends on %v which would not be deployed", n.Config.Coordinate, parent.Config.Coordinate)
+			notDeployed[n.ID()] = struct{}{}
+			entries = append(entries, Entry{Coordinate: n.Config.Coordinate, Action: Skip})
+			continue
+		}
+
+		entry, entity := planConfig(ctx, n.Config, clients, resolvedEntities, recordedObjectID(n.Config.Coordinate))
+		if entry.Action == Skip || entry.Action == Error {
+			notDeployed[n.ID()] = struct{}{}
+		} else {
+			resolvedEntities.Put(entity)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// recordedObjectIDs returns a function returning the ID of the object recorded for a config on the given environment in
+// the deployment state, or an empty string if no object is recorded or no state is given
+func recordedObjectIDs(st *state.State, environment string) func(coordinate.Coordinate) string {
+	return func(c coordinate.Coordinate) string {
+		if st == nil {
+			return ""
+		}
+		e, _ := st.Get(environment, c)
+		return e.ObjectID
+	}
+}
+
+func notDeployedParent(component graph.SortedComponent, n graph.ConfigNode, notDeployed map[int64]struct{}) (graph.ConfigNode, bool) {
+	parents := component.Graph.To(n.ID())
+	for parents.Next() {
+		p := parents.Node().(graph.ConfigNode)
+		if _, found := notDeployed[p.ID()]; found {
+			return p, true
+		}
+	}
+	return graph.ConfigNode{}, false
+}
+
+func planConfig(ctx context.Context, c *config.Config, clients deploy.ClientSet, resolvedEntities deploy.EntityLookup, recordedObjectID string) (Entry, entities.ResolvedEntity) {
+	entry := Entry{Coordinate: c.Coordinate}
+
+	if c.Skip {
+		entry.Action = Skip
+		return entry, entities.ResolvedEntity{}
+	}
+
+	properties, errs := c.ResolveParameterValues(resolvedEntities)
+	if len(errs) > 0 {
+		entry.Action, entry.Err = Error, mutlierror.New(errs...)
+		return entry, entities.ResolvedEntity{}
+	}
+
+	renderedConfig, err := c.Render(properties)
+	if err != nil {
+		entry.Action, entry.Err = Error, err
+		return entry, entities.ResolvedEntity{}
+	}
+
+	obj, found, err := deploy.LookupObject(ctx, c, clients, properties, renderedConfig, recordedObjectID)
+	if err != nil {
+		log.WithCtxFields(ctx).WithFields(field.Error(err)).Error("Failed to look up existing object: %v", err)
+		entry.Action, entry.Err = Error, err
+		return entry, entities.ResolvedEntity{}
+	}
+
+	if !found {
+		entry.Action = Create
+		properties[config.IdParameter] = fmt.Sprintf("<id of %s>", c.Coordinate)
+		return entry, resolvedEntity(c, properties)
+	}
+
+	entry.RemoteID = obj.ID
+	entry.Action = Unchanged
+	if !c.Lifecycle.CreateOnly {
+		if entry.Differences, err = differences(c, obj.ID, obj.Payload, renderedConfig); err != nil {
+			entry.Action, entry.Err = Error, err
+			return entry, entities.ResolvedEntity{}
+		}
+		if len(entry.Differences) > 0 {
+			entry.Action = Update
+		}
+	}
+
+	properties[config.IdParameter] = obj.ID
+	if _, isSetting := c.Type.(config.SettingsType); isSetting {
+		if properties[config.IdParameter], err = setting.EntityID(c, obj.ID); err != nil {
+			entry.Action, entry.Err = Error, err
+			return entry, entities.ResolvedEntity{}
+		}
+	}
+	return entry, resolvedEntity(c, properties)
+}
+
+// differences returns the changes deploying the rendered config would apply to the existing object with the given
+// payload. The values of fields whose changes are ignored are kept, like in a deployment.
+func differences(c *config.Config, id string, payload []byte, renderedConfig string) ([]jsonutils.Difference, error) {
+	renderedConfig, err := deploy.WithIgnoredChanges(c, renderedConfig, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to keep ignored changes of existing object %q: %w", id, err)
+	}
+
+	remotePayload, err := sanitize(c, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse existing object %q: %w", id, err)
+	}
+
+	diff, err := jsonutils.Diff(remotePayload, []byte(renderedConfig), jsonutils.DiffOptions{IgnoreUndefinedKeys: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare config with existing object %q: %w", id, err)
+	}
+	return diff, nil
+}
+
+// sanitize removes server-populated properties from the payload of an existing classic config, like a download does
+func sanitize(c *config.Config, payload []byte) ([]byte, error) {
+	t, isClassic := c.Type.(config.ClassicApiType)
+	if !isClassic {
+		return payload, nil
+	}
+
+	var properties map[string]any
+	if err := json.Unmarshal(payload, &properties); err != nil {
+		return nil, err
+	}
+	return json.Marshal(classicDownload.RemoveServerPopulatedProperties(properties, t.Api))
+}
+
+func resolvedEntity(c *config.Config, properties parameter.Properties) entities.ResolvedEntity {
+	name, err := extract.ConfigName(c, properties)
+	if err != nil {
+		name = fmt.Sprintf("%v", properties[config.IdParameter])
+	}
+	return entities.ResolvedEntity{
+		EntityName: name,
+		Coordinate: c.Coordinate,
+		Properties: properties,
+	}
+}
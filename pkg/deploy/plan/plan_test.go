@@ -0,0 +1,287 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plan_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+var (
+	profileCoordinate = coordinate.Coordinate{Project: "p", Type: "auto-tag", ConfigId: "profile"}
+	settingCoordinate = coordinate.Coordinate{Project: "p", Type: "builtin:alerting.profile", ConfigId: "setting"}
+	newCoordinate     = coordinate.Coordinate{Project: "p", Type: "builtin:alerting.profile", ConfigId: "new"}
+	skippedCoordinate = coordinate.Coordinate{Project: "p", Type: "auto-tag", ConfigId: "skipped"}
+	childCoordinate   = coordinate.Coordinate{Project: "p", Type: "builtin:alerting.profile", ConfigId: "child"}
+)
+
+func givenProjects() []project.Project {
+	profile := config.Config{
+		Type:        config.ClassicApiType{Api: "auto-tag"},
+		Template:    template.NewInMemoryTemplate("profile", `{"name": "{{ .name }}", "severity": "high"}`),
+		Coordinate:  profileCoordinate,
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.NameParameter: &value.ValueParameter{Value: "profile"},
+		},
+	}
+	unchangedSetting := config.Config{
+		Type:        config.SettingsType{SchemaId: "builtin:alerting.profile"},
+		Template:    template.NewInMemoryTemplate("setting", `{"profile": "{{ .profile }}"}`),
+		Coordinate:  settingCoordinate,
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+			"profile":             reference.New("p", "auto-tag", "profile", "id"),
+		},
+	}
+	newSetting := config.Config{
+		Type:        config.SettingsType{SchemaId: "builtin:alerting.profile"},
+		Template:    template.NewInMemoryTemplate("new", `{}`),
+		Coordinate:  newCoordinate,
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+		},
+	}
+	skipped := config.Config{
+		Type:        config.ClassicApiType{Api: "auto-tag"},
+		Template:    template.NewInMemoryTemplate("skipped", `{}`),
+		Coordinate:  skippedCoordinate,
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.NameParameter: &value.ValueParameter{Value: "skipped"},
+		},
+		Skip: true,
+	}
+	child := config.Config{
+		Type:        config.SettingsType{SchemaId: "builtin:alerting.profile"},
+		Template:    template.NewInMemoryTemplate("child", `{"profile": "{{ .profile }}"}`),
+		Coordinate:  childCoordinate,
+		Environment: "env",
+		Parameters: config.Parameters{
+			config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+			"profile":             reference.New("p", "auto-tag", "skipped", "id"),
+		},
+	}
+
+	return []project.Project{
+		{
+			Id: "p",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"auto-tag":                 []config.Config{profile, skipped},
+					"builtin:alerting.profile": []config.Config{unchangedSetting, newSetting, child},
+				},
+			},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ConfigExistsByName(gomock.Any(), gomock.Any(), "profile").Return(true, "profile-id", nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), "profile-id").Return([]byte(`{"id": "profile-id", "name": "profile", "severity": "low"}`), nil)
+	c.EXPECT().FindSettingsObject(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, obj dtclient.SettingsObject) (dtclient.DownloadSettingsObject, bool, error) {
+		if obj.Coordinate == settingCoordinate {
+			return dtclient.DownloadSettingsObject{ObjectId: "setting-id", Value: []byte(`{"profile": "profile-id", "enabled": true}`)}, true, nil
+		}
+		return dtclient.DownloadSettingsObject{}, false, nil
+	}).Times(2)
+
+	clients := deploy.EnvironmentClients{
+		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Classic: c, Settings: c},
+	}
+
+	p, err := plan.New(context.TODO(), givenProjects(), clients, nil)
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []plan.Entry{
+		{
+			Coordinate: profileCoordinate,
+			Action:     plan.Update,
+			RemoteID:   "profile-id",
+			Differences: []json.Difference{
+				{Path: "severity", Kind: json.Changed, From: "low", To: "high"},
+			},
+		},
+		{Coordinate: settingCoordinate, Action: plan.Unchanged, RemoteID: "setting-id"},
+		{Coordinate: newCoordinate, Action: plan.Create},
+		{Coordinate: skippedCoordinate, Action: plan.Skip},
+		{Coordinate: childCoordinate, Action: plan.Skip},
+	}, p["env"])
+	assert.True(t, p.HasChanges())
+}
+
+func TestNew_RecordedObjectsAreLookedUpByID(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), "recorded-id").Return([]byte(`{"id": "recorded-id", "name": "renamed profile", "severity": "high"}`), nil)
+	c.EXPECT().FindSettingsObject(gomock.Any(), gomock.Any()).Return(dtclient.DownloadSettingsObject{}, false, nil).Times(2)
+
+	clients := deploy.EnvironmentClients{
+		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Classic: c, Settings: c},
+	}
+
+	st := state.New()
+	st.Put("env", profileCoordinate, state.Entry{ObjectID: "recorded-id"})
+
+	p, err := plan.New(context.TODO(), givenProjects(), clients, st)
+	assert.NoError(t, err)
+
+	assert.Contains(t, p["env"], plan.Entry{
+		Coordinate: profileCoordinate,
+		Action:     plan.Update,
+		RemoteID:   "recorded-id",
+		Differences: []json.Difference{
+			{Path: "name", Kind: json.Changed, From: "renamed profile", To: "profile"},
+		},
+	})
+}
+
+func TestNew_Lifecycle(t *testing.T) {
+	newProfile := func(id string, lifecycle config.Lifecycle) config.Config {
+		return config.Config{
+			Type:        config.ClassicApiType{Api: "auto-tag"},
+			Template:    template.NewInMemoryTemplate(id, `{"name": "{{ .name }}", "severity": "high", "rules": []}`),
+			Coordinate:  coordinate.Coordinate{Project: "p", Type: "auto-tag", ConfigId: id},
+			Environment: "env",
+			Parameters:  config.Parameters{config.NameParameter: &value.ValueParameter{Value: id}},
+			Lifecycle:   lifecycle,
+		}
+	}
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ConfigExistsByName(gomock.Any(), gomock.Any(), "create-only").Return(true, "create-only-id", nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), "create-only-id").Return([]byte(`{"name": "create-only", "severity": "low"}`), nil)
+	c.EXPECT().ConfigExistsByName(gomock.Any(), gomock.Any(), "ignored").Return(true, "ignored-id", nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), "ignored-id").Return([]byte(`{"name": "ignored", "severity": "low", "rules": [{"key": "x"}]}`), nil)
+
+	clients := deploy.EnvironmentClients{
+		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Classic: c},
+	}
+	projects := []project.Project{{
+		Id: "p",
+		Configs: project.ConfigsPerTypePerEnvironments{"env": project.ConfigsPerType{"auto-tag": []config.Config{
+			newProfile("create-only", config.Lifecycle{CreateOnly: true}),
+			newProfile("ignored", config.Lifecycle{IgnoreChanges: []string{"severity", "rules"}}),
+		}}},
+	}}
+
+	p, err := plan.New(context.TODO(), projects, clients, nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []plan.Entry{
+		{Coordinate: coordinate.Coordinate{Project: "p", Type: "auto-tag", ConfigId: "create-only"}, Action: plan.Unchanged, RemoteID: "create-only-id"},
+		{Coordinate: coordinate.Coordinate{Project: "p", Type: "auto-tag", ConfigId: "ignored"}, Action: plan.Unchanged, RemoteID: "ignored-id"},
+	}, p["env"])
+}
+
+func TestNew_LookupErrorsAreReturned(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ConfigExistsByName(gomock.Any(), gomock.Any(), "profile").Return(false, "", fmt.Errorf("request failed"))
+	c.EXPECT().FindSettingsObject(gomock.Any(), gomock.Any()).Return(dtclient.DownloadSettingsObject{}, false, nil)
+
+	clients := deploy.EnvironmentClients{
+		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Classic: c, Settings: c},
+	}
+
+	p, err := plan.New(context.TODO(), givenProjects(), clients, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, p.Count(plan.Error))
+	assert.Equal(t, 3, p.Count(plan.Skip), "setting referencing the failed profile must be skipped")
+	assert.Equal(t, 1, p.Count(plan.Create))
+}
+
+func TestNew_Canceled(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	clients := deploy.EnvironmentClients{
+		deploy.EnvironmentInfo{Name: "env"}: deploy.ClientSet{Classic: c, Settings: c},
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("interrupted"))
+
+	_, err := plan.New(ctx, givenProjects(), clients, nil)
+	assert.ErrorContains(t, err, "planning was canceled: interrupted")
+}
+
+func TestPrint(t *testing.T) {
+	p := plan.Plan{
+		"env": []plan.Entry{
+			{Coordinate: settingCoordinate, Action: plan.Unchanged, RemoteID: "setting-id"},
+			{
+				Coordinate: profileCoordinate,
+				Action:     plan.Update,
+				RemoteID:   "profile-id",
+				Differences: []json.Difference{
+					{Path: "severity", Kind: json.Changed, From: "low", To: "high"},
+				},
+			},
+			{Coordinate: newCoordinate, Action: plan.Create},
+		},
+	}
+
+	out := bytes.Buffer{}
+	err := plan.Print(&out, p)
+	assert.NoError(t, err)
+	assert.Equal(t, `Environment "env":
+  ~ update    p:auto-tag:profile (profile-id)
+        ~ severity: "low" => "high"
+  + create    p:builtin:alerting.profile:new
+  = unchanged p:builtin:alerting.profile:setting (setting-id)
+
+Plan: 1 to create, 1 to update, 1 unchanged, 0 skipped, 0 failed
+`, out.String())
+}
+
+func TestPrint_MasksSecrets(t *testing.T) {
+	secret.Register("plan-secret-value")
+	p := plan.Plan{
+		"env": []plan.Entry{
+			{
+				Coordinate: profileCoordinate,
+				Action:     plan.Update,
+				Differences: []json.Difference{
+					{Path: "token", Kind: json.Changed, From: "old", To: "plan-secret-value"},
+				},
+			},
+		},
+	}
+
+	out := bytes.Buffer{}
+	assert.NoError(t, plan.Print(&out, p))
+	assert.NotContains(t, out.String(), "plan-secret-value")
+	assert.Contains(t, out.String(), `~ token: "old" => "****"`)
+}
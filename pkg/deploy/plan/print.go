@@ -0,0 +1,76 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plan
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"io"
+	"slices"
+	"strings"
+)
+
+var actionSymbols = map[Action]string{
+	Create:    "+",
+	Update:    "~",
+	Unchanged: "=",
+	Skip:      "-",
+	Error:     "!",
+}
+
+// Print writes a human-readable representation of the Plan to w.
+// Environments and configs are printed in alphabetical order, each update is followed by its differences.
+// Registered secret values are masked.
+func Print(w io.Writer, p Plan) error {
+	b := strings.Builder{}
+
+	envs := make([]string, 0, len(p))
+	for env := range p {
+		envs = append(envs, env)
+	}
+	slices.Sort(envs)
+
+	for _, env := range envs {
+		entries := slices.Clone(p[env])
+		slices.SortFunc(entries, func(a, b Entry) int {
+			return strings.Compare(a.Coordinate.String(), b.Coordinate.String())
+		})
+
+		b.WriteString(fmt.Sprintf("Environment %q:\n", env))
+		for _, e := range entries {
+			b.WriteString(fmt.Sprintf("  %s %-9s %s", actionSymbols[e.Action], e.Action, e.Coordinate))
+			if e.RemoteID != "" {
+				b.WriteString(fmt.Sprintf(" (%s)", e.RemoteID))
+			}
+			if e.Err != nil {
+				b.WriteString(fmt.Sprintf(": %v", e.Err))
+			}
+			b.WriteString("\n")
+
+			for _, d := range e.Differences {
+				b.WriteString(fmt.Sprintf("        %s\n", d))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(fmt.Sprintf("Plan: %d to create, %d to update, %d unchanged, %d skipped, %d failed\n",
+		p.Count(Create), p.Count(Update), p.Count(Unchanged), p.Count(Skip), p.Count(Error)))
+
+	_, err := io.WriteString(secret.Writer{Writer: w}, b.String())
+	return err
+}
@@ -0,0 +1,240 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package report records the result of deploying each config, and writes it as a structured JSON or JUnit XML report.
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"github.com/spf13/afero"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status is the result of deploying a config
+type Status string
+
+const (
+	// Deployed marks configs that were deployed successfully
+	Deployed Status = "deployed"
+	// Unchanged marks configs that were not deployed again, as they are unchanged since their last deployment, or as
+	// they are create-only and their object already exists
+	Unchanged Status = "unchanged"
+	// Skipped marks configs that are skipped, or that depend on a config that was skipped
+	Skipped Status = "skipped"
+	// Failed marks configs that failed to deploy
+	Failed Status = "failed"
+	// ParentFailed marks configs that were not deployed, as they depend on a config that failed to deploy
+	ParentFailed Status = "skipped-parent-failed"
+	// Canceled marks configs that were not deployed, as the deployment was canceled
+	Canceled Status = "canceled"
+)
+
+// Error describes why a config failed to deploy
+type Error struct {
+	// Message of the error
+	Message string `json:"message"`
+	// StatusCode is the HTTP status code of a failed API call
+	StatusCode int `json:"statusCode,omitempty"`
+	// Body is the response body of a failed API call
+	Body string `json:"body,omitempty"`
+	// Method is the HTTP method of a failed API call
+	Method string `json:"method,omitempty"`
+	// URL is the URL of a failed API call
+	URL string `json:"url,omitempty"`
+}
+
+// NewError returns the Error describing err, including the details of failed API calls
+func NewError(err error) *Error {
+	e := Error{Message: err.Error()}
+
+	var respErr rest.RespError
+	if errors.As(err, &respErr) {
+		e.StatusCode = respErr.StatusCode
+		e.Body = respErr.Body
+		if respErr.Request != nil {
+			e.Method, e.URL = respErr.Request.Method, respErr.Request.URL
+		}
+	}
+	return &e
+}
+
+// MismatchKind describes how a field of a deployed object differs from the rendered config
+type MismatchKind string
+
+const (
+	// Dropped marks fields of the rendered config that are missing in the deployed object
+	Dropped MismatchKind = "dropped"
+	// Changed marks fields whose value in the deployed object differs from the one in the rendered config
+	Changed MismatchKind = "changed"
+)
+
+// Mismatch is a field of a deployed object that differs from the rendered config it was deployed from
+type Mismatch struct {
+	// Path of the field, e.g. "rules[0].enabled"
+	Path string `json:"path"`
+	// Kind describes how the field differs
+	Kind MismatchKind `json:"kind"`
+	// Expected is the value of the field in the rendered config
+	Expected any `json:"expected"`
+	// Actual is the value of the field in the deployed object, nil if the field was Dropped
+	Actual any `json:"actual,omitempty"`
+}
+
+func (m Mismatch) String() string {
+	if m.Kind == Dropped {
+		return fmt.Sprintf("%s was dropped (expected %s)", m.Path, marshalValue(m.Expected))
+	}
+	return fmt.Sprintf("%s was changed (expected %s, but got %s)", m.Path, marshalValue(m.Expected), marshalValue(m.Actual))
+}
+
+func marshalValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// Record is the result of deploying a single config to an environment
+type Record struct {
+	// Coordinate of the config
+	Coordinate coordinate.Coordinate
+	// Status of the deployment
+	Status Status
+	// ObjectID is the identifier of the deployed object on the environment
+	ObjectID string
+	// Duration the deployment of the config took
+	Duration time.Duration
+	// Reason describes why a config was skipped or canceled
+	Reason string
+	// Error describes why the deployment failed
+	Error *Error
+	// Mismatches are the fields of the deployed object that differ from the rendered config, if the deployment was
+	// verified
+	Mismatches []Mismatch
+}
+
+// Report holds the Records of a deployment per environment.
+// It is safe for concurrent use.
+type Report struct {
+	lock    sync.RWMutex
+	records map[string][]Record
+	started map[string]bool
+}
+
+// New returns an empty Report
+func New() *Report {
+	return &Report{
+		records: make(map[string][]Record),
+		started: make(map[string]bool),
+	}
+}
+
+// Start records that the deployment to the given environment started. Environments that are not started, e.g. as an
+// earlier environment or rollout stage failed or as the deployment was canceled, were not deployed at all.
+func (r *Report) Start(environment string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.started[environment] = true
+}
+
+// Started returns whether the deployment to the given environment started
+func (r *Report) Started(environment string) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.started[environment]
+}
+
+// Add records the result of deploying a config to the given environment
+func (r *Report) Add(environment string, rec Record) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.records[environment] = append(r.records[environment], rec)
+}
+
+// Environments returns the names of all environments with Records, sorted alphabetically
+func (r *Report) Environments() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	envs := make([]string, 0, len(r.records))
+	for env := range r.records {
+		envs = append(envs, env)
+	}
+	slices.Sort(envs)
+	return envs
+}
+
+// Records returns all Records of the given environment, sorted by their coordinate
+func (r *Report) Records(environment string) []Record {
+	r.lock.RLock()
+	records := slices.Clone(r.records[environment])
+	r.lock.RUnlock()
+
+	slices.SortFunc(records, func(a, b Record) int {
+		return strings.Compare(a.Coordinate.String(), b.Coordinate.String())
+	})
+	return records
+}
+
+// Count returns how many Records of the given environment have the given Status
+func (r *Report) Count(environment string, s Status) int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	count := 0
+	for _, rec := range r.records[environment] {
+		if rec.Status == s {
+			count++
+		}
+	}
+	return count
+}
+
+// Write writes the Report to the given file. Files with an '.xml' extension are written as JUnit XML, all other files
+// as JSON.
+func (r *Report) Write(fs afero.Fs, path string) error {
+	write := r.WriteJSON
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		write = r.WriteJUnit
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return fmt.Errorf("failed to create directory for report %q: %w", path, err)
+	}
+
+	f, err := fs.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report %q: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := write(f); err != nil {
+		return fmt.Errorf("failed to write report %q: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package report_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/report"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/rest"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func givenReport() *report.Report {
+	r := report.New()
+	r.Add("env", report.Record{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "b"},
+		Status:     report.Failed,
+		Duration:   1500 * time.Millisecond,
+		Error: report.NewError(fmt.Errorf("wrapped: %w", rest.RespError{
+			Reason:     "failed",
+			StatusCode: 400,
+			Body:       `{"error": "invalid"}`,
+			Request:    &rest.RequestInfo{Method: "PUT", URL: "https://env/api/config/v1/dashboards/1"},
+		})),
+	})
+	r.Add("env", report.Record{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "a"},
+		Status:     report.Deployed,
+		ObjectID:   "object-id",
+		Duration:   500 * time.Millisecond,
+		Mismatches: []report.Mismatch{
+			{Path: "tiles[0].name", Kind: report.Changed, Expected: "Tile", Actual: "tile"},
+			{Path: "shared", Kind: report.Dropped, Expected: true},
+		},
+	})
+	r.Add("env", report.Record{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "c"},
+		Status:     report.ParentFailed,
+		Reason:     "depends on p:dashboard:b which failed to deploy",
+	})
+	r.Add("env2", report.Record{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "a"},
+		Status:     report.Skipped,
+		Reason:     "config is skipped",
+	})
+	return r
+}
+
+func TestNewError(t *testing.T) {
+	e := report.NewError(fmt.Errorf("wrapped: %w", rest.RespError{Reason: "failed", StatusCode: 404, Body: "not found"}))
+	assert.Equal(t, &report.Error{Message: "wrapped: failed (HTTP 404): not found", StatusCode: 404, Body: "not found"}, e)
+
+	e = report.NewError(fmt.Errorf("some error"))
+	assert.Equal(t, &report.Error{Message: "some error"}, e)
+}
+
+func TestReport_Records(t *testing.T) {
+	r := givenReport()
+
+	assert.Equal(t, []string{"env", "env2"}, r.Environments())
+	records := r.Records("env")
+	assert.Len(t, records, 3)
+	assert.Equal(t, "a", records[0].Coordinate.ConfigId, "records must be sorted by coordinate")
+	assert.Equal(t, 1, r.Count("env", report.Failed))
+	assert.Equal(t, 0, r.Count("env", report.Skipped))
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	out := bytes.Buffer{}
+	assert.NoError(t, givenReport().WriteJSON(&out))
+	assert.JSONEq(t, `{
+  "environments": {
+    "env": {
+      "summary": {"deployed": 1, "failed": 1, "skipped-parent-failed": 1},
+      "configs": [
+        {
+          "project": "p", "type": "dashboard", "configId": "a", "status": "deployed", "objectId": "object-id", "durationMs": 500,
+          "mismatches": [
+            {"path": "tiles[0].name", "kind": "changed", "expected": "Tile", "actual": "tile"},
+            {"path": "shared", "kind": "dropped", "expected": true}
+          ]
+        },
+        {
+          "project": "p", "type": "dashboard", "configId": "b", "status": "failed", "durationMs": 1500,
+          "error": {"message": "wrapped: failed (HTTP 400): {\"error\": \"invalid\"}", "statusCode": 400, "body": "{\"error\": \"invalid\"}", "method": "PUT", "url": "https://env/api/config/v1/dashboards/1"}
+        },
+        {"project": "p", "type": "dashboard", "configId": "c", "status": "skipped-parent-failed", "durationMs": 0, "reason": "depends on p:dashboard:b which failed to deploy"}
+      ]
+    },
+    "env2": {
+      "summary": {"skipped": 1},
+      "configs": [
+        {"project": "p", "type": "dashboard", "configId": "a", "status": "skipped", "durationMs": 0, "reason": "config is skipped"}
+      ]
+    }
+  }
+}`, out.String())
+}
+
+func TestReport_WriteJUnit(t *testing.T) {
+	out := bytes.Buffer{}
+	assert.NoError(t, givenReport().WriteJUnit(&out))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="monaco deploy" tests="4" failures="2" skipped="1" time="2.000">
+  <testsuite name="env" tests="3" failures="2" skipped="0" time="2.000">
+    <testcase name="p:dashboard:a" classname="env" time="0.500">
+      <system-out>object ID: object-id&#xA;mismatch: tiles[0].name was changed (expected &#34;Tile&#34;, but got &#34;tile&#34;)&#xA;mismatch: shared was dropped (expected true)</system-out>
+    </testcase>
+    <testcase name="p:dashboard:b" classname="env" time="1.500">
+      <failure message="wrapped: failed (HTTP 400): {&#34;error&#34;: &#34;invalid&#34;}" type="failed">HTTP 400 (PUT https://env/api/config/v1/dashboards/1)&#xA;{&#34;error&#34;: &#34;invalid&#34;}</failure>
+    </testcase>
+    <testcase name="p:dashboard:c" classname="env" time="0.000">
+      <failure message="depends on p:dashboard:b which failed to deploy" type="skipped-parent-failed"></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="env2" tests="1" failures="0" skipped="1" time="0.000">
+    <testcase name="p:dashboard:a" classname="env2" time="0.000">
+      <skipped message="config is skipped"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, out.String())
+}
+
+func TestReport_Write(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	r := givenReport()
+
+	assert.NoError(t, r.Write(fs, "reports/report.json"))
+	assert.NoError(t, r.Write(fs, "reports/report.XML"))
+
+	j, err := afero.ReadFile(fs, "reports/report.json")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(j), "{"))
+
+	x, err := afero.ReadFile(fs, "reports/report.XML")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(x), "<?xml"))
+}
+
+func TestReport_DoesNotMaskSecrets(t *testing.T) {
+	secret.Register("report-secret-value")
+	r := report.New()
+	r.Add("env", report.Record{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "a"},
+		Status:     report.Deployed,
+		Mismatches: []report.Mismatch{{Path: "token", Kind: report.Changed, Expected: "report-secret-value", Actual: "other"}},
+	})
+
+	out := bytes.Buffer{}
+	assert.NoError(t, r.WriteJSON(&out))
+	assert.NoError(t, r.WriteJUnit(&out))
+	assert.Contains(t, out.String(), "report-secret-value", "reports are data, so only logs and console output are masked")
+}
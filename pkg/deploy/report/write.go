@@ -0,0 +1,204 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type jsonReport struct {
+	Environments map[string]jsonEnvironment `json:"environments"`
+}
+
+type jsonEnvironment struct {
+	Summary map[Status]int `json:"summary"`
+	Configs []jsonRecord   `json:"configs"`
+}
+
+type jsonRecord struct {
+	Project    string     `json:"project"`
+	Type       string     `json:"type"`
+	ConfigId   string     `json:"configId"`
+	Status     Status     `json:"status"`
+	ObjectID   string     `json:"objectId,omitempty"`
+	DurationMs int64      `json:"durationMs"`
+	Reason     string     `json:"reason,omitempty"`
+	Error      *Error     `json:"error,omitempty"`
+	Mismatches []Mismatch `json:"mismatches,omitempty"`
+}
+
+// WriteJSON writes the Report as JSON to w
+func (r *Report) WriteJSON(w io.Writer) error {
+	out := jsonReport{Environments: make(map[string]jsonEnvironment)}
+	for _, env := range r.Environments() {
+		e := jsonEnvironment{
+			Summary: make(map[Status]int),
+			Configs: make([]jsonRecord, 0),
+		}
+		for _, rec := range r.Records(env) {
+			e.Summary[rec.Status]++
+			e.Configs = append(e.Configs, jsonRecord{
+				Project:    rec.Coordinate.Project,
+				Type:       rec.Coordinate.Type,
+				ConfigId:   rec.Coordinate.ConfigId,
+				Status:     rec.Status,
+				ObjectID:   rec.ObjectID,
+				DurationMs: rec.Duration.Milliseconds(),
+				Reason:     rec.Reason,
+				Error:      rec.Error,
+				Mismatches: rec.Mismatches,
+			})
+		}
+		out.Environments[env] = e
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Details string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// WriteJUnit writes the Report as JUnit XML to w. Each environment is a test suite, and each config is a test case.
+// Configs that failed to deploy are failures, configs that were not deployed because they depend on a failed config are
+// reported as failures as well, and skipped and canceled configs are skipped test cases.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suites := junitTestSuites{Name: "monaco deploy"}
+	var total time.Duration
+
+	for _, env := range r.Environments() {
+		suite := junitTestSuite{Name: env}
+		var duration time.Duration
+
+		for _, rec := range r.Records(env) {
+			tc := junitTestCase{
+				Name:      rec.Coordinate.String(),
+				ClassName: env,
+				Time:      seconds(rec.Duration),
+			}
+			tc.SystemOut = systemOut(rec)
+
+			switch rec.Status {
+			case Failed:
+				tc.Failure = &junitFailure{Message: "deployment failed", Type: string(rec.Status)}
+				if rec.Error != nil {
+					tc.Failure.Message = rec.Error.Message
+					tc.Failure.Details = errorDetails(rec.Error)
+				}
+				suite.Failures++
+			case ParentFailed:
+				tc.Failure = &junitFailure{Message: rec.Reason, Type: string(rec.Status)}
+				suite.Failures++
+			case Skipped, Canceled:
+				tc.Skipped = &junitSkipped{Message: rec.Reason}
+				suite.Skipped++
+			}
+
+			suite.TestCases = append(suite.TestCases, tc)
+			duration += rec.Duration
+		}
+
+		suite.Tests = len(suite.TestCases)
+		suite.Time = seconds(duration)
+
+		suites.Suites = append(suites.Suites, suite)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		total += duration
+	}
+	suites.Time = seconds(total)
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, data)
+	return err
+}
+
+// systemOut returns the object ID of the record, followed by its mismatches
+func systemOut(rec Record) string {
+	var lines []string
+	if rec.ObjectID != "" {
+		lines = append(lines, fmt.Sprintf("object ID: %s", rec.ObjectID))
+	}
+	for _, m := range rec.Mismatches {
+		lines = append(lines, fmt.Sprintf("mismatch: %s", m))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func errorDetails(e *Error) string {
+	if e.StatusCode == 0 {
+		return e.Message
+	}
+
+	details := fmt.Sprintf("HTTP %d", e.StatusCode)
+	if e.Method != "" {
+		details += fmt.Sprintf(" (%s %s)", e.Method, e.URL)
+	}
+	return fmt.Sprintf("%s\n%s", details, e.Body)
+}
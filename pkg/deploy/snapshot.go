@@ -0,0 +1,84 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+)
+
+// capture adds the object the config is about to update to the deployment snapshot.
+// It returns whether such an object exists. If no snapshot is taken, it always returns true.
+func (d environmentDeployment) capture(ctx context.Context, c *config.Config, properties parameter.Properties, renderedConfig string) (bool, error) {
+	if d.opts.Snapshot == nil || d.opts.DryRun {
+		return true, nil
+	}
+
+	obj, found, err := d.lookup(ctx, c, properties, renderedConfig)
+	if err != nil || !found {
+		return false, err
+	}
+
+	o := snapshotObject(c, obj.ID)
+	o.Payload = obj.Payload
+
+	if t, isSetting := c.Type.(config.SettingsType); isSetting {
+		// the scope, schema version and external ID of the existing object are needed to restore it
+		existing, err := d.clients.Settings.GetSettingById(ctx, obj.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get settings object %q of schema %q: %w", obj.ID, t.SchemaId, err)
+		}
+		o.Scope, o.SchemaVersion, o.ExternalID = existing.Scope, existing.SchemaVersion, existing.ExternalId
+	}
+
+	log.WithCtxFields(ctx).Debug("Captured existing object %q in snapshot", obj.ID)
+	d.opts.Snapshot.Add(d.env.Name, o)
+	return true, nil
+}
+
+// captureCreated adds an object the deployment created to the deployment snapshot
+func (d environmentDeployment) captureCreated(c *config.Config, resolvedEntity entities.ResolvedEntity) {
+	if d.opts.Snapshot == nil || d.opts.DryRun || resolvedEntity.ObjectID == "" {
+		return
+	}
+
+	o := snapshotObject(c, resolvedEntity.ObjectID)
+	o.Created = true
+	d.opts.Snapshot.Add(d.env.Name, o)
+}
+
+func snapshotObject(c *config.Config, objectID string) snapshot.Object {
+	o := snapshot.Object{
+		Coordinate: c.Coordinate,
+		Kind:       c.Type.ID(),
+		ObjectID:   objectID,
+	}
+	switch t := c.Type.(type) {
+	case config.ClassicApiType:
+		o.API = t.Api
+	case config.SettingsType:
+		o.API = t.SchemaId
+	case config.AutomationType:
+		o.API = string(t.Resource)
+	}
+	return o
+}
@@ -0,0 +1,237 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	automationAPI "github.com/dynatrace/dynatrace-configuration-as-code-core/api/clients/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/buckets"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/automationutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	classicDownload "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
+	"net/http"
+	"strings"
+)
+
+// AutomationClient is the client used to restore automation objects
+type AutomationClient interface {
+	Upsert(ctx context.Context, resourceType automationAPI.ResourceType, id string, data []byte) (automation.Response, error)
+	Delete(ctx context.Context, resourceType automationAPI.ResourceType, id string) (automation.Response, error)
+}
+
+// BucketClient is the client used to restore Grail buckets
+type BucketClient interface {
+	Upsert(ctx context.Context, bucketName string, data []byte) (buckets.Response, error)
+	Delete(ctx context.Context, bucketName string) (buckets.Response, error)
+}
+
+// Clients are the clients used to restore the objects of a single environment
+type Clients struct {
+	Classic    dtclient.ConfigClient
+	Settings   dtclient.SettingsClient
+	Automation AutomationClient
+	Bucket     BucketClient
+}
+
+// Restore restores the given objects on an environment: objects a deployment updated are reset to their captured
+// payload, and objects a deployment created are deleted. Objects are restored in reverse order of their capture, so
+// that objects are restored before the objects they depend on.
+//
+// Restoring continues if an object can not be restored, and an error is returned after all objects were attempted.
+// The objects that were restored successfully are returned in either case.
+func Restore(ctx context.Context, clients Clients, apis api.APIs, objects []Object) ([]Object, error) {
+	var restored []Object
+	errCount := 0
+	for i := len(objects) - 1; i >= 0; i-- {
+		o := objects[i]
+		logger := log.WithCtxFields(ctx).WithFields(field.Coordinate(o.Coordinate))
+
+		var err error
+		if o.Created {
+			logger.Info("Deleting object %q created by the deployment of %s", o.ObjectID, o.Coordinate)
+			err = remove(ctx, clients, apis, o)
+		} else {
+			logger.Info("Restoring object %q updated by the deployment of %s", o.ObjectID, o.Coordinate)
+			err = restore(ctx, clients, apis, o)
+		}
+
+		if err != nil {
+			logger.WithFields(field.Error(err)).Error("Failed to restore object %q: %v", o.ObjectID, err)
+			errCount++
+			continue
+		}
+		restored = append(restored, o)
+	}
+
+	if errCount > 0 {
+		return restored, fmt.Errorf("failed to restore %d of %d objects", errCount, len(objects))
+	}
+	return restored, nil
+}
+
+func restore(ctx context.Context, clients Clients, apis api.APIs, o Object) error {
+	// secret values are masked when a snapshot is written, restoring the mask would overwrite them
+	if len(o.MaskedFields) > 0 {
+		return fmt.Errorf("the captured payload contained secret values at %s, which were masked - restore the object manually", strings.Join(o.MaskedFields, ", "))
+	}
+
+	switch o.Kind {
+	case config.ClassicApiTypeId:
+		a, found := apis[o.API]
+		if !found {
+			return fmt.Errorf("unknown api %q", o.API)
+		}
+
+		properties, err := unmarshal(o.Payload)
+		if err != nil {
+			return err
+		}
+		name := o.ObjectID
+		if n, ok := properties["name"].(string); ok {
+			name = n
+		}
+		payload, err := json.Marshal(classicDownload.RemoveServerPopulatedProperties(properties, o.API))
+		if err != nil {
+			return err
+		}
+
+		if a.SingleConfiguration {
+			_, err = clients.Classic.UpsertConfigByName(ctx, a, name, payload)
+			return err
+		}
+		// the object is updated by its ID, even if other objects with the same name exist
+		_, err = clients.Classic.UpsertConfigByNonUniqueNameAndId(ctx, a, o.ObjectID, name, payload, true)
+		return err
+
+	case config.SettingsTypeId:
+		// the object is replaced by its ID, and gets back the external ID it had before the deployment
+		_, err := clients.Settings.UpsertSettingsByObjectID(ctx, dtclient.SettingsObject{
+			SchemaId:       o.API,
+			SchemaVersion:  o.SchemaVersion,
+			Scope:          o.Scope,
+			Content:        o.Payload,
+			OriginObjectId: o.ObjectID,
+		}, o.ExternalID)
+		return err
+
+	case config.AutomationTypeId:
+		resourceType, err := automationutils.ClientResourceTypeFromConfigType(config.AutomationResource(o.API))
+		if err != nil {
+			return err
+		}
+		payload, err := withoutProperties(o.Payload, "id", "modificationInfo", "lastExecution")
+		if err != nil {
+			return err
+		}
+
+		resp, err := clients.Automation.Upsert(ctx, resourceType, o.ObjectID, payload)
+		if err != nil {
+			return err
+		}
+		if apiErr, isErr := resp.AsAPIError(); isErr {
+			return apiErr
+		}
+		return nil
+
+	case config.BucketTypeId:
+		payload, err := withoutProperties(o.Payload, "bucketName", "status", "version")
+		if err != nil {
+			return err
+		}
+
+		resp, err := clients.Bucket.Upsert(ctx, o.ObjectID, payload)
+		if err != nil {
+			return err
+		}
+		if apiErr, isErr := resp.AsAPIError(); isErr {
+			return apiErr
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown config-type (ID: %q)", o.Kind)
+	}
+}
+
+func remove(ctx context.Context, clients Clients, apis api.APIs, o Object) error {
+	switch o.Kind {
+	case config.ClassicApiTypeId:
+		a, found := apis[o.API]
+		if !found {
+			return fmt.Errorf("unknown api %q", o.API)
+		}
+		return clients.Classic.DeleteConfigById(ctx, a, o.ObjectID)
+
+	case config.SettingsTypeId:
+		return clients.Settings.DeleteSettings(ctx, o.ObjectID)
+
+	case config.AutomationTypeId:
+		resourceType, err := automationutils.ClientResourceTypeFromConfigType(config.AutomationResource(o.API))
+		if err != nil {
+			return err
+		}
+
+		resp, err := clients.Automation.Delete(ctx, resourceType, o.ObjectID)
+		if err != nil {
+			return err
+		}
+		if apiErr, isErr := resp.AsAPIError(); isErr && apiErr.StatusCode != http.StatusNotFound {
+			return apiErr
+		}
+		return nil
+
+	case config.BucketTypeId:
+		resp, err := clients.Bucket.Delete(ctx, o.ObjectID)
+		if err != nil {
+			return err
+		}
+		if apiErr, isErr := resp.AsAPIError(); isErr && apiErr.StatusCode != http.StatusNotFound {
+			return apiErr
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown config-type (ID: %q)", o.Kind)
+	}
+}
+
+func unmarshal(payload []byte) (map[string]any, error) {
+	var properties map[string]any
+	if err := json.Unmarshal(payload, &properties); err != nil {
+		return nil, fmt.Errorf("failed to parse captured payload: %w", err)
+	}
+	return properties, nil
+}
+
+// withoutProperties returns the payload without the given top-level properties
+func withoutProperties(payload []byte, keys ...string) ([]byte, error) {
+	properties, err := unmarshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		delete(properties, k)
+	}
+	return json.Marshal(properties)
+}
@@ -0,0 +1,301 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package snapshot records the objects a deployment changes on Dynatrace environments, so that they can be restored.
+// Before a deployment updates an object, its current payload is captured. Objects that a deployment creates are
+// recorded as well, so that restoring a snapshot deletes them again.
+//
+// A snapshot is persisted as a directory holding one JSON file per environment.
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/spf13/afero"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// currentVersion is the version of the snapshot file format written by this version of monaco
+const currentVersion = 1
+
+const fileExtension = ".json"
+
+// Object is the state of a single object before a deployment changed it
+type Object struct {
+	// Coordinate of the config that was deployed to the object
+	Coordinate coordinate.Coordinate
+	// Kind is the type of config that was deployed to the object
+	Kind config.TypeId
+	// API identifies where the object is stored: the classic API, Settings 2.0 schema, or automation resource.
+	// It is empty for Grail buckets.
+	API string
+	// ObjectID is the identifier of the object on the environment
+	ObjectID string
+	// Created states that the object did not exist before the deployment
+	Created bool
+	// Scope is the scope of a Settings 2.0 object
+	Scope string
+	// SchemaVersion is the schema version of a Settings 2.0 object
+	SchemaVersion string
+	// ExternalID is the external ID of a Settings 2.0 object before the deployment. It is empty if the object had none.
+	ExternalID string
+	// Payload is the JSON payload of the object before the deployment. It is empty for Created objects.
+	Payload []byte
+	// MaskedFields are the paths of the Payload values that contained secret values when the snapshot was written, e.g.
+	// "rules[0].token". The values were masked, so objects with masked fields can not be restored.
+	MaskedFields []string
+}
+
+// Snapshot holds the captured Objects per environment, in the order they were captured.
+// It is safe for concurrent use.
+type Snapshot struct {
+	lock    sync.RWMutex
+	objects map[string][]Object
+}
+
+// New returns an empty Snapshot
+func New() *Snapshot {
+	return &Snapshot{
+		objects: make(map[string][]Object),
+	}
+}
+
+// Add captures the Object for the given environment
+func (s *Snapshot) Add(environment string, o Object) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.objects[environment] = append(s.objects[environment], o)
+}
+
+// Objects returns all Objects captured for the given environment, in the order they were captured
+func (s *Snapshot) Objects(environment string) []Object {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return slices.Clone(s.objects[environment])
+}
+
+// Environments returns the names of all environments for which Objects were captured, sorted alphabetically
+func (s *Snapshot) Environments() []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	envs := make([]string, 0, len(s.objects))
+	for env, objects := range s.objects {
+		if len(objects) > 0 {
+			envs = append(envs, env)
+		}
+	}
+	slices.Sort(envs)
+	return envs
+}
+
+// IsEmpty returns true if no Object was captured for any environment
+func (s *Snapshot) IsEmpty() bool {
+	return len(s.Environments()) == 0
+}
+
+type snapshotFile struct {
+	Version     int                `json:"version"`
+	Environment string             `json:"environment"`
+	Objects     []snapshotFileItem `json:"objects"`
+}
+
+type snapshotFileItem struct {
+	Project       string          `json:"project"`
+	Type          string          `json:"type"`
+	ConfigId      string          `json:"configId"`
+	Kind          config.TypeId   `json:"kind"`
+	API           string          `json:"api,omitempty"`
+	ObjectID      string          `json:"objectId"`
+	Created       bool            `json:"created,omitempty"`
+	Scope         string          `json:"scope,omitempty"`
+	SchemaVersion string          `json:"schemaVersion,omitempty"`
+	ExternalID    string          `json:"externalId,omitempty"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	MaskedFields  []string        `json:"maskedFields,omitempty"`
+}
+
+// Load reads the Snapshot stored in the given directory
+func Load(fs afero.Fs, dir string) (*Snapshot, error) {
+	infos, err := afero.ReadDir(fs, dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("snapshot %q does not exist", dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %q: %w", dir, err)
+	}
+
+	s := New()
+	for _, info := range infos {
+		if info.IsDir() || filepath.Ext(info.Name()) != fileExtension {
+			continue
+		}
+
+		path := filepath.Join(dir, info.Name())
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot file %q: %w", path, err)
+		}
+
+		var f snapshotFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot file %q: %w", path, err)
+		}
+		if f.Version != currentVersion {
+			return nil, fmt.Errorf("snapshot file %q has unsupported version %d - expected version %d", path, f.Version, currentVersion)
+		}
+		if f.Environment == "" {
+			return nil, fmt.Errorf("snapshot file %q does not define an environment", path)
+		}
+
+		for _, i := range f.Objects {
+			s.Add(f.Environment, Object{
+				Coordinate:    coordinate.Coordinate{Project: i.Project, Type: i.Type, ConfigId: i.ConfigId},
+				Kind:          i.Kind,
+				API:           i.API,
+				ObjectID:      i.ObjectID,
+				Created:       i.Created,
+				Scope:         i.Scope,
+				SchemaVersion: i.SchemaVersion,
+				ExternalID:    i.ExternalID,
+				Payload:       i.Payload,
+				MaskedFields:  i.MaskedFields,
+			})
+		}
+	}
+
+	if s.IsEmpty() {
+		return nil, fmt.Errorf("snapshot %q does not contain any objects", dir)
+	}
+	return s, nil
+}
+
+// Write persists the Snapshot to the given directory, writing one file per environment.
+// Payload values containing registered secret values are masked, and recorded as MaskedFields of their object.
+func (s *Snapshot) Write(fs afero.Fs, dir string) error {
+	if err := fs.MkdirAll(dir, 0777); err != nil {
+		return fmt.Errorf("failed to create snapshot directory %q: %w", dir, err)
+	}
+
+	for _, env := range s.Environments() {
+		f := snapshotFile{
+			Version:     currentVersion,
+			Environment: env,
+		}
+		for _, o := range s.Objects(env) {
+			payload, masked, err := maskSecrets(o.Payload)
+			if err != nil {
+				return fmt.Errorf("failed to mask secret values of object %q: %w", o.ObjectID, err)
+			}
+
+			f.Objects = append(f.Objects, snapshotFileItem{
+				Project:       o.Coordinate.Project,
+				Type:          o.Coordinate.Type,
+				ConfigId:      o.Coordinate.ConfigId,
+				Kind:          o.Kind,
+				API:           o.API,
+				ObjectID:      o.ObjectID,
+				Created:       o.Created,
+				Scope:         o.Scope,
+				SchemaVersion: o.SchemaVersion,
+				ExternalID:    o.ExternalID,
+				Payload:       payload,
+				MaskedFields:  append(slices.Clone(o.MaskedFields), masked...),
+			})
+		}
+
+		data, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal snapshot of environment %q: %w", env, err)
+		}
+
+		path := filepath.Join(dir, fileName(env))
+		if err := afero.WriteFile(fs, path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write snapshot file %q: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// maskSecrets masks all string values of the JSON payload that contain registered secret values, and returns the
+// paths of these values. If no value contains a secret, the payload is returned as is.
+func maskSecrets(payload []byte) ([]byte, []string, error) {
+	if len(payload) == 0 {
+		return payload, nil, nil
+	}
+
+	var v any
+	d := json.NewDecoder(bytes.NewReader(payload))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, nil, err
+	}
+
+	var masked []string
+	v = maskValue("", v, &masked)
+	if len(masked) == 0 {
+		return payload, nil, nil
+	}
+	slices.Sort(masked)
+
+	data, err := json.Marshal(v)
+	return data, masked, err
+}
+
+func maskValue(path string, v any, masked *[]string) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, e := range val {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			val[k] = maskValue(p, e, masked)
+		}
+	case []any:
+		for i, e := range val {
+			val[i] = maskValue(fmt.Sprintf("%s[%d]", path, i), e, masked)
+		}
+	case string:
+		if m := secret.MaskValues(val); m != val {
+			*masked = append(*masked, path)
+			return m
+		}
+	}
+	return v
+}
+
+// fileName returns the name of the snapshot file of an environment. The environment name is stored in the file itself,
+// so characters that are not safe for file names are replaced.
+func fileName(environment string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, environment) + fileExtension
+}
@@ -0,0 +1,177 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+var (
+	settingObject = snapshot.Object{
+		Coordinate:    coordinate.Coordinate{Project: "p", Type: "builtin:alerting.profile", ConfigId: "setting"},
+		Kind:          config.SettingsTypeId,
+		API:           "builtin:alerting.profile",
+		ObjectID:      "setting-id",
+		Scope:         "environment",
+		SchemaVersion: "1.2.3",
+		ExternalID:    "original-external-id",
+		Payload:       []byte(`{"name":"old"}`),
+	}
+	dashboardObject = snapshot.Object{
+		Coordinate: coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "dashboard"},
+		Kind:       config.ClassicApiTypeId,
+		API:        "dashboard",
+		ObjectID:   "dashboard-id",
+		Created:    true,
+	}
+)
+
+func TestSnapshot_WriteAndLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	s := snapshot.New()
+	assert.True(t, s.IsEmpty())
+	s.Add("env", settingObject)
+	s.Add("env", dashboardObject)
+	s.Add("env/2", dashboardObject)
+	assert.False(t, s.IsEmpty())
+
+	assert.NoError(t, s.Write(fs, "snapshots/1"))
+
+	exists, err := afero.Exists(fs, "snapshots/1/env_2.json")
+	assert.NoError(t, err)
+	assert.True(t, exists, "environment names must be sanitized for file names")
+
+	loaded, err := snapshot.Load(fs, "snapshots/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"env", "env/2"}, loaded.Environments())
+	assert.Equal(t, []snapshot.Object{dashboardObject}, loaded.Objects("env/2"))
+
+	objects := loaded.Objects("env")
+	assert.Len(t, objects, 2)
+	assert.JSONEq(t, string(settingObject.Payload), string(objects[0].Payload))
+	objects[0].Payload = settingObject.Payload
+	assert.Equal(t, []snapshot.Object{settingObject, dashboardObject}, objects, "objects must keep the order they were captured in")
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := snapshot.Load(afero.NewMemMapFs(), "missing")
+		assert.ErrorContains(t, err, "does not exist")
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, fs.MkdirAll("empty", 0777))
+
+		_, err := snapshot.Load(fs, "empty")
+		assert.ErrorContains(t, err, "does not contain any objects")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, afero.WriteFile(fs, "snap/env.json", []byte(`{"version": 42, "environment": "env", "objects": []}`), 0644))
+
+		_, err := snapshot.Load(fs, "snap")
+		assert.ErrorContains(t, err, "unsupported version 42")
+	})
+}
+
+func TestRestore(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+
+	deleted := c.EXPECT().DeleteConfigById(gomock.Any(), gomock.Any(), "dashboard-id").Return(nil)
+	c.EXPECT().UpsertSettingsByObjectID(gomock.Any(), dtclient.SettingsObject{
+		SchemaId:       "builtin:alerting.profile",
+		SchemaVersion:  "1.2.3",
+		Scope:          "environment",
+		Content:        settingObject.Payload,
+		OriginObjectId: "setting-id",
+	}, "original-external-id").Return(dtclient.DynatraceEntity{}, nil).After(deleted)
+
+	restored, err := snapshot.Restore(context.TODO(), snapshot.Clients{Classic: c, Settings: c}, api.NewAPIs(), []snapshot.Object{settingObject, dashboardObject})
+	assert.NoError(t, err)
+	assert.Equal(t, []snapshot.Object{dashboardObject, settingObject}, restored)
+}
+
+func TestRestore_ContinuesOnError(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().DeleteConfigById(gomock.Any(), gomock.Any(), "dashboard-id").Return(errors.New("failed"))
+	c.EXPECT().UpsertSettingsByObjectID(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{}, nil)
+
+	restored, err := snapshot.Restore(context.TODO(), snapshot.Clients{Classic: c, Settings: c}, api.NewAPIs(), []snapshot.Object{settingObject, dashboardObject})
+	assert.ErrorContains(t, err, "failed to restore 1 of 2 objects")
+	assert.Equal(t, []snapshot.Object{settingObject}, restored, "only restored objects must be returned")
+}
+
+func TestSnapshot_WriteMasksSecrets(t *testing.T) {
+	secret.Register("snapshot-secret-value")
+	fs := afero.NewMemMapFs()
+
+	o := settingObject
+	o.Payload = []byte(`{"token":"snapshot-secret-value","rules":[{"header":"Bearer snapshot-secret-value","pattern":"****"}],"name":"old"}`)
+	s := snapshot.New()
+	s.Add("env", o)
+	assert.NoError(t, s.Write(fs, "snap"))
+
+	data, err := afero.ReadFile(fs, "snap/env.json")
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "snapshot-secret-value")
+
+	loaded, err := snapshot.Load(fs, "snap")
+	assert.NoError(t, err)
+	objects := loaded.Objects("env")
+	assert.Equal(t, []string{"rules[0].header", "token"}, objects[0].MaskedFields)
+	assert.JSONEq(t, `{"token":"****","rules":[{"header":"Bearer ****","pattern":"****"}],"name":"old"}`, string(objects[0].Payload))
+	assert.Empty(t, s.Objects("env")[0].MaskedFields, "captured objects must not be modified")
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	_, err = snapshot.Restore(context.TODO(), snapshot.Clients{Settings: c}, api.NewAPIs(), objects)
+	assert.ErrorContains(t, err, "failed to restore 1 of 1 objects", "masked payloads must not be restored")
+}
+
+func TestRestore_PayloadContainingMask(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	o := settingObject
+	o.Payload = []byte(`{"pattern":"****"}`)
+	s := snapshot.New()
+	s.Add("env", o)
+	assert.NoError(t, s.Write(fs, "snap"))
+
+	loaded, err := snapshot.Load(fs, "snap")
+	assert.NoError(t, err)
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().UpsertSettingsByObjectID(gomock.Any(), gomock.Any(), gomock.Any()).Return(dtclient.DynatraceEntity{}, nil)
+
+	_, err = snapshot.Restore(context.TODO(), snapshot.Clients{Settings: c}, api.NewAPIs(), loaded.Objects("env"))
+	assert.NoError(t, err, "payloads without masked secret values must be restored, even if they contain the mask")
+}
@@ -0,0 +1,223 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package state records which remote objects configurations were deployed to.
+// The state is persisted as a local JSON file, which allows deployments to find the objects of configs by their ID
+// instead of re-discovering them by name, external ID or origin object ID.
+package state
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/spf13/afero"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// currentVersion is the version of the state file format written by this version of monaco
+const currentVersion = 1
+
+// Entry records the remote object a config was deployed to
+type Entry struct {
+	// ObjectID is the identifier of the object on the environment
+	ObjectID string
+	// SchemaVersion is the schema version the object was deployed with. It is only set for Settings 2.0 objects.
+	SchemaVersion string
+	// PayloadHash is the hash of the rendered payload and parameter values the object was last deployed with, see ConfigHash
+	PayloadHash string
+}
+
+// State holds an Entry for each deployed config, per environment.
+// It is safe for concurrent use.
+type State struct {
+	lock    sync.RWMutex
+	entries map[string]map[coordinate.Coordinate]Entry
+	// hashKey is the random key of the payload hashes of this State, see ConfigHash
+	hashKey []byte
+}
+
+// New returns an empty State with a new random hash key
+func New() *State {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("failed to generate hash key of state: %v", err))
+	}
+
+	return &State{
+		entries: make(map[string]map[coordinate.Coordinate]Entry),
+		hashKey: key,
+	}
+}
+
+// Get returns the Entry of the config with the given coordinate on the given environment
+func (s *State) Get(environment string, c coordinate.Coordinate) (Entry, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	e, found := s.entries[environment][c]
+	return e, found
+}
+
+// Put records the Entry of the config with the given coordinate on the given environment
+func (s *State) Put(environment string, c coordinate.Coordinate, e Entry) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, exists := s.entries[environment]; !exists {
+		s.entries[environment] = make(map[coordinate.Coordinate]Entry)
+	}
+	s.entries[environment][c] = e
+}
+
+// Delete removes the Entry of the config with the given coordinate on the given environment
+func (s *State) Delete(environment string, c coordinate.Coordinate) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.entries[environment], c)
+}
+
+// Coordinates returns the coordinates of all configs recorded for the given environment
+func (s *State) Coordinates(environment string) []coordinate.Coordinate {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	coords := make([]coordinate.Coordinate, 0, len(s.entries[environment]))
+	for c := range s.entries[environment] {
+		coords = append(coords, c)
+	}
+	slices.SortFunc(coords, func(a, b coordinate.Coordinate) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return coords
+}
+
+// ConfigHash returns the hash of a rendered payload and the resolved parameter values it was rendered with, as recorded
+// in Entry.PayloadHash. The parameter values are part of the hash, as not all of them end up in the payload - e.g. the
+// scope of a Settings 2.0 object.
+//
+// The payload is hashed as is, so that rotating a secret value changes the hash. As the state is meant to be kept in
+// version control, the hash is an HMAC keyed with the random key of the State. Hashes can thus not be compared to
+// hashes of guessed payloads computed without the state file.
+func (s *State) ConfigHash(payload string, properties map[string]interface{}) string {
+	mac := hmac.New(sha256.New, s.hashKey)
+	// maps are printed sorted by key, so equal properties always result in the same hash
+	_, _ = fmt.Fprintf(mac, "%s\n%v", payload, properties)
+	return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type stateFile struct {
+	Version      int                        `json:"version"`
+	HashKey      string                     `json:"hashKey,omitempty"`
+	Environments map[string][]stateFileItem `json:"environments"`
+}
+
+type stateFileItem struct {
+	Project       string `json:"project"`
+	Type          string `json:"type"`
+	ConfigId      string `json:"configId"`
+	ObjectID      string `json:"objectId"`
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+	PayloadHash   string `json:"payloadHash,omitempty"`
+}
+
+// Load reads the State from the given file. If the file does not exist, an empty State is returned.
+func Load(fs afero.Fs, path string) (*State, error) {
+	data, err := afero.ReadFile(fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file %q: %w", path, err)
+	}
+
+	var f stateFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %q: %w", path, err)
+	}
+	if f.Version != currentVersion {
+		return nil, fmt.Errorf("state file %q has unsupported version %d - expected version %d", path, f.Version, currentVersion)
+	}
+
+	s := New()
+	// state files written before payloads were hashed with a key get a new key, so that their configs are deployed once
+	if f.HashKey != "" {
+		if s.hashKey, err = hex.DecodeString(f.HashKey); err != nil {
+			return nil, fmt.Errorf("failed to parse hash key of state file %q: %w", path, err)
+		}
+	}
+	for env, items := range f.Environments {
+		for _, i := range items {
+			s.Put(env, coordinate.Coordinate{Project: i.Project, Type: i.Type, ConfigId: i.ConfigId}, Entry{
+				ObjectID:      i.ObjectID,
+				SchemaVersion: i.SchemaVersion,
+				PayloadHash:   i.PayloadHash,
+			})
+		}
+	}
+	return s, nil
+}
+
+// Write persists the State to the given file. Environments and configs are written in a stable order, so that the file
+// can be kept in version control.
+func (s *State) Write(fs afero.Fs, path string) error {
+	s.lock.RLock()
+	f := stateFile{
+		Version:      currentVersion,
+		HashKey:      hex.EncodeToString(s.hashKey),
+		Environments: make(map[string][]stateFileItem, len(s.entries)),
+	}
+	for env, entries := range s.entries {
+		items := make([]stateFileItem, 0, len(entries))
+		for c, e := range entries {
+			items = append(items, stateFileItem{
+				Project:       c.Project,
+				Type:          c.Type,
+				ConfigId:      c.ConfigId,
+				ObjectID:      e.ObjectID,
+				SchemaVersion: e.SchemaVersion,
+				PayloadHash:   e.PayloadHash,
+			})
+		}
+		slices.SortFunc(items, func(a, b stateFileItem) int {
+			return strings.Compare(a.Project+":"+a.Type+":"+a.ConfigId, b.Project+":"+b.Type+":"+b.ConfigId)
+		})
+		f.Environments[env] = items
+	}
+	s.lock.RUnlock()
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return fmt.Errorf("failed to create directory for state file %q: %w", path, err)
+	}
+	if err := afero.WriteFile(fs, path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state file %q: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state_test
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/state"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var (
+	settingCoordinate   = coordinate.Coordinate{Project: "p", Type: "builtin:alerting.profile", ConfigId: "setting"}
+	dashboardCoordinate = coordinate.Coordinate{Project: "p", Type: "dashboard", ConfigId: "dashboard"}
+)
+
+func TestState_GetPutDelete(t *testing.T) {
+	s := state.New()
+
+	_, found := s.Get("env", settingCoordinate)
+	assert.False(t, found)
+
+	s.Put("env", settingCoordinate, state.Entry{ObjectID: "object-id"})
+	e, found := s.Get("env", settingCoordinate)
+	assert.True(t, found)
+	assert.Equal(t, "object-id", e.ObjectID)
+
+	_, found = s.Get("other-env", settingCoordinate)
+	assert.False(t, found, "entries must be kept per environment")
+
+	s.Delete("env", settingCoordinate)
+	_, found = s.Get("env", settingCoordinate)
+	assert.False(t, found)
+}
+
+func TestState_WriteAndLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	s := state.New()
+	s.Put("env", settingCoordinate, state.Entry{ObjectID: "object-id", SchemaVersion: "1.2.3", PayloadHash: "hmac-sha256:empty"})
+	s.Put("env", dashboardCoordinate, state.Entry{ObjectID: "dashboard-id", PayloadHash: "hmac-sha256:x"})
+	s.Put("env2", dashboardCoordinate, state.Entry{ObjectID: "other-dashboard-id"})
+
+	err := s.Write(fs, "state/monaco-state.json")
+	assert.NoError(t, err)
+
+	loaded, err := state.Load(fs, "state/monaco-state.json")
+	assert.NoError(t, err)
+	assert.Equal(t, []coordinate.Coordinate{settingCoordinate, dashboardCoordinate}, loaded.Coordinates("env"))
+
+	e, _ := loaded.Get("env", settingCoordinate)
+	assert.Equal(t, state.Entry{ObjectID: "object-id", SchemaVersion: "1.2.3", PayloadHash: "hmac-sha256:empty"}, e)
+
+	e, _ = loaded.Get("env2", dashboardCoordinate)
+	assert.Equal(t, state.Entry{ObjectID: "other-dashboard-id"}, e)
+}
+
+func TestState_WriteIsStable(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "state.json", []byte(`{"version": 1, "hashKey": "00ff", "environments": {}}`), 0644))
+
+	s, err := state.Load(fs, "state.json")
+	assert.NoError(t, err)
+	s.Put("env", dashboardCoordinate, state.Entry{ObjectID: "dashboard-id"})
+	s.Put("env", settingCoordinate, state.Entry{ObjectID: "object-id", SchemaVersion: "1.2.3"})
+
+	assert.NoError(t, s.Write(fs, "state.json"))
+
+	content, err := afero.ReadFile(fs, "state.json")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "version": 1,
+  "hashKey": "00ff",
+  "environments": {
+    "env": [
+      {"project": "p", "type": "builtin:alerting.profile", "configId": "setting", "objectId": "object-id", "schemaVersion": "1.2.3"},
+      {"project": "p", "type": "dashboard", "configId": "dashboard", "objectId": "dashboard-id"}
+    ]
+  }
+}`, string(content))
+}
+
+func TestLoad_MissingFileReturnsEmptyState(t *testing.T) {
+	s, err := state.Load(afero.NewMemMapFs(), "state.json")
+	assert.NoError(t, err)
+	assert.Empty(t, s.Coordinates("env"))
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"invalid JSON", `{`},
+		{"unsupported version", `{"version": 42, "environments": {}}`},
+		{"invalid hash key", `{"version": 1, "hashKey": "not hex", "environments": {}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			assert.NoError(t, afero.WriteFile(fs, "state.json", []byte(tt.content), 0644))
+
+			_, err := state.Load(fs, "state.json")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestState_ConfigHash(t *testing.T) {
+	s := state.New()
+	assert.Equal(t, s.ConfigHash(`{"name": "a"}`, map[string]interface{}{"name": "a"}), s.ConfigHash(`{"name": "a"}`, map[string]interface{}{"name": "a"}))
+	assert.NotEqual(t, s.ConfigHash(`{"name": "a"}`, map[string]interface{}{"name": "a"}), s.ConfigHash(`{"name": "b"}`, map[string]interface{}{"name": "b"}))
+	assert.NotEqual(t, s.ConfigHash(`{}`, map[string]interface{}{"scope": "a"}), s.ConfigHash(`{}`, map[string]interface{}{"scope": "b"}), "parameters must be part of the hash")
+
+	t.Run("rotated secret values change the hash", func(t *testing.T) {
+		secret.Register("hash-secret-1", "hash-secret-2")
+
+		assert.NotEqual(t,
+			s.ConfigHash(`{"token": "hash-secret-1"}`, map[string]interface{}{"token": "hash-secret-1"}),
+			s.ConfigHash(`{"token": "hash-secret-2"}`, map[string]interface{}{"token": "hash-secret-2"}))
+	})
+
+	t.Run("hashes are keyed per state", func(t *testing.T) {
+		assert.NotEqual(t, s.ConfigHash(`{}`, nil), state.New().ConfigHash(`{}`, nil))
+
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, s.Write(fs, "state.json"))
+		loaded, err := state.Load(fs, "state.json")
+		assert.NoError(t, err)
+		assert.Equal(t, s.ConfigHash(`{}`, nil), loaded.ConfigHash(`{}`, nil), "the key must be kept in the state file")
+	})
+}
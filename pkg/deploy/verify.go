@@ -0,0 +1,144 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/bucket"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/report"
+	"strings"
+)
+
+// Verification defines how deployed objects are verified
+type Verification string
+
+const (
+	// NoVerification disables the verification of deployed objects
+	NoVerification Verification = ""
+	// VerifyWarn logs a warning for each field of a deployed object that differs from the rendered config
+	VerifyWarn Verification = "warn"
+	// VerifyError fails the deployment of configs whose deployed object differs from the rendered config
+	VerifyError Verification = "error"
+)
+
+// verify reads the deployed object back from the environment and compares it to the rendered config.
+// It returns the fields of the rendered config that the API dropped or changed. Additional fields of the deployed object,
+// like server-populated properties and defaults, are ignored. Mismatches, and failures to read the deployed object, are
+// only returned as error if the Verification is VerifyError.
+func (d environmentDeployment) verify(ctx context.Context, c *config.Config, resolvedEntity entities.ResolvedEntity, renderedConfig string) ([]report.Mismatch, error) {
+	if d.opts.Verify == NoVerification || d.opts.DryRun {
+		return nil, nil
+	}
+
+	mismatches, err := d.compareDeployedObject(ctx, c, resolvedEntity.ObjectID, renderedConfig)
+	if err != nil {
+		if d.opts.Verify == VerifyError {
+			log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Failed to verify deployed object %q: %v", resolvedEntity.ObjectID, err)
+			return nil, fmt.Errorf("failed to verify deployed object %q: %w", resolvedEntity.ObjectID, err)
+		}
+		log.WithCtxFields(ctx).WithFields(field.Error(err)).Warn("Failed to verify deployed object %q: %v", resolvedEntity.ObjectID, err)
+		return nil, nil
+	}
+
+	if len(mismatches) == 0 {
+		log.WithCtxFields(ctx).Debug("Verified deployed object %q", resolvedEntity.ObjectID)
+		return nil, nil
+	}
+
+	descriptions := make([]string, len(mismatches))
+	for i, m := range mismatches {
+		descriptions[i] = m.String()
+	}
+
+	if d.opts.Verify == VerifyError {
+		err := fmt.Errorf("deployed object %q differs from the rendered config: %s", resolvedEntity.ObjectID, strings.Join(descriptions, "; "))
+		log.WithCtxFields(ctx).WithFields(field.Error(err), field.StatusDeploymentFailed()).Error("Verification failed: %v", err)
+		return mismatches, err
+	}
+
+	for _, desc := range descriptions {
+		log.WithCtxFields(ctx).Warn("Deployed object %q differs from the rendered config: %s", resolvedEntity.ObjectID, desc)
+	}
+	return mismatches, nil
+}
+
+// compareDeployedObject returns the fields of the rendered config that differ in the deployed object with the given ID
+func (d environmentDeployment) compareDeployedObject(ctx context.Context, c *config.Config, objectID string, renderedConfig string) ([]report.Mismatch, error) {
+	payload, err := d.readDeployedObject(ctx, c, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := jsonutils.Diff(payload, []byte(renderedConfig), jsonutils.DiffOptions{IgnoreUndefinedKeys: true})
+	if err != nil {
+		return nil, err
+	}
+
+	mismatches := make([]report.Mismatch, 0, len(diffs))
+	for _, diff := range diffs {
+		m := report.Mismatch{Path: diff.Path, Kind: report.Changed, Expected: diff.To, Actual: diff.From}
+		if diff.Kind == jsonutils.Added {
+			m.Kind = report.Dropped
+		}
+		mismatches = append(mismatches, m)
+	}
+	return mismatches, nil
+}
+
+// readDeployedObject returns the JSON payload of the deployed object with the given ID
+func (d environmentDeployment) readDeployedObject(ctx context.Context, c *config.Config, objectID string) ([]byte, error) {
+	switch t := c.Type.(type) {
+	case config.SettingsType:
+		obj, err := d.clients.Settings.GetSettingById(ctx, objectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get settings object %q of schema %q: %w", objectID, t.SchemaId, err)
+		}
+		return obj.Value, nil
+
+	case config.ClassicApiType:
+		a, found := api.NewAPIs()[t.Api]
+		if !found {
+			return nil, fmt.Errorf("unknown api `%s`. this is most likely a bug", t.Api)
+		}
+		return d.clients.Classic.ReadConfigById(ctx, a, objectID)
+
+	case config.AutomationType:
+		obj, found, err := automation.Lookup(ctx, d.clients.Automation, c)
+		if err == nil && !found {
+			err = fmt.Errorf("automation object %q does not exist", objectID)
+		}
+		return obj.Payload, err
+
+	case config.BucketType:
+		obj, found, err := bucket.Lookup(ctx, d.clients.Bucket, c)
+		if err == nil && !found {
+			err = fmt.Errorf("bucket %q does not exist", objectID)
+		}
+		return obj.Payload, err
+
+	default:
+		return nil, fmt.Errorf("unknown config-type (ID: %q)", c.Type.ID())
+	}
+}
@@ -57,7 +57,7 @@ func NewDownloader(client *client.Client) *Downloader {
 
 // Download downloads all automation resources for a given project
 // If automationTypes is given it will just download those types of automation resources
-func (d *Downloader) Download(projectName string, automationTypes ...config.AutomationType) (v2.ConfigsPerType, error) {
+func (d *Downloader) Download(ctx context.Context, projectName string, automationTypes ...config.AutomationType) (v2.ConfigsPerType, error) {
 	if len(automationTypes) == 0 {
 		automationTypes = maps.Keys(automationTypesToResources)
 	}
@@ -71,7 +71,7 @@ func (d *Downloader) Download(projectName string, automationTypes ...config.Auto
 			lg.Warn("No resource mapping for automation type %s found", at.Resource)
 			continue
 		}
-		response, err := d.client.List(context.TODO(), resource)
+		response, err := d.client.List(ctx, resource)
 		if err != nil {
 			lg.WithFields(field.Error(err)).Error("Failed to fetch all objects for automation resource %s: %v", at.Resource, err)
 			continue
@@ -179,6 +179,6 @@ func createTemplateFromRawJSON(obj automationutils.Response, configType, project
 	return t, extractedName
 }
 
-func (d NoopAutomationDownloader) Download(_ string, _ ...config.AutomationType) (v2.ConfigsPerType, error) {
+func (d NoopAutomationDownloader) Download(_ context.Context, _ string, _ ...config.AutomationType) (v2.ConfigsPerType, error) {
 	return nil, nil
 }
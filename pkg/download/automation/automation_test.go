@@ -19,6 +19,7 @@
 package automation
 
 import (
+	"context"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/automation"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/automationutils"
@@ -54,7 +55,7 @@ func TestDownloader_Download(t *testing.T) {
 		assert.NoError(t, err)
 		httpClient := automation.NewClient(rest.NewClient(serverURL, server.Client()))
 		downloader := NewDownloader(httpClient)
-		result, err := downloader.Download("projectName")
+		result, err := downloader.Download(context.TODO(), "projectName")
 		assert.Len(t, result, 3)
 		assert.Len(t, result[string(config.Workflow)], 3)
 		assert.Len(t, result[string(config.SchedulingRule)], 6)
@@ -83,7 +84,7 @@ func TestDownloader_Download(t *testing.T) {
 		assert.NoError(t, err)
 		httpClient := automation.NewClient(rest.NewClient(serverURL, server.Client()))
 		downloader := NewDownloader(httpClient)
-		result, err := downloader.Download("projectName",
+		result, err := downloader.Download(context.TODO(), "projectName",
 			config.AutomationType{Resource: config.Workflow}, config.AutomationType{Resource: config.BusinessCalendar})
 		assert.Len(t, result, 2)
 		assert.Len(t, result[string(config.Workflow)], 3)
@@ -108,7 +109,7 @@ func TestDownloader_Download(t *testing.T) {
 		httpClient := automation.NewClient(rest.NewClient(serverURL, server.Client()))
 
 		downloader := NewDownloader(httpClient)
-		result, err := downloader.Download("projectName", config.AutomationType{Resource: config.Workflow})
+		result, err := downloader.Download(context.TODO(), "projectName", config.AutomationType{Resource: config.Workflow})
 		assert.NoError(t, err)
 
 		assert.Len(t, result, 1)
@@ -143,7 +144,7 @@ func TestDownloader_Download_FailsToDownloadSpecificResource(t *testing.T) {
 	assert.NoError(t, err)
 	httpClient := automation.NewClient(rest.NewClient(serverURL, server.Client()))
 	downloader := NewDownloader(httpClient)
-	result, err := downloader.Download("projectName")
+	result, err := downloader.Download(context.TODO(), "projectName")
 	assert.Len(t, result, 2)
 	assert.Len(t, result[string(config.Workflow)], 3)
 	assert.Len(t, result[string(config.SchedulingRule)], 6)
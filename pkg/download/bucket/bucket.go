@@ -55,9 +55,9 @@ func NewDownloader(client BucketClient) *Downloader {
 		client: client,
 	}
 }
-func (d *Downloader) Download(projectName string, _ ...config.BucketType) (v2.ConfigsPerType, error) { // error in return is just to complain to interface
+func (d *Downloader) Download(ctx context.Context, projectName string, _ ...config.BucketType) (v2.ConfigsPerType, error) { // error in return is just to complain to interface
 	result := make(v2.ConfigsPerType)
-	response, err := d.client.List(context.TODO())
+	response, err := d.client.List(ctx)
 	if err != nil {
 		log.WithFields(field.Type("bucket"), field.Error(err)).Error("Failed to fetch all bucket definitions: %v", err)
 		return nil, nil
@@ -19,6 +19,7 @@
 package bucket
 
 import (
+	"context"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/buckets"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
@@ -51,7 +52,7 @@ func TestDownloader_Download(t *testing.T) {
 		assert.NoError(t, err)
 		bucketClient := buckets.NewClient(rest.NewClient(baseUrl, server.Client()))
 		downloader := NewDownloader(bucketClient)
-		result, err := downloader.Download("projectName")
+		result, err := downloader.Download(context.TODO(), "projectName")
 		assert.NoError(t, err)
 		assert.Len(t, result, 1)
 		assert.Len(t, result["bucket"], 2) // there should be 2 buckets (default bucket shall be skipped)
@@ -85,7 +86,7 @@ func TestDownloader_Download(t *testing.T) {
 		baseUrl, _ := url.Parse(server.URL)
 		bucketClient := buckets.NewClient(rest.NewClient(baseUrl, server.Client()))
 		downloader := NewDownloader(bucketClient)
-		result, err := downloader.Download("projectName")
+		result, err := downloader.Download(context.TODO(), "projectName")
 		assert.Len(t, result, 0)
 		assert.NoError(t, err)
 	})
@@ -105,7 +106,7 @@ func TestDownloader_Download(t *testing.T) {
 		baseUrl, _ := url.Parse(server.URL)
 		bucketClient := buckets.NewClient(rest.NewClient(baseUrl, server.Client()))
 		downloader := NewDownloader(bucketClient)
-		result, err := downloader.Download("projectName")
+		result, err := downloader.Download(context.TODO(), "projectName")
 		assert.Len(t, result, 0)
 		assert.NoError(t, err)
 	})
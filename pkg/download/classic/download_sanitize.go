@@ -42,11 +42,18 @@ var apiSanitizeFunctions = map[string]func(properties map[string]interface{}) ma
 }
 
 func sanitizeProperties(properties map[string]interface{}, apiId string) map[string]interface{} {
-	properties = removeIdentifyingProperties(properties)
-	properties = removePropertiesNotAllowedOnUpload(properties, apiId)
+	properties = RemoveServerPopulatedProperties(properties, apiId)
 	return replaceTemplateProperties(properties)
 }
 
+// RemoveServerPopulatedProperties removes the properties of a config of the given API that are populated by the
+// Dynatrace API, like IDs and metadata, as well as properties that are not allowed on upload.
+// The given properties are modified and returned.
+func RemoveServerPopulatedProperties(properties map[string]interface{}, apiId string) map[string]interface{} {
+	properties = removeIdentifyingProperties(properties)
+	return removePropertiesNotAllowedOnUpload(properties, apiId)
+}
+
 func removeIdentifyingProperties(dat map[string]interface{}) map[string]interface{} {
 	dat = removeByPath(dat, []string{"metadata"})
 	dat = removeByPath(dat, []string{"id"})
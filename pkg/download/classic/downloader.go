@@ -88,13 +88,25 @@ func WithFiltering(b bool) Option {
 	}
 }
 
-func (d *Downloader) Download(projectName string, _ ...config.ClassicApiType) (project.ConfigsPerType, error) {
-	log.Info("Downloading configuration APIs from %d endpoints", len(d.apisToDownload))
-	configs := d.downloadAPIs(d.apisToDownload, projectName)
+// Download downloads the configs of all APIs of the Downloader. If specific APIs are given, only the configs of those
+// of them that are APIs of the Downloader are downloaded.
+func (d *Downloader) Download(ctx context.Context, projectName string, specificAPIs ...config.ClassicApiType) (project.ConfigsPerType, error) {
+	apisToDownload := d.apisToDownload
+	if len(specificAPIs) > 0 {
+		apisToDownload = make(api.APIs, len(specificAPIs))
+		for _, t := range specificAPIs {
+			if a, found := d.apisToDownload[t.Api]; found {
+				apisToDownload[t.Api] = a
+			}
+		}
+	}
+
+	log.Info("Downloading configuration APIs from %d endpoints", len(apisToDownload))
+	configs := d.downloadAPIs(ctx, apisToDownload, projectName)
 	return configs, nil
 }
 
-func (d *Downloader) downloadAPIs(apisToDownload api.APIs, projectName string) project.ConfigsPerType {
+func (d *Downloader) downloadAPIs(ctx context.Context, apisToDownload api.APIs, projectName string) project.ConfigsPerType {
 	log.Debug("APIs to download: \n - %v", strings.Join(maps.Keys(apisToDownload), "\n - "))
 	results := make(project.ConfigsPerType, len(apisToDownload))
 	mutex := sync.Mutex{}
@@ -107,7 +119,7 @@ func (d *Downloader) downloadAPIs(apisToDownload api.APIs, projectName string) p
 		currentApi := currentApi // prevent data race
 		go func() {
 			defer wg.Done()
-			configsToDownload, err := d.findConfigsToDownload(currentApi)
+			configsToDownload, err := d.findConfigsToDownload(ctx, currentApi)
 			remoteCount := len(configsToDownload)
 
 			lg := log.WithFields(field.Type(currentApi.ID))
@@ -124,7 +136,7 @@ func (d *Downloader) downloadAPIs(apisToDownload api.APIs, projectName string) p
 			}
 
 			lg.Debug("Found %d configs of type %q to download", len(configsToDownload), currentApi.ID)
-			cfgs := d.downloadConfigsOfAPI(currentApi, configsToDownload, projectName)
+			cfgs := d.downloadConfigsOfAPI(ctx, currentApi, configsToDownload, projectName)
 
 			if len(cfgs) > 0 {
 				mutex.Lock()
@@ -153,7 +165,7 @@ func (d *Downloader) downloadAPIs(apisToDownload api.APIs, projectName string) p
 	return results
 }
 
-func (d *Downloader) downloadConfigsOfAPI(api api.API, values []dtclient.Value, projectName string) []config.Config {
+func (d *Downloader) downloadConfigsOfAPI(ctx context.Context, api api.API, values []dtclient.Value, projectName string) []config.Config {
 	results := make([]config.Config, 0, len(values))
 	mutex := sync.Mutex{}
 	wg := sync.WaitGroup{}
@@ -163,7 +175,7 @@ func (d *Downloader) downloadConfigsOfAPI(api api.API, values []dtclient.Value,
 		value := value
 		go func() {
 			defer wg.Done()
-			downloadedJson, err := d.downloadAndUnmarshalConfig(api, value)
+			downloadedJson, err := d.downloadAndUnmarshalConfig(ctx, api, value)
 			if api.TweakResponseFunc != nil {
 				api.TweakResponseFunc(downloadedJson)
 			}
@@ -194,8 +206,8 @@ func (d *Downloader) downloadConfigsOfAPI(api api.API, values []dtclient.Value,
 	return results
 }
 
-func (d *Downloader) downloadAndUnmarshalConfig(theApi api.API, value dtclient.Value) (map[string]interface{}, error) {
-	response, err := d.client.ReadConfigById(theApi, value.Id)
+func (d *Downloader) downloadAndUnmarshalConfig(ctx context.Context, theApi api.API, value dtclient.Value) (map[string]interface{}, error) {
+	response, err := d.client.ReadConfigById(ctx, theApi, value.Id)
 
 	if err != nil {
 		return nil, err
@@ -244,7 +256,7 @@ func (d *Downloader) createTemplate(mappedJson map[string]interface{}, value dtc
 	return templ, nil
 }
 
-func (d *Downloader) findConfigsToDownload(currentApi api.API) ([]dtclient.Value, error) {
+func (d *Downloader) findConfigsToDownload(ctx context.Context, currentApi api.API) ([]dtclient.Value, error) {
 	if currentApi.SingleConfiguration {
 		log.WithFields(field.Type(currentApi.ID)).Debug("\tFetching singleton-configuration '%v'", currentApi.ID)
 
@@ -253,7 +265,7 @@ func (d *Downloader) findConfigsToDownload(currentApi api.API) ([]dtclient.Value
 		return []dtclient.Value{singletonConfigToDownload}, nil
 	}
 	log.WithFields(field.Type(currentApi.ID)).Debug("\tFetching all '%v' configs", currentApi.ID)
-	return d.client.ListConfigs(context.TODO(), currentApi)
+	return d.client.ListConfigs(ctx, currentApi)
 }
 
 func (d *Downloader) shouldPersist(a api.API, json map[string]interface{}) bool {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -36,7 +37,7 @@ func TestDownloadConfigs_FailedToFindConfigsToDownload(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 0)
 }
@@ -51,7 +52,7 @@ func TestDownload_NoConfigsToDownloadFound(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 0)
 }
@@ -66,8 +67,8 @@ func TestDownload_ConfigsDownloaded(t *testing.T) {
 		}
 		return nil, nil
 	}).Times(2)
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
 
 	testAPI1 := api.API{ID: "API_ID_1", URLPath: "API_PATH_1", NonUniqueName: true}
 	testAPI2 := api.API{ID: "API_ID_2", URLPath: "API_PATH_2", NonUniqueName: false}
@@ -76,21 +77,37 @@ func TestDownload_ConfigsDownloaded(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 2)
 }
 
+func TestDownload_OnlySpecificAPIsAreDownloaded(t *testing.T) {
+	testAPI1 := api.API{ID: "API_ID_1", URLPath: "API_PATH_1"}
+	testAPI2 := api.API{ID: "API_ID_2", URLPath: "API_PATH_2"}
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListConfigs(gomock.Any(), testAPI2).Return([]dtclient.Value{{Id: "id", Name: "name"}}, nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), testAPI2, "id").Return([]byte("{}"), nil)
+
+	downloader := classic.NewDownloader(c, classic.WithAPIs(api.APIs{"API_ID_1": testAPI1, "API_ID_2": testAPI2}))
+
+	configurations, err := downloader.Download(context.TODO(), "project", config.ClassicApiType{Api: "API_ID_2"}, config.ClassicApiType{Api: "unknown"})
+	assert.NoError(t, err)
+	assert.Len(t, configurations, 1)
+	assert.Len(t, configurations["API_ID_2"], 1)
+}
+
 func TestDownload_SingleConfigurationAPI(t *testing.T) {
 	client := dtclient.NewMockClient(gomock.NewController(t))
-	client.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
+	client.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
 
 	testAPI1 := api.API{ID: "API_ID_1", URLPath: "API_PATH_1", SingleConfiguration: true, NonUniqueName: true}
 	apiMap := api.APIs{"API_ID_1": testAPI1}
 
 	downloader := classic.NewDownloader(client, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -105,7 +122,7 @@ func TestDownload_ErrorFetchingConfig(t *testing.T) {
 		}
 		return nil, nil
 	}).Times(2)
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).DoAndReturn(func(a api.API, id string) (json []byte, err error) {
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a api.API, id string) (json []byte, err error) {
 		if a.ID == "API_ID_1" {
 			return []byte("{}"), fmt.Errorf("NO")
 		}
@@ -118,7 +135,7 @@ func TestDownload_ErrorFetchingConfig(t *testing.T) {
 	apiMap := api.APIs{"API_ID_1": testAPI1, "API_ID_2": testAPI2}
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -133,8 +150,8 @@ func TestDownload_ConfigsDownloaded_WithEmptyFilter(t *testing.T) {
 		}
 		return nil, nil
 	}).Times(2)
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
 
 	testAPI1 := api.API{ID: "API_ID_1", URLPath: "API_PATH_1", NonUniqueName: true}
 	testAPI2 := api.API{ID: "API_ID_2", URLPath: "API_PATH_2", NonUniqueName: true}
@@ -143,7 +160,7 @@ func TestDownload_ConfigsDownloaded_WithEmptyFilter(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap), classic.WithAPIContentFilters(map[string]classic.ContentFilter{}))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 2)
 }
@@ -159,7 +176,7 @@ func TestDownload_SkipConfigThatShouldNotBePersisted(t *testing.T) {
 		}
 		return nil, nil
 	}).Times(2)
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("{}"), nil).Times(2)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("{}"), nil).Times(2)
 
 	apiFilters := map[string]classic.ContentFilter{"API_ID_1": {
 		ShouldConfigBePersisted: func(_ map[string]interface{}) bool {
@@ -173,7 +190,7 @@ func TestDownload_SkipConfigThatShouldNotBePersisted(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap), classic.WithAPIContentFilters(apiFilters))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -189,7 +206,7 @@ func TestDownload_SkipConfigBeforeDownload(t *testing.T) {
 		}
 		return nil, nil
 	}).AnyTimes()
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("{}"), nil).AnyTimes()
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("{}"), nil).AnyTimes()
 
 	apiFilters := map[string]classic.ContentFilter{
 		"API_ID_1": {
@@ -229,7 +246,7 @@ func TestDownload_SkipConfigBeforeDownload(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap), classic.WithAPIContentFilters(apiFilters), classic.WithFiltering(tt.withFiltering))
-			configurations, err := downloader.Download("project")
+			configurations, err := downloader.Download(context.TODO(), "project")
 			assert.NoError(t, err)
 			assert.Len(t, configurations, tt.wantDownloadedConfigs)
 		})
@@ -247,7 +264,7 @@ func TestDownload_FilteringCanBeTurnedOffViaFeatureFlags(t *testing.T) {
 		}
 		return nil, nil
 	}).Times(2)
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
 
 	apiFilters := map[string]classic.ContentFilter{"API_ID_1": {
 		ShouldBeSkippedPreDownload: func(_ dtclient.Value) bool {
@@ -261,7 +278,7 @@ func TestDownload_FilteringCanBeTurnedOffViaFeatureFlags(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap), classic.WithAPIContentFilters(apiFilters))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -276,7 +293,7 @@ func TestDownload_APIWithoutAnyConfigAvailableAreNotDownloaded(t *testing.T) {
 		}
 		return nil, nil
 	}).Times(2)
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
 
 	testAPI1 := api.API{ID: "API_ID_1", URLPath: "API_PATH_1", NonUniqueName: true}
 	testAPI2 := api.API{ID: "API_ID_2", URLPath: "API_PATH_2", NonUniqueName: false}
@@ -285,7 +302,7 @@ func TestDownload_APIWithoutAnyConfigAvailableAreNotDownloaded(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -300,8 +317,8 @@ func TestDownload_MalformedResponseFromAnAPI(t *testing.T) {
 		}
 		return nil, nil
 	}).Times(2)
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("-1"), nil)
-	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("-1"), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte("{}"), nil)
 
 	testAPI1 := api.API{ID: "API_ID_1", URLPath: "API_PATH_1", NonUniqueName: true}
 	testAPI2 := api.API{ID: "API_ID_2", URLPath: "API_PATH_2", NonUniqueName: false}
@@ -309,7 +326,7 @@ func TestDownload_MalformedResponseFromAnAPI(t *testing.T) {
 
 	downloader := classic.NewDownloader(c, classic.WithAPIs(apiMap))
 
-	configurations, err := downloader.Download("project")
+	configurations, err := downloader.Download(context.TODO(), "project")
 	assert.NoError(t, err)
 	assert.Len(t, configurations, 1)
 }
@@ -69,22 +69,22 @@ func NewDownloader(client dtclient.SettingsClient, opts ...func(*Downloader)) *D
 	return d
 }
 
-func (d *Downloader) Download(projectName string, schemaIDs ...config.SettingsType) (v2.ConfigsPerType, error) {
+func (d *Downloader) Download(ctx context.Context, projectName string, schemaIDs ...config.SettingsType) (v2.ConfigsPerType, error) {
 	if len(schemaIDs) == 0 {
-		return d.downloadAll(projectName)
+		return d.downloadAll(ctx, projectName)
 	}
 	var schemas []string
 	for _, s := range schemaIDs {
 		schemas = append(schemas, s.SchemaId)
 	}
-	return d.downloadSpecific(projectName, schemas)
+	return d.downloadSpecific(ctx, projectName, schemas)
 }
 
-func (d *Downloader) downloadAll(projectName string) (v2.ConfigsPerType, error) {
+func (d *Downloader) downloadAll(ctx context.Context, projectName string) (v2.ConfigsPerType, error) {
 	log.Debug("Fetching all schemas to download")
 
 	// get ALL schemas
-	schemas, err := d.client.ListSchemas()
+	schemas, err := d.client.ListSchemas(ctx)
 	if err != nil {
 		log.WithFields(field.Error(err)).Error("Failed to fetch all known schemas. Skipping settings download. Reason: %s", err)
 		return nil, err
@@ -95,22 +95,22 @@ func (d *Downloader) downloadAll(projectName string) (v2.ConfigsPerType, error)
 		ids = append(ids, i.SchemaId)
 	}
 
-	result := d.download(ids, projectName)
+	result := d.download(ctx, ids, projectName)
 	return result, nil
 }
 
-func (d *Downloader) downloadSpecific(projectName string, schemaIDs []string) (v2.ConfigsPerType, error) {
-	if ok, unknownSchemas := validateSpecificSchemas(d.client, schemaIDs); !ok {
+func (d *Downloader) downloadSpecific(ctx context.Context, projectName string, schemaIDs []string) (v2.ConfigsPerType, error) {
+	if ok, unknownSchemas := validateSpecificSchemas(ctx, d.client, schemaIDs); !ok {
 		err := fmt.Errorf("requested settings-schema(s) '%v' are not known", strings.Join(unknownSchemas, ","))
 		log.WithFields(field.F("unknownSchemas", unknownSchemas), field.Error(err)).Error("%v. Please consult the documentation for available schemas and verify they are available in your environment.", err)
 		return nil, err
 	}
 	log.Debug("Settings to download: \n - %v", strings.Join(schemaIDs, "\n - "))
-	result := d.download(schemaIDs, projectName)
+	result := d.download(ctx, schemaIDs, projectName)
 	return result, nil
 }
 
-func (d *Downloader) download(schemas []string, projectName string) v2.ConfigsPerType {
+func (d *Downloader) download(ctx context.Context, schemas []string, projectName string) v2.ConfigsPerType {
 	results := make(v2.ConfigsPerType, len(schemas))
 	downloadMutex := sync.Mutex{}
 	wg := sync.WaitGroup{}
@@ -122,7 +122,7 @@ func (d *Downloader) download(schemas []string, projectName string) v2.ConfigsPe
 			lg := log.WithFields(field.Type(s))
 
 			lg.Debug("Downloading all settings for schema %s", s)
-			objects, err := d.client.ListSettings(context.TODO(), s, dtclient.ListSettingsOptions{})
+			objects, err := d.client.ListSettings(ctx, s, dtclient.ListSettingsOptions{})
 			if err != nil {
 				var errMsg string
 				var respErr clientErrors.RespError
@@ -210,12 +210,12 @@ func shouldFilterUnmodifiableSettings() bool {
 	return shouldFilterSettings() && featureflags.DownloadFilterSettingsUnmodifiable().Enabled()
 }
 
-func validateSpecificSchemas(c dtclient.SettingsClient, schemas []string) (valid bool, unknownSchemas []string) {
+func validateSpecificSchemas(ctx context.Context, c dtclient.SettingsClient, schemas []string) (valid bool, unknownSchemas []string) {
 	if len(schemas) == 0 {
 		return true, nil
 	}
 
-	schemaList, err := c.ListSchemas()
+	schemaList, err := c.ListSchemas(ctx)
 	if err != nil {
 		log.WithFields(field.Error(err)).Error("failed to query available Settings Schemas: %v", err)
 		return false, schemas
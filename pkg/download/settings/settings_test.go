@@ -19,6 +19,7 @@
 package settings
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
@@ -267,10 +268,10 @@ func TestDownloadAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			c := dtclient.NewMockClient(gomock.NewController(t))
 			schemas, err := tt.mockValues.Schemas()
-			c.EXPECT().ListSchemas().Times(tt.mockValues.ListSchemasCalls).Return(schemas, err)
+			c.EXPECT().ListSchemas(gomock.Any()).Times(tt.mockValues.ListSchemasCalls).Return(schemas, err)
 			settings, err := tt.mockValues.Settings()
 			c.EXPECT().ListSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(tt.mockValues.ListSettingsCalls).Return(settings, err)
-			res, _ := NewDownloader(c, WithFilters(tt.filters)).Download("projectName")
+			res, _ := NewDownloader(c, WithFilters(tt.filters)).Download(context.TODO(), "projectName")
 			assert.Equal(t, tt.want, res)
 		})
 	}
@@ -349,9 +350,9 @@ func TestDownload(t *testing.T) {
 			c := dtclient.NewMockClient(gomock.NewController(t))
 			schemas, err1 := tt.mockValues.Schemas()
 			settings, err2 := tt.mockValues.Settings()
-			c.EXPECT().ListSchemas().Times(tt.mockValues.ListSchemasCalls).Return(schemas, err1)
+			c.EXPECT().ListSchemas(gomock.Any()).Times(tt.mockValues.ListSchemasCalls).Return(schemas, err1)
 			c.EXPECT().ListSettings(gomock.Any(), gomock.Any(), gomock.Any()).Times(tt.mockValues.ListSettingsCalls).Return(settings, err2)
-			res, _ := NewDownloader(c).Download("projectName", tt.Schemas...)
+			res, _ := NewDownloader(c).Download(context.TODO(), "projectName", tt.Schemas...)
 			assert.Equal(t, tt.want, res)
 		})
 	}
@@ -444,9 +445,9 @@ func Test_validateSpecificSettings(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := dtclient.NewMockClient(gomock.NewController(t))
-			c.EXPECT().ListSchemas().AnyTimes().Return(tt.given.settingsOnEnvironment, nil)
+			c.EXPECT().ListSchemas(gomock.Any()).AnyTimes().Return(tt.given.settingsOnEnvironment, nil)
 
-			gotValid, gotUnknownSchemas := validateSpecificSchemas(c, tt.given.specificSettingsRequested)
+			gotValid, gotUnknownSchemas := validateSpecificSchemas(context.TODO(), c, tt.given.specificSettingsRequested)
 			assert.Equalf(t, tt.wantValid, gotValid, "validateSpecificSchemas(%v) for available settings %v", tt.given.specificSettingsRequested, tt.given.specificSettingsRequested)
 			assert.Equalf(t, tt.wantUnknownSchemas, gotUnknownSchemas, "validateSpecificSchemas(%v) for available settings %v", tt.given.specificSettingsRequested, tt.given.specificSettingsRequested)
 		})
@@ -0,0 +1,371 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package drift detects differences between the configs defined in projects and the objects on Dynatrace environments,
+// e.g. because objects were changed manually after they were deployed.
+package drift
+
+import (
+	"context"
+	"fmt"
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/mutlierror"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"slices"
+	"strings"
+)
+
+// Status describes how an object on an environment drifted from its config
+type Status string
+
+const (
+	// Changed marks objects that differ from their config
+	Changed Status = "changed"
+	// Missing marks configs for which no object exists on the environment
+	Missing Status = "missing"
+)
+
+// Drift is a single config whose object drifted on an environment
+type Drift struct {
+	// Project, Type and ConfigId are the coordinate of the config
+	Project  string `json:"project"`
+	Type     string `json:"type"`
+	ConfigId string `json:"configId"`
+	// Status describes how the object drifted
+	Status Status `json:"status"`
+	// ObjectID is the identifier of the object on the environment, empty if the object is Missing
+	ObjectID string `json:"objectId,omitempty"`
+	// Differences of a Changed object. From is the value on the environment, To is the value defined by the config.
+	Differences []jsonutils.Difference `json:"differences,omitempty"`
+}
+
+// Report holds all drifts per environment, keyed by environment name. Environments without drift have no drifts.
+type Report map[string][]Drift
+
+// HasDrift returns true if any object drifted on any environment
+func (r Report) HasDrift() bool {
+	for _, drifts := range r {
+		if len(drifts) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Downloaders download the objects of a single environment that configs are compared with
+type Downloaders struct {
+	Classic    download.Downloader[config.ClassicApiType]
+	Settings   download.Downloader[config.SettingsType]
+	Automation download.Downloader[config.AutomationType]
+	Bucket     download.Downloader[config.BucketType]
+}
+
+// Environment holds what is needed to detect drift on a single environment
+type Environment struct {
+	// Clients are used to find the objects configs are deployed to, and to resolve parameters
+	Clients deploy.ClientSet
+	// Downloaders download the objects configs are compared with
+	Downloaders Downloaders
+}
+
+// Environments are the environments to detect drift on
+type Environments map[deploy.EnvironmentInfo]Environment
+
+// projectName is the name of the project objects are downloaded to. The name is not used, as downloaded objects are
+// only compared with configs.
+const projectName = "drift"
+
+// Detect compares the rendered configs of the given projects with the objects on the given environments.
+// All objects of the types of the configs are downloaded, and each config is compared with the downloaded object a
+// deployment would update. Only properties defined by a config are compared, and downloaded objects are sanitized like
+// for a download, so that properties populated by Dynatrace are not reported as drift. Like in a deployment, create-only
+// configs are not compared with their existing objects, and changes of fields whose changes are ignored are not drift.
+//
+// Skipped configs, and configs depending on skipped or missing configs, are not compared, as references to them can
+// not be resolved. If any config can not be compared, or ctx is canceled, an error is returned in addition to the Report.
+func Detect(ctx context.Context, projects []project.Project, environments Environments) (Report, error) {
+	names := make([]string, 0, len(environments))
+	for env := range environments {
+		names = append(names, env.Name)
+	}
+	g := graph.New(projects, names)
+
+	r := make(Report, len(environments))
+	errs := make(deployErrors.EnvironmentDeploymentErrors)
+	for env, e := range environments {
+		if ctx.Err() != nil {
+			return r, fmt.Errorf("drift detection was canceled: %w", context.Cause(ctx))
+		}
+
+		ctx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+		log.WithCtxFields(ctx).Info("Detecting drift on environment %q...", env.Name)
+
+		components, err := g.GetIndependentlySortedConfigs(env.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get independently sorted configs for environment %q: %w", env.Name, err)
+		}
+
+		remoteObjects, err := downloadObjects(ctx, e.Downloaders, components)
+		if err != nil {
+			errs = errs.Append(env.Name, err)
+			continue
+		}
+
+		d := detector{
+			clients:       e.Clients,
+			remoteObjects: remoteObjects,
+			resolvedEntities: deploy.EntityLookup{
+				EntityMap:             entities.New(),
+				MonitoredEntityFinder: deploy.NewMonitoredEntityFinder(ctx, e.Clients.Entities),
+				RemoteObjectFinder:    deploy.NewRemoteObjectFinder(ctx, e.Clients, false),
+				Ctx:                   ctx,
+			},
+		}
+
+		drifts := make([]Drift, 0)
+		for _, component := range components {
+			componentDrifts, componentErrs := d.detectComponent(ctx, component)
+			drifts = append(drifts, componentDrifts...)
+			if len(componentErrs) > 0 {
+				errs = errs.Append(env.Name, componentErrs...)
+			}
+		}
+
+		slices.SortFunc(drifts, func(a, b Drift) int {
+			return strings.Compare(a.Project+":"+a.Type+":"+a.ConfigId, b.Project+":"+b.Type+":"+b.ConfigId)
+		})
+		r[env.Name] = drifts
+	}
+
+	if ctx.Err() != nil {
+		return r, fmt.Errorf("drift detection was canceled: %w", context.Cause(ctx))
+	}
+	if len(errs) > 0 {
+		return r, errs
+	}
+	return r, nil
+}
+
+// remoteObjects holds downloaded objects keyed by the type of their config and their object ID
+type remoteObjects map[string]map[string]config.Config
+
+// downloadObjects downloads all objects of the types of the given configs
+func downloadObjects(ctx context.Context, downloaders Downloaders, components []graph.SortedComponent) (remoteObjects, error) {
+	var classicTypes []config.ClassicApiType
+	var settingsTypes []config.SettingsType
+	var automationTypes []config.AutomationType
+	var hasBuckets bool
+
+	seen := make(map[string]struct{})
+	for _, component := range components {
+		for _, node := range component.SortedNodes {
+			c := node.(graph.ConfigNode).Config
+			if _, found := seen[c.Coordinate.Type]; found || c.Skip {
+				continue
+			}
+			seen[c.Coordinate.Type] = struct{}{}
+
+			switch t := c.Type.(type) {
+			case config.ClassicApiType:
+				classicTypes = append(classicTypes, t)
+			case config.SettingsType:
+				settingsTypes = append(settingsTypes, t)
+			case config.AutomationType:
+				automationTypes = append(automationTypes, t)
+			case config.BucketType:
+				hasBuckets = true
+			}
+		}
+	}
+
+	objects := make(remoteObjects)
+	add := func(configsPerType project.ConfigsPerType, err error) error {
+		if err != nil {
+			return err
+		}
+		for _, configs := range configsPerType {
+			for _, c := range configs {
+				// classic configs are downloaded with their object ID as config ID
+				id := c.OriginObjectId
+				if id == "" {
+					id = c.Coordinate.ConfigId
+				}
+
+				if objects[c.Coordinate.Type] == nil {
+					objects[c.Coordinate.Type] = make(map[string]config.Config)
+				}
+				objects[c.Coordinate.Type][id] = c
+			}
+		}
+		return nil
+	}
+
+	if len(classicTypes) > 0 {
+		if err := add(downloaders.Classic.Download(ctx, projectName, classicTypes...)); err != nil {
+			return nil, fmt.Errorf("failed to download classic configs: %w", err)
+		}
+	}
+	if len(settingsTypes) > 0 {
+		if err := add(downloaders.Settings.Download(ctx, projectName, settingsTypes...)); err != nil {
+			return nil, fmt.Errorf("failed to download settings: %w", err)
+		}
+	}
+	if len(automationTypes) > 0 {
+		if err := add(downloaders.Automation.Download(ctx, projectName, automationTypes...)); err != nil {
+			return nil, fmt.Errorf("failed to download automations: %w", err)
+		}
+	}
+	if hasBuckets {
+		if err := add(downloaders.Bucket.Download(ctx, projectName)); err != nil {
+			return nil, fmt.Errorf("failed to download buckets: %w", err)
+		}
+	}
+	return objects, nil
+}
+
+// detector compares the configs of a single environment with their downloaded objects
+type detector struct {
+	clients          deploy.ClientSet
+	remoteObjects    remoteObjects
+	resolvedEntities deploy.EntityLookup
+}
+
+// detectComponent compares all configs of a component in their sorted order. Configs whose dependencies could not be
+// compared are not compared either, as references to their dependencies can not be resolved.
+func (d detector) detectComponent(ctx context.Context, component graph.SortedComponent) ([]Drift, []error) {
+	var drifts []Drift
+	var errs []error
+	notCompared := make(map[int64]struct{})
+
+	for _, node := range component.SortedNodes {
+		n := node.(graph.ConfigNode)
+		ctx := context.WithValue(ctx, log.CtxKeyCoord{}, n.Config.Coordinate)
+
+		if parent, found := notComparedParent(component, n, notCompared); found {
+			log.WithCtxFields(ctx).Warn("Not comparing %v, as it depends on %v which is skipped, missing or could not be compared", n.Config.Coordinate, parent.Config.Coordinate)
+			notCompared[n.ID()] = struct{}{}
+			continue
+		}
+
+		drift, compared, err := d.detectConfig(ctx, n.Config)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to detect drift of %s: %w", n.Config.Coordinate, err))
+		}
+		if drift != nil {
+			drifts = append(drifts, *drift)
+		}
+		if !compared {
+			notCompared[n.ID()] = struct{}{}
+		}
+	}
+	return drifts, errs
+}
+
+func notComparedParent(component graph.SortedComponent, n graph.ConfigNode, notCompared map[int64]struct{}) (graph.ConfigNode, bool) {
+	parents := component.Graph.To(n.ID())
+	for parents.Next() {
+		p := parents.Node().(graph.ConfigNode)
+		if _, found := notCompared[p.ID()]; found {
+			return p, true
+		}
+	}
+	return graph.ConfigNode{}, false
+}
+
+// detectConfig compares a single config with the downloaded object a deployment would update. The drift of the config
+// is returned, or nil if its object did not drift. The returned bool states whether the config was compared with an
+// existing object. If so, its entity is stored, so that configs depending on it can be compared as well.
+func (d detector) detectConfig(ctx context.Context, c *config.Config) (*Drift, bool, error) {
+	if c.Skip {
+		log.WithCtxFields(ctx).Debug("Not comparing %v, as it is skipped", c.Coordinate)
+		return nil, false, nil
+	}
+
+	properties, errs := c.ResolveParameterValues(d.resolvedEntities)
+	if len(errs) > 0 {
+		return nil, false, mutlierror.New(errs...)
+	}
+
+	renderedConfig, err := c.Render(properties)
+	if err != nil {
+		return nil, false, err
+	}
+
+	id, found, err := deploy.LookupObjectID(ctx, c, d.clients, properties, renderedConfig)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up existing object: %w", err)
+	}
+
+	obj, downloaded := d.remoteObjects[c.Coordinate.Type][id]
+	if !found || !downloaded {
+		return &Drift{Project: c.Coordinate.Project, Type: c.Coordinate.Type, ConfigId: c.Coordinate.ConfigId, Status: Missing}, false, nil
+	}
+
+	resolvedEntity, err := deploy.ExistingEntity(c, properties, id)
+	if err != nil {
+		return nil, false, err
+	}
+	d.resolvedEntities.Put(resolvedEntity)
+
+	if c.Lifecycle.CreateOnly {
+		log.WithCtxFields(ctx).Debug("Not comparing %v, as it is create-only and its object exists", c.Coordinate)
+		return nil, true, nil
+	}
+
+	remotePayload, err := render(obj)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to render downloaded object %q: %w", id, err)
+	}
+
+	// changes of ignored fields are not drift, as deployments keep them
+	renderedConfig, err = deploy.WithIgnoredChanges(c, renderedConfig, []byte(remotePayload))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to keep ignored changes of existing object %q: %w", id, err)
+	}
+
+	differences, err := jsonutils.Diff([]byte(remotePayload), []byte(renderedConfig), jsonutils.DiffOptions{IgnoreUndefinedKeys: true})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to compare config with existing object %q: %w", id, err)
+	}
+
+	if len(differences) == 0 {
+		return nil, true, nil
+	}
+	return &Drift{
+		Project:     c.Coordinate.Project,
+		Type:        c.Coordinate.Type,
+		ConfigId:    c.Coordinate.ConfigId,
+		Status:      Changed,
+		ObjectID:    id,
+		Differences: differences,
+	}, true, nil
+}
+
+// render renders the template of a downloaded object with its own parameters, e.g. the name of classic configs
+func render(downloaded config.Config) (string, error) {
+	properties, errs := downloaded.ResolveParameterValues(entities.New())
+	if len(errs) > 0 {
+		return "", mutlierror.New(errs...)
+	}
+	return downloaded.Render(properties)
+}
@@ -0,0 +1,243 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	jsonutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/classic"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/drift"
+	project "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func givenProjects() []project.Project {
+	newTag := func(id string, tmpl string, params config.Parameters) config.Config {
+		params[config.NameParameter] = &value.ValueParameter{Value: id}
+		return config.Config{
+			Type:        config.ClassicApiType{Api: "auto-tag"},
+			Template:    template.NewInMemoryTemplate(id, tmpl),
+			Coordinate:  coordinate.Coordinate{Project: "p", Type: "auto-tag", ConfigId: id},
+			Environment: "env",
+			Parameters:  params,
+		}
+	}
+
+	return []project.Project{
+		{
+			Id: "p",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"auto-tag": []config.Config{
+						newTag("changed", `{"name": "{{ .name }}", "enabled": true}`, config.Parameters{}),
+						newTag("unchanged", `{"name": "{{ .name }}", "parent": "{{ .parent }}"}`, config.Parameters{
+							"parent": reference.New("p", "auto-tag", "changed", config.IdParameter),
+						}),
+						newTag("missing", `{"name": "{{ .name }}"}`, config.Parameters{}),
+						newTag("depends-on-missing", `{"name": "{{ .name }}", "parent": "{{ .parent }}"}`, config.Parameters{
+							"parent": reference.New("p", "auto-tag", "missing", config.IdParameter),
+						}),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDetect(t *testing.T) {
+	autoTagAPI := api.NewAPIs()["auto-tag"]
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListConfigs(gomock.Any(), autoTagAPI).Return([]dtclient.Value{
+		{Id: "changed-id", Name: "changed"},
+		{Id: "unchanged-id", Name: "unchanged"},
+		{Id: "depends-on-missing-id", Name: "depends-on-missing"},
+	}, nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), autoTagAPI, "changed-id").Return([]byte(`{"id": "changed-id", "metadata": {}, "name": "changed", "enabled": false}`), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), autoTagAPI, "unchanged-id").Return([]byte(`{"id": "unchanged-id", "name": "unchanged", "parent": "changed-id", "rules": []}`), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), autoTagAPI, "depends-on-missing-id").Return([]byte(`{"name": "depends-on-missing", "parent": "other-id"}`), nil)
+	c.EXPECT().ConfigExistsByName(gomock.Any(), autoTagAPI, "changed").Return(true, "changed-id", nil)
+	c.EXPECT().ConfigExistsByName(gomock.Any(), autoTagAPI, "unchanged").Return(true, "unchanged-id", nil)
+	c.EXPECT().ConfigExistsByName(gomock.Any(), autoTagAPI, "missing").Return(false, "", nil)
+
+	environments := drift.Environments{
+		deploy.EnvironmentInfo{Name: "env"}: drift.Environment{
+			Clients:     deploy.ClientSet{Classic: c},
+			Downloaders: drift.Downloaders{Classic: classic.NewDownloader(c)},
+		},
+	}
+
+	r, err := drift.Detect(context.TODO(), givenProjects(), environments)
+	assert.NoError(t, err)
+	assert.True(t, r.HasDrift())
+	assert.Equal(t, drift.Report{
+		"env": {
+			{
+				Project:     "p",
+				Type:        "auto-tag",
+				ConfigId:    "changed",
+				Status:      drift.Changed,
+				ObjectID:    "changed-id",
+				Differences: []jsonutils.Difference{{Path: "enabled", Kind: jsonutils.Changed, From: false, To: true}},
+			},
+			{Project: "p", Type: "auto-tag", ConfigId: "missing", Status: drift.Missing},
+		},
+	}, r, "configs depending on missing configs must not be compared")
+}
+
+func TestDetect_Lifecycle(t *testing.T) {
+	autoTagAPI := api.NewAPIs()["auto-tag"]
+
+	newTag := func(id string, lifecycle config.Lifecycle) config.Config {
+		return config.Config{
+			Type:        config.ClassicApiType{Api: "auto-tag"},
+			Template:    template.NewInMemoryTemplate(id, `{"name": "{{ .name }}", "enabled": true, "description": "from monaco"}`),
+			Coordinate:  coordinate.Coordinate{Project: "p", Type: "auto-tag", ConfigId: id},
+			Environment: "env",
+			Parameters:  config.Parameters{config.NameParameter: &value.ValueParameter{Value: id}},
+			Lifecycle:   lifecycle,
+		}
+	}
+
+	c := dtclient.NewMockClient(gomock.NewController(t))
+	c.EXPECT().ListConfigs(gomock.Any(), autoTagAPI).Return([]dtclient.Value{
+		{Id: "create-only-id", Name: "create-only"},
+		{Id: "ignored-id", Name: "ignored"},
+	}, nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), autoTagAPI, "create-only-id").Return([]byte(`{"name": "create-only", "enabled": false}`), nil)
+	c.EXPECT().ReadConfigById(gomock.Any(), autoTagAPI, "ignored-id").Return([]byte(`{"name": "ignored", "enabled": false, "description": "edited by hand"}`), nil)
+	c.EXPECT().ConfigExistsByName(gomock.Any(), autoTagAPI, "create-only").Return(true, "create-only-id", nil)
+	c.EXPECT().ConfigExistsByName(gomock.Any(), autoTagAPI, "ignored").Return(true, "ignored-id", nil)
+
+	environments := drift.Environments{
+		deploy.EnvironmentInfo{Name: "env"}: drift.Environment{
+			Clients:     deploy.ClientSet{Classic: c},
+			Downloaders: drift.Downloaders{Classic: classic.NewDownloader(c)},
+		},
+	}
+	projects := []project.Project{{
+		Id: "p",
+		Configs: project.ConfigsPerTypePerEnvironments{"env": project.ConfigsPerType{"auto-tag": []config.Config{
+			newTag("create-only", config.Lifecycle{CreateOnly: true}),
+			newTag("ignored", config.Lifecycle{IgnoreChanges: []string{"enabled", "description"}}),
+		}}},
+	}}
+
+	r, err := drift.Detect(context.TODO(), projects, environments)
+	assert.NoError(t, err)
+	assert.False(t, r.HasDrift())
+}
+
+func TestDetect_Canceled(t *testing.T) {
+	c := dtclient.NewMockClient(gomock.NewController(t))
+
+	environments := drift.Environments{
+		deploy.EnvironmentInfo{Name: "env"}: drift.Environment{
+			Clients:     deploy.ClientSet{Classic: c},
+			Downloaders: drift.Downloaders{Classic: classic.NewDownloader(c)},
+		},
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("interrupted"))
+
+	_, err := drift.Detect(ctx, givenProjects(), environments)
+	assert.ErrorContains(t, err, "drift detection was canceled: interrupted")
+}
+
+func TestReport_HasDrift(t *testing.T) {
+	assert.False(t, drift.Report{"env": {}}.HasDrift())
+	assert.True(t, drift.Report{"env": {}, "env2": {{Status: drift.Missing}}}.HasDrift())
+}
+
+var report = drift.Report{
+	"env2": {},
+	"env1": {
+		{
+			Project:     "p",
+			Type:        "auto-tag",
+			ConfigId:    "changed",
+			Status:      drift.Changed,
+			ObjectID:    "changed-id",
+			Differences: []jsonutils.Difference{{Path: "enabled", Kind: jsonutils.Changed, From: false, To: true}},
+		},
+		{Project: "p", Type: "auto-tag", ConfigId: "missing", Status: drift.Missing},
+	},
+}
+
+func TestPrint(t *testing.T) {
+	out := bytes.Buffer{}
+	assert.NoError(t, drift.Print(&out, report))
+	assert.Equal(t, `Environment "env1":
+  changed p:auto-tag:changed (changed-id)
+        ~ enabled: false => true
+  missing p:auto-tag:missing
+Environment "env2": no drift
+
+Drift: 2 configs drifted
+`, out.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	out := bytes.Buffer{}
+	assert.NoError(t, drift.WriteJSON(&out, report))
+	assert.JSONEq(t, `{
+  "drift": true,
+  "environments": {
+    "env1": [
+      {
+        "project": "p", "type": "auto-tag", "configId": "changed", "status": "changed", "objectId": "changed-id",
+        "differences": [{"path": "enabled", "kind": "changed", "from": false, "to": true}]
+      },
+      {"project": "p", "type": "auto-tag", "configId": "missing", "status": "missing"}
+    ],
+    "env2": []
+  }
+}`, out.String())
+}
+
+func TestPrint_MasksSecrets(t *testing.T) {
+	secret.Register("drift-secret-value")
+	r := drift.Report{
+		"env": []drift.Drift{
+			{
+				Project: "p", Type: "auto-tag", ConfigId: "changed", Status: drift.Changed,
+				Differences: []jsonutils.Difference{{Path: "token", Kind: jsonutils.Changed, From: "old", To: "drift-secret-value"}},
+			},
+		},
+	}
+
+	out := bytes.Buffer{}
+	assert.NoError(t, drift.Print(&out, r))
+	assert.NoError(t, drift.WriteJSON(&out, r))
+	assert.NotContains(t, out.String(), "drift-secret-value")
+}
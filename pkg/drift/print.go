@@ -0,0 +1,85 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Print writes a human-readable representation of the Report to w.
+// Environments are printed in alphabetical order, each changed object is followed by its differences.
+// Registered secret values are masked.
+func Print(w io.Writer, r Report) error {
+	b := strings.Builder{}
+
+	total := 0
+	for _, env := range r.environments() {
+		if len(r[env]) == 0 {
+			b.WriteString(fmt.Sprintf("Environment %q: no drift\n", env))
+			continue
+		}
+
+		b.WriteString(fmt.Sprintf("Environment %q:\n", env))
+		for _, d := range r[env] {
+			b.WriteString(fmt.Sprintf("  %-7s %s:%s:%s", d.Status, d.Project, d.Type, d.ConfigId))
+			if d.ObjectID != "" {
+				b.WriteString(fmt.Sprintf(" (%s)", d.ObjectID))
+			}
+			b.WriteString("\n")
+
+			for _, diff := range d.Differences {
+				b.WriteString(fmt.Sprintf("        %s\n", diff))
+			}
+		}
+		total += len(r[env])
+	}
+
+	b.WriteString(fmt.Sprintf("\nDrift: %d configs drifted\n", total))
+
+	_, err := io.WriteString(secret.Writer{Writer: w}, b.String())
+	return err
+}
+
+type jsonReport struct {
+	Drift        bool               `json:"drift"`
+	Environments map[string][]Drift `json:"environments"`
+}
+
+// WriteJSON writes a machine-readable JSON representation of the Report to w. Registered secret values are masked.
+func WriteJSON(w io.Writer, r Report) error {
+	data, err := json.MarshalIndent(jsonReport{Drift: r.HasDrift(), Environments: r}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal drift report: %w", err)
+	}
+
+	_, err = secret.Writer{Writer: w}.Write(append(data, '\n'))
+	return err
+}
+
+func (r Report) environments() []string {
+	envs := make([]string, 0, len(r))
+	for env := range r {
+		envs = append(envs, env)
+	}
+	slices.Sort(envs)
+	return envs
+}
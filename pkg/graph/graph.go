@@ -189,6 +189,61 @@ func buildUndirectedGraph(d *simple.DirectedGraph) *simple.UndirectedGraph {
 	return u
 }
 
+// SelectConfigs returns the coordinates of all configs of the given environment that are selected, together with all
+// configs they depend on, directly or transitively. If withDependents is set, all configs depending on selected configs
+// are returned as well, together with all configs these dependents depend on.
+func (graphs ConfigGraphPerEnvironment) SelectConfigs(environment string, selected func(coordinate.Coordinate) bool, withDependents bool) map[coordinate.Coordinate]struct{} {
+	g, ok := graphs[environment]
+	if !ok {
+		return map[coordinate.Coordinate]struct{}{}
+	}
+
+	var selectedNodes []graph.Node
+	nodes := g.Nodes()
+	for nodes.Next() {
+		if selected(nodes.Node().(ConfigNode).Config.Coordinate) {
+			selectedNodes = append(selectedNodes, nodes.Node())
+		}
+	}
+
+	if withDependents {
+		// edges point from a config to the configs depending on it, so dependents are reached by following edges
+		selectedNodes = collectReachable(selectedNodes, g.From)
+	}
+	// dependencies are reached by following edges backwards
+	dependencies := collectReachable(selectedNodes, g.To)
+
+	result := make(map[coordinate.Coordinate]struct{}, len(dependencies))
+	for _, n := range dependencies {
+		result[n.(ConfigNode).Config.Coordinate] = struct{}{}
+	}
+	return result
+}
+
+// collectReachable returns the start nodes, and all nodes reachable from them using next
+func collectReachable(start []graph.Node, next func(id int64) graph.Nodes) []graph.Node {
+	var result []graph.Node
+	visited := make(map[int64]struct{})
+	toVisit := append([]graph.Node{}, start...)
+
+	for len(toVisit) > 0 {
+		n := toVisit[0]
+		toVisit = toVisit[1:]
+
+		if _, found := visited[n.ID()]; found {
+			continue
+		}
+		visited[n.ID()] = struct{}{}
+		result = append(result, n)
+
+		neighbours := next(n.ID())
+		for neighbours.Next() {
+			toVisit = append(toVisit, neighbours.Node())
+		}
+	}
+	return result
+}
+
 // New creates a new ConfigGraphPerEnvironment based on the given projects and environments.
 func New(projects []project.Project, environments []string) ConfigGraphPerEnvironment {
 	graphs := make(ConfigGraphPerEnvironment)
@@ -234,6 +234,52 @@ func TestGraphExport(t *testing.T) {
 	assert.Equal(t, string(dot), "strict digraph dev_dependency_graph {\n  // Node definitions.\n  \"project1:dashboard:sample dashboard\";\n  \"project1:dashboard:Random Dashboard\";\n  \"project2:auto-tag:tag\";\n\n  // Edge definitions.\n  \"project2:auto-tag:tag\" -> \"project1:dashboard:sample dashboard\";\n}")
 }
 
+func TestGraphExport_DependsOn(t *testing.T) {
+	zone := coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "zone"}
+	setting := coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "profile"}
+
+	projects := []project.Project{
+		{
+			Id: "project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"dev": {
+					"management-zone":          []config.Config{{Coordinate: zone, Environment: "dev"}},
+					"builtin:alerting.profile": []config.Config{{Coordinate: setting, Environment: "dev", DependsOn: []coordinate.Coordinate{zone}}},
+				},
+			},
+		},
+	}
+
+	dot, err := graph.New(projects, []string{"dev"}).EncodeToDOT("dev")
+	assert.NoError(t, err)
+	assert.Contains(t, string(dot), `"project:management-zone:zone" -> "project:builtin:alerting.profile:profile";`)
+}
+
+func TestGraphCycleErrors_DependsOn(t *testing.T) {
+	a := coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "a"}
+	b := coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "b"}
+
+	projects := []project.Project{
+		{
+			Id: "project",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"dev": {
+					"management-zone": []config.Config{
+						{Coordinate: a, Environment: "dev", DependsOn: []coordinate.Coordinate{b}},
+						{Coordinate: b, Environment: "dev", DependsOn: []coordinate.Coordinate{a}},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := graph.New(projects, []string{"dev"}).GetIndependentlySortedConfigs("dev")
+	var sortErrs graph.SortingErrors
+	assert.ErrorAs(t, err, &sortErrs)
+	assert.Len(t, sortErrs, 1)
+	assert.IsType(t, graph.CyclicDependencyError{}, sortErrs[0])
+}
+
 func TestGraphCycleErrors(t *testing.T) {
 	projectId := "project1"
 	referencedProjectId := "project2"
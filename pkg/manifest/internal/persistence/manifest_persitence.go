@@ -18,6 +18,7 @@ package persistence
 
 import (
 	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -102,12 +103,20 @@ type Environment struct {
 	URL  TypedValue `yaml:"url" json:"url" jsonschema:"required,oneof_type=string;object,description=The URL of the environment."`
 
 	Auth Auth `yaml:"auth,omitempty" json:"auth" jsonschema:"required,description=This defines all information required for authenticated access to the environment's API."`
+
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty" jsonschema:"description=Parameters available to all configs deployed to this environment via 'manifest' parameters. They override parameters of the same name defined for the group."`
+
+	Hooks *hook.Definitions `yaml:"hooks,omitempty" json:"hooks,omitempty" jsonschema:"description=Hooks run before and after configs are deployed to this environment. They run after the hooks defined for the group."`
 }
 
 // Group defines a group of Environment
 type Group struct {
 	Name         string        `yaml:"name" json:"name" jsonschema:"required,description=The name of the group - this can be freely defined and will be used in logs, etc."`
 	Environments []Environment `yaml:"environments" json:"environments" jsonschema:"required,minLength=1,description=The environments that are part of this group."`
+
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty" jsonschema:"description=Parameters available to all configs deployed to environments of this group via 'manifest' parameters."`
+
+	Hooks *hook.Definitions `yaml:"hooks,omitempty" json:"hooks,omitempty" jsonschema:"description=Hooks run before and after configs are deployed to each environment of this group."`
 }
 
 type Manifest struct {
@@ -118,6 +127,39 @@ type Manifest struct {
 	EnvironmentGroups []Group `yaml:"environmentGroups" json:"environmentGroups" jsonschema:"minLength=1,description=A list of environment groups that configs in the defined 'projects' will be deployed to. Required when deploying environment configurations."`
 	// Accounts is a list of accounts that account resources in Projects will be deployed to
 	Accounts []Account `yaml:"accounts,omitempty" json:"accounts" jsonschema:"minLength=1,description=A list of environment groups that configs in Projects will be deployed to. Required when deploying account resources."`
+	// Rollout defines the order in which EnvironmentGroups are deployed to
+	Rollout *Rollout `yaml:"rollout,omitempty" json:"rollout,omitempty" jsonschema:"description=Defines a staged rollout - environment groups are deployed stage by stage, and later stages are not deployed if the deployment of an earlier stage fails."`
+	// DeploymentEvents configures the events sent to environments after deploying to them
+	DeploymentEvents *DeploymentEvents `yaml:"deploymentEvents,omitempty" json:"deploymentEvents,omitempty" jsonschema:"description=Configures the Dynatrace events sent to each environment after configurations were deployed to it."`
+	// Notifications are the webhooks a summary of each deployment and deletion is sent to
+	Notifications []Notification `yaml:"notifications,omitempty" json:"notifications,omitempty" jsonschema:"description=Webhooks a summary of each deployment and deletion is sent to."`
+}
+
+// Notification defines a webhook a summary of each deployment and deletion is sent to
+type Notification struct {
+	URL      TypedValue                  `yaml:"url" json:"url" jsonschema:"required,description=The URL of the webhook. As it usually contains a secret, it should be loaded from an environment variable."`
+	Format   string                      `yaml:"format,omitempty" json:"format,omitempty" jsonschema:"enum=slack,enum=teams,enum=generic,description=The format of the payload - a 'slack' or 'teams' message, or a 'generic' JSON summary. Defaults to 'generic'."`
+	Template string                      `yaml:"template,omitempty" json:"template,omitempty" jsonschema:"description=A Go template rendered with the summary. For 'slack' and 'teams' it replaces the text of the message, for 'generic' it replaces the whole payload."`
+	Headers  map[string]hook.HeaderValue `yaml:"headers,omitempty" json:"headers,omitempty" jsonschema:"description=HTTP headers sent with the notification. As they usually contain credentials, their values can be loaded from environment variables."`
+}
+
+// DeploymentEvents configures the events sent to environments after deploying to them
+type DeploymentEvents struct {
+	Enabled        bool   `yaml:"enabled" json:"enabled" jsonschema:"description=If true, a CUSTOM_CONFIGURATION event listing the changed configurations is sent to each environment after deploying to it."`
+	EntitySelector string `yaml:"entitySelector,omitempty" json:"entitySelector,omitempty" jsonschema:"description=Selects the entities the event is attached to. If it is not set, the event is not attached to any entity."`
+}
+
+// Rollout defines a staged rollout of a deployment
+type Rollout struct {
+	Stages []Stage `yaml:"stages" json:"stages" jsonschema:"required,minLength=1,description=The stages of the rollout, in the order they are deployed. Every environment group must be part of exactly one stage."`
+}
+
+// Stage is a step of a Rollout
+type Stage struct {
+	Name    string   `yaml:"name" json:"name" jsonschema:"required,description=The name of the stage - this can be freely defined and will be used in logs, etc."`
+	Groups  []string `yaml:"groups" json:"groups" jsonschema:"required,minLength=1,description=The names of the environment groups deployed in this stage."`
+	Wait    string   `yaml:"wait,omitempty" json:"wait,omitempty" jsonschema:"description=How long to wait after the previous stage was deployed, before this stage is deployed, e.g. '10m' or '1h30m'."`
+	Confirm bool     `yaml:"confirm,omitempty" json:"confirm,omitempty" jsonschema:"description=If true, the stage is only deployed after the rollout was confirmed interactively."`
 }
 
 type Account struct {
@@ -24,8 +24,10 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 	version2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/version"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/notification"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v2"
@@ -190,6 +192,18 @@ func Load(context *Context) (manifest.Manifest, []error) {
 		}
 	}
 
+	// rollout
+	stages, rolloutErrors := parseRollout(context, manifestYAML.Rollout, manifestYAML.EnvironmentGroups)
+	if rolloutErrors != nil {
+		errs = append(errs, rolloutErrors...)
+	}
+
+	// notifications
+	notifications, notificationErrors := parseNotifications(context, manifestYAML.Notifications)
+	if notificationErrors != nil {
+		errs = append(errs, notificationErrors...)
+	}
+
 	// accounts
 	accounts, accErr := parseAccounts(context, manifestYAML.Accounts)
 	if accErr != nil {
@@ -205,9 +219,51 @@ func Load(context *Context) (manifest.Manifest, []error) {
 		Projects:     projectDefinitions,
 		Environments: environmentDefinitions,
 		Accounts:     accounts,
+		Stages:       stages,
+
+		DeploymentEvents: parseDeploymentEvents(manifestYAML.DeploymentEvents),
+		Notifications:    notifications,
 	}, nil
 }
 
+// parseNotifications parses the notification webhooks. The URLs of webhooks loaded from environment variables are
+// registered as secrets, as webhook URLs usually contain credentials. Header values loaded from environment variables are
+// only resolved when the notification is sent.
+func parseNotifications(context *Context, definitions []persistence.Notification) ([]manifest.Notification, []error) {
+	var errs []error
+	var notifications []manifest.Notification
+	for i, d := range definitions {
+		urlDef, err := parseURLDefinition(context, d.URL)
+		if err != nil {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("invalid notification %d: %v", i+1, err)))
+			continue
+		}
+		if urlDef.Type == manifest.EnvironmentURLType && !context.Opts.DoNotResolveEnvVars {
+			secret.Register(urlDef.Value)
+		}
+
+		if err := hook.ValidateHeaders(d.Headers); err != nil {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("invalid notification %d: %v", i+1, err)))
+			continue
+		}
+
+		if _, err := notification.NewWebhook(urlDef.Value, d.Format, d.Template, d.Headers); err != nil {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("invalid notification %d: %v", i+1, err)))
+			continue
+		}
+
+		notifications = append(notifications, manifest.Notification{URL: urlDef, Format: d.Format, Template: d.Template, Headers: d.Headers})
+	}
+	return notifications, errs
+}
+
+func parseDeploymentEvents(e *persistence.DeploymentEvents) manifest.DeploymentEvents {
+	if e == nil {
+		return manifest.DeploymentEvents{}
+	}
+	return manifest.DeploymentEvents{Enabled: e.Enabled, EntitySelector: e.EntitySelector}
+}
+
 func parseAuth(context *Context, a persistence.Auth) (manifest.Auth, error) {
 	token, err := parseAuthSecret(context, a.Token)
 	if err != nil {
@@ -387,7 +443,7 @@ func parseEnvironments(context *Context, groups []persistence.Group) (map[string
 				continue
 			}
 
-			parsedEnv, configErrors := parseSingleEnvironment(context, env, group.Name)
+			parsedEnv, configErrors := parseSingleEnvironment(context, env, group)
 
 			if configErrors != nil {
 				errors = append(errors, configErrors...)
@@ -435,8 +491,9 @@ func shouldSkipEnv(context *Context, group persistence.Group, env persistence.En
 	return true
 }
 
-func parseSingleEnvironment(context *Context, config persistence.Environment, group string) (manifest.EnvironmentDefinition, []error) {
+func parseSingleEnvironment(context *Context, config persistence.Environment, g persistence.Group) (manifest.EnvironmentDefinition, []error) {
 	var errs []error
+	group := g.Name
 
 	a, err := parseAuth(context, config.Auth)
 	if err != nil {
@@ -448,18 +505,59 @@ func parseSingleEnvironment(context *Context, config persistence.Environment, gr
 		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, err.Error()))
 	}
 
+	groupHooks, err := parseHooks(g.Hooks)
+	if err != nil {
+		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, fmt.Sprintf("invalid hooks of group: %s", err)))
+	}
+
+	envHooks, err := parseHooks(config.Hooks)
+	if err != nil {
+		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, fmt.Sprintf("invalid hooks: %s", err)))
+	}
+
 	if len(errs) > 0 {
 		return manifest.EnvironmentDefinition{}, errs
 	}
 
 	return manifest.EnvironmentDefinition{
-		Name:  config.Name,
-		URL:   urlDef,
-		Auth:  a,
-		Group: group,
+		Name:            config.Name,
+		URL:             urlDef,
+		Auth:            a,
+		Group:           group,
+		Parameters:      mergeParameters(g.Parameters, config.Parameters),
+		GroupParameters: g.Parameters,
+		GroupHooks:      groupHooks,
+		Hooks: hook.Hooks{
+			PreDeploy:  append(groupHooks.PreDeploy, envHooks.PreDeploy...),
+			PostDeploy: append(groupHooks.PostDeploy, envHooks.PostDeploy...),
+		},
 	}, nil
 }
 
+func parseHooks(d *hook.Definitions) (hook.Hooks, error) {
+	if d == nil {
+		return hook.Hooks{}, nil
+	}
+	return hook.Parse(*d)
+}
+
+// mergeParameters returns the group parameters, overridden by the environment parameters of the same name. If neither
+// defines parameters, nil is returned.
+func mergeParameters(groupParameters map[string]interface{}, envParameters map[string]interface{}) map[string]interface{} {
+	if len(groupParameters) == 0 && len(envParameters) == 0 {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(groupParameters)+len(envParameters))
+	for k, v := range groupParameters {
+		result[k] = v
+	}
+	for k, v := range envParameters {
+		result[k] = v
+	}
+	return result
+}
+
 func parseURLDefinition(context *Context, u persistence.TypedValue) (manifest.URLDefinition, error) {
 
 	// Depending on the type, the url.value either contains the env var name or the direct value of the url
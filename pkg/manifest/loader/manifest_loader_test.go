@@ -21,6 +21,7 @@ package loader
 import (
 	"fmt"
 	monacoVersion "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/version"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
@@ -31,6 +32,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_extractUrlType(t *testing.T) {
@@ -1784,3 +1786,210 @@ projects: [{name: a, path: p}]
 		})
 	}
 }
+
+func TestLoadManifest_EnvironmentParameters(t *testing.T) {
+	t.Setenv("e", "mock token")
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+  - name: g
+    parameters: {team: group-team, prefix: monaco}
+    environments:
+      - {name: e1, url: {value: d}, auth: {token: {name: e}}, parameters: {team: env-team, tags: [a, b]}}
+      - {name: e2, url: {value: d}, auth: {token: {name: e}}}
+  - name: g2
+    environments:
+      - {name: e3, url: {value: d}, auth: {token: {name: e}}}
+`), 0400))
+
+	mani, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml"})
+	assert.Empty(t, errs)
+
+	assert.Equal(t, map[string]interface{}{"team": "env-team", "prefix": "monaco", "tags": []interface{}{"a", "b"}}, mani.Environments["e1"].Parameters, "environment parameters must override group parameters")
+	assert.Equal(t, map[string]interface{}{"team": "group-team", "prefix": "monaco"}, mani.Environments["e2"].Parameters)
+	assert.Nil(t, mani.Environments["e3"].Parameters)
+	assert.Equal(t, map[string]interface{}{"team": "group-team", "prefix": "monaco"}, mani.Environments["e1"].GroupParameters)
+}
+
+func TestLoadManifest_EnvironmentHooks(t *testing.T) {
+	t.Setenv("e", "mock token")
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+  - name: g
+    hooks:
+      preDeploy: [{command: [./maintenance.sh, start]}]
+      postDeploy: [{command: [./maintenance.sh, stop]}]
+    environments:
+      - name: e1
+        url: {value: d}
+        auth: {token: {name: e}}
+        hooks:
+          postDeploy: [{url: "https://example.com/smoke-test", timeout: 1m}]
+      - {name: e2, url: {value: d}, auth: {token: {name: e}}}
+  - name: g2
+    environments:
+      - {name: e3, url: {value: d}, auth: {token: {name: e}}, hooks: {preDeploy: [{url: invalid}]}}
+`), 0400))
+
+	mani, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Groups: []string{"g"}})
+	assert.Empty(t, errs)
+
+	assert.Equal(t, hook.Hooks{
+		PreDeploy:  []hook.Hook{{Command: []string{"./maintenance.sh", "start"}}},
+		PostDeploy: []hook.Hook{{Command: []string{"./maintenance.sh", "stop"}}, {URL: "https://example.com/smoke-test", Timeout: time.Minute}},
+	}, mani.Environments["e1"].Hooks, "hooks of the group must run before hooks of the environment")
+	assert.Equal(t, hook.Hooks{
+		PreDeploy:  []hook.Hook{{Command: []string{"./maintenance.sh", "start"}}},
+		PostDeploy: []hook.Hook{{Command: []string{"./maintenance.sh", "stop"}}},
+	}, mani.Environments["e2"].Hooks)
+
+	_, errs = Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Groups: []string{"g2"}})
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], `"invalid" is not a valid HTTP(S) URL`)
+}
+
+func TestLoadManifest_DeploymentEvents(t *testing.T) {
+	t.Setenv("e", "mock token")
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: g, environments: [{name: e1, url: {value: d}, auth: {token: {name: e}}}]}]
+deploymentEvents:
+  enabled: true
+  entitySelector: type(HOST),tag(monaco)
+`), 0400))
+	assert.NoError(t, afero.WriteFile(fs, "no-events.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: g, environments: [{name: e1, url: {value: d}, auth: {token: {name: e}}}]}]
+`), 0400))
+
+	mani, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml"})
+	assert.Empty(t, errs)
+	assert.Equal(t, manifest.DeploymentEvents{Enabled: true, EntitySelector: "type(HOST),tag(monaco)"}, mani.DeploymentEvents)
+
+	mani, errs = Load(&Context{Fs: fs, ManifestPath: "no-events.yaml"})
+	assert.Empty(t, errs)
+	assert.Equal(t, manifest.DeploymentEvents{}, mani.DeploymentEvents)
+}
+
+func TestLoadManifest_Notifications(t *testing.T) {
+	t.Setenv("e", "mock token")
+	t.Setenv("SLACK_WEBHOOK", "https://hooks.slack.com/services/secret")
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: g, environments: [{name: e1, url: {value: d}, auth: {token: {name: e}}}]}]
+notifications:
+  - url: {type: environment, value: SLACK_WEBHOOK}
+    format: slack
+  - url: https://example.com/hook
+    template: '{"title": {{ json .Title }}}'
+    headers:
+      Authorization: Bearer token
+      X-Token: {type: environment, value: NOTIFICATION_TOKEN}
+`), 0400))
+	assert.NoError(t, afero.WriteFile(fs, "invalid.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: g, environments: [{name: e1, url: {value: d}, auth: {token: {name: e}}}]}]
+notifications:
+  - {url: https://example.com/hook, format: mail}
+  - {url: {type: environment, value: UNDEFINED_WEBHOOK}}
+  - {url: https://example.com/hook, headers: {Authorization: {type: file, value: token}}}
+`), 0400))
+
+	mani, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml"})
+	assert.Empty(t, errs)
+	assert.Equal(t, []manifest.Notification{
+		{
+			URL:    manifest.URLDefinition{Type: manifest.EnvironmentURLType, Name: "SLACK_WEBHOOK", Value: "https://hooks.slack.com/services/secret"},
+			Format: "slack",
+		},
+		{
+			URL:      manifest.URLDefinition{Type: manifest.ValueURLType, Value: "https://example.com/hook"},
+			Template: `{"title": {{ json .Title }}}`,
+			Headers: map[string]hook.HeaderValue{
+				"Authorization": {Type: hook.TypeValue, Value: "Bearer token"},
+				"X-Token":       {Type: hook.TypeEnvironment, Value: "NOTIFICATION_TOKEN"},
+			},
+		},
+	}, mani.Notifications)
+
+	_, errs = Load(&Context{Fs: fs, ManifestPath: "invalid.yaml"})
+	assert.Len(t, errs, 3)
+	assert.ErrorContains(t, errs[0], `invalid notification 1: unknown format "mail"`)
+	assert.ErrorContains(t, errs[1], `invalid notification 2: environment variable "UNDEFINED_WEBHOOK" could not be found`)
+	assert.ErrorContains(t, errs[2], `invalid notification 3: header "Authorization" has unknown type "file"`)
+}
+
+func TestLoadManifest_Rollout(t *testing.T) {
+	t.Setenv("e", "mock token")
+
+	const groups = `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+  - {name: dev, environments: [{name: e1, url: {value: d}, auth: {token: {name: e}}}]}
+  - {name: staging, environments: [{name: e2, url: {value: d}, auth: {token: {name: e}}}]}
+  - {name: prod, environments: [{name: e3, url: {value: d}, auth: {token: {name: e}}}]}
+`
+
+	load := func(t *testing.T, rollout string) (manifest.Manifest, []error) {
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(groups+rollout), 0400))
+		return Load(&Context{Fs: fs, ManifestPath: "manifest.yaml"})
+	}
+
+	t.Run("stages are loaded in order", func(t *testing.T) {
+		mani, errs := load(t, `
+rollout:
+  stages:
+    - {name: first, groups: [dev, staging]}
+    - {name: second, groups: [prod], wait: 10m, confirm: true}
+`)
+		assert.Empty(t, errs)
+		assert.Equal(t, []manifest.Stage{
+			{Name: "first", Groups: []string{"dev", "staging"}},
+			{Name: "second", Groups: []string{"prod"}, Wait: 10 * time.Minute, Confirm: true},
+		}, mani.Stages)
+	})
+
+	t.Run("no rollout", func(t *testing.T) {
+		mani, errs := load(t, "")
+		assert.Empty(t, errs)
+		assert.Empty(t, mani.Stages)
+	})
+
+	tests := []struct {
+		name    string
+		rollout string
+		wantErr string
+	}{
+		{"no stages", "rollout: {stages: []}", "must define at least one stage"},
+		{"missing name", "rollout: {stages: [{groups: [dev, staging, prod]}]}", "missing stage name"},
+		{"duplicated name", "rollout: {stages: [{name: s, groups: [dev, staging]}, {name: s, groups: [prod]}]}", `duplicated stage name "s"`},
+		{"unknown group", "rollout: {stages: [{name: s, groups: [dev, staging, prod, qa]}]}", `unknown group "qa"`},
+		{"group in two stages", "rollout: {stages: [{name: s1, groups: [dev, staging]}, {name: s2, groups: [dev, prod]}]}", `group "dev" is part of stage "s1" and stage "s2"`},
+		{"group in no stage", "rollout: {stages: [{name: s, groups: [dev, staging]}]}", `group "prod" is not part of any rollout stage`},
+		{"invalid wait", "rollout: {stages: [{name: s, groups: [dev, staging, prod], wait: soon}]}", `invalid wait time "soon"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := load(t, tt.rollout)
+			assert.Len(t, errs, 1)
+			assert.ErrorContains(t, errs[0], tt.wantErr)
+		})
+	}
+}
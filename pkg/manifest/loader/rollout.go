@@ -0,0 +1,94 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+	"time"
+)
+
+// parseRollout parses the stages of a staged rollout. Every environment group must be part of exactly one stage, so
+// that no environment is left out of the rollout by accident.
+func parseRollout(context *Context, rollout *persistence.Rollout, groups []persistence.Group) ([]manifest.Stage, []error) {
+	if rollout == nil {
+		return nil, nil
+	}
+	if len(rollout.Stages) == 0 {
+		return nil, []error{newManifestLoaderError(context.ManifestPath, "'rollout' must define at least one stage")}
+	}
+
+	var errs []error
+	// index of the stage each group is part of, or -1 if it is not part of any stage yet
+	stageOfGroup := make(map[string]int, len(groups))
+	for _, g := range groups {
+		stageOfGroup[g.Name] = -1
+	}
+
+	stageNames := make(map[string]bool, len(rollout.Stages))
+	stages := make([]manifest.Stage, 0, len(rollout.Stages))
+	for i, s := range rollout.Stages {
+		if s.Name == "" {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("missing stage name on index `%d`", i)))
+		} else if stageNames[s.Name] {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("duplicated stage name %q", s.Name)))
+		}
+		stageNames[s.Name] = true
+
+		if len(s.Groups) == 0 {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("stage %q does not define any groups", s.Name)))
+		}
+		for _, g := range s.Groups {
+			stage, exists := stageOfGroup[g]
+			switch {
+			case !exists:
+				errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("stage %q references unknown group %q", s.Name, g)))
+			case stage >= 0:
+				errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("group %q is part of stage %q and stage %q", g, rollout.Stages[stage].Name, s.Name)))
+			default:
+				stageOfGroup[g] = i
+			}
+		}
+
+		var wait time.Duration
+		if s.Wait != "" {
+			var err error
+			if wait, err = time.ParseDuration(s.Wait); err != nil || wait < 0 {
+				errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("stage %q has invalid wait time %q - expected a duration like '10m'", s.Name, s.Wait)))
+			}
+		}
+
+		stages = append(stages, manifest.Stage{
+			Name:    s.Name,
+			Groups:  s.Groups,
+			Wait:    wait,
+			Confirm: s.Confirm,
+		})
+	}
+
+	for _, g := range groups {
+		if stageOfGroup[g.Name] < 0 {
+			errs = append(errs, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("group %q is not part of any rollout stage", g.Name)))
+		}
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+	return stages, nil
+}
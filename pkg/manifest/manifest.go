@@ -20,9 +20,11 @@ package manifest
 import (
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/oauth2/endpoints"
 	"github.com/google/uuid"
 	"golang.org/x/exp/maps"
+	"time"
 )
 
 type ProjectDefinition struct {
@@ -63,6 +65,20 @@ type EnvironmentDefinition struct {
 	Group string
 	URL   URLDefinition
 	Auth  Auth
+
+	// Parameters defined in the manifest for the environment, including the ones defined for its group.
+	// Parameters of the environment override group parameters of the same name.
+	Parameters map[string]interface{}
+
+	// GroupParameters are the parameters defined in the manifest for the group of the environment
+	GroupParameters map[string]interface{}
+
+	// Hooks run before and after configs are deployed to the environment, including the ones defined for its group.
+	// Hooks of the group run before the ones of the environment.
+	Hooks hook.Hooks
+
+	// GroupHooks are the hooks defined in the manifest for the group of the environment
+	GroupHooks hook.Hooks
 }
 
 // URLType describes from where the url is loaded.
@@ -137,4 +153,54 @@ type Manifest struct {
 
 	// Accounts holds all accounts defined in the manifest. Key is the user-defined account name.
 	Accounts map[string]Account
+
+	// Stages of a staged rollout, in the order they are deployed. It is empty if the manifest does not define a rollout.
+	Stages []Stage
+
+	// DeploymentEvents configures the events sent to environments after deploying to them
+	DeploymentEvents DeploymentEvents
+
+	// Notifications are the webhooks a summary of each deployment and deletion is sent to
+	Notifications []Notification
+}
+
+// Notification defines a webhook a summary of each deployment and deletion is sent to, see notification.NewWebhook
+type Notification struct {
+	// URL of the webhook
+	URL URLDefinition
+
+	// Format of the payload, see notification.Format
+	Format string
+
+	// Template the payload is rendered from. It is optional.
+	Template string
+
+	// Headers are sent with the notification. Values loaded from environment variables are resolved when the notification
+	// is sent.
+	Headers map[string]hook.HeaderValue
+}
+
+// DeploymentEvents configures the Dynatrace events sent to each environment after configurations were deployed to it
+type DeploymentEvents struct {
+	// Enabled states that an event is sent to each environment after deploying to it
+	Enabled bool
+
+	// EntitySelector selects the entities the event is attached to. If it is empty, the event is not attached to any
+	// entity.
+	EntitySelector string
+}
+
+// Stage is a step of a staged rollout. All environments of a stage are deployed before the next stage starts.
+type Stage struct {
+	// Name of the stage
+	Name string
+
+	// Groups are the names of the environment groups deployed in the stage
+	Groups []string
+
+	// Wait is how long to wait after the previous stage was deployed, before the stage is deployed
+	Wait time.Duration
+
+	// Confirm states that the stage is only deployed after the rollout was confirmed
+	Confirm bool
 }
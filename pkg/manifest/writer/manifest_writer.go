@@ -19,10 +19,12 @@ package writer
 import (
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/spf13/afero"
@@ -78,6 +80,9 @@ func Write(context *Context, manifestToWrite manifest.Manifest) error {
 		ManifestVersion:   "1.0", // we default to old version unless account management FF is active
 		Projects:          projects,
 		EnvironmentGroups: groups,
+		Rollout:           toWriteableRollout(manifestToWrite.Stages),
+		DeploymentEvents:  toWriteableDeploymentEvents(manifestToWrite.DeploymentEvents),
+		Notifications:     toWriteableNotifications(manifestToWrite.Notifications),
 	}
 
 	if featureflags.AccountManagement().Enabled() {
@@ -102,6 +107,46 @@ func persistManifestToDisk(context *Context, m persistence.Manifest) error {
 	return nil
 }
 
+func toWriteableRollout(stages []manifest.Stage) *persistence.Rollout {
+	if len(stages) == 0 {
+		return nil
+	}
+
+	r := persistence.Rollout{Stages: make([]persistence.Stage, 0, len(stages))}
+	for _, s := range stages {
+		stage := persistence.Stage{Name: s.Name, Groups: s.Groups, Confirm: s.Confirm}
+		if s.Wait > 0 {
+			stage.Wait = s.Wait.String()
+		}
+		r.Stages = append(r.Stages, stage)
+	}
+	return &r
+}
+
+func toWriteableDeploymentEvents(e manifest.DeploymentEvents) *persistence.DeploymentEvents {
+	if e == (manifest.DeploymentEvents{}) {
+		return nil
+	}
+	return &persistence.DeploymentEvents{Enabled: e.Enabled, EntitySelector: e.EntitySelector}
+}
+
+func toWriteableNotifications(notifications []manifest.Notification) []persistence.Notification {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	result := make([]persistence.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		result = append(result, persistence.Notification{
+			URL:      toWriteableURL(n.URL),
+			Format:   n.Format,
+			Template: n.Template,
+			Headers:  n.Headers,
+		})
+	}
+	return result
+}
+
 func toWriteableProjects(projects map[string]manifest.ProjectDefinition) (result []persistence.Project) {
 	groups := map[string]persistence.Project{}
 
@@ -150,24 +195,74 @@ func extractGroupedProjectDetails(projectDefinition manifest.ProjectDefinition)
 
 func toWriteableEnvironmentGroups(environments map[string]manifest.EnvironmentDefinition) (result []persistence.Group) {
 	environmentPerGroup := make(map[string][]persistence.Environment)
+	parametersPerGroup := make(map[string]map[string]interface{})
+	hooksPerGroup := make(map[string]hook.Hooks)
 
 	for name, env := range environments {
 		e := persistence.Environment{
-			Name: name,
-			URL:  toWriteableURL(env.URL),
-			Auth: getAuth(env),
+			Name:       name,
+			URL:        toWriteableURL(env.URL),
+			Auth:       getAuth(env),
+			Parameters: environmentSpecificParameters(env),
 		}
+		e.Hooks = toWriteableHooks(environmentSpecificHooks(env))
 
 		environmentPerGroup[env.Group] = append(environmentPerGroup[env.Group], e)
+		if len(env.GroupParameters) > 0 {
+			parametersPerGroup[env.Group] = env.GroupParameters
+		}
+		if !env.GroupHooks.IsEmpty() {
+			hooksPerGroup[env.Group] = env.GroupHooks
+		}
 	}
 
 	for g, envs := range environmentPerGroup {
-		result = append(result, persistence.Group{Name: g, Environments: envs})
+		result = append(result, persistence.Group{Name: g, Environments: envs, Parameters: parametersPerGroup[g], Hooks: toWriteableHooks(hooksPerGroup[g])})
 	}
 
 	return result
 }
 
+// environmentSpecificParameters returns the parameters of the environment that are not inherited from its group, i.e.
+// the ones the group does not define, or defines with a different value
+func environmentSpecificParameters(env manifest.EnvironmentDefinition) map[string]interface{} {
+	var result map[string]interface{}
+	for k, v := range env.Parameters {
+		if groupValue, found := env.GroupParameters[k]; found && reflect.DeepEqual(groupValue, v) {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]interface{})
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// environmentSpecificHooks returns the hooks of the environment that are not inherited from its group. As the hooks of
+// the group run first, they are the ones following the hooks of the group.
+func environmentSpecificHooks(env manifest.EnvironmentDefinition) hook.Hooks {
+	return hook.Hooks{
+		PreDeploy:  withoutPrefix(env.Hooks.PreDeploy, env.GroupHooks.PreDeploy),
+		PostDeploy: withoutPrefix(env.Hooks.PostDeploy, env.GroupHooks.PostDeploy),
+	}
+}
+
+func withoutPrefix(hooks []hook.Hook, prefix []hook.Hook) []hook.Hook {
+	if len(prefix) > len(hooks) || !reflect.DeepEqual(hooks[:len(prefix)], prefix) {
+		return hooks
+	}
+	return hooks[len(prefix):]
+}
+
+func toWriteableHooks(h hook.Hooks) *hook.Definitions {
+	if h.IsEmpty() {
+		return nil
+	}
+	definitions := hook.ToDefinitions(h)
+	return &definitions
+}
+
 func getAuth(env manifest.EnvironmentDefinition) persistence.Auth {
 	return persistence.Auth{
 		Token: getTokenSecret(env.Auth, env.Name),
@@ -22,6 +22,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/oauth2/endpoints"
 	"github.com/google/uuid"
 	"github.com/spf13/afero"
@@ -29,6 +30,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 )
 
 func Test_toWriteableProjects(t *testing.T) {
@@ -347,6 +349,46 @@ func Test_toWriteableEnvironmentGroups(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "writes group parameters on the group and environment specific ones on the environment",
+			input: map[string]manifest.EnvironmentDefinition{
+				"env1": {
+					Name:            "env1",
+					URL:             manifest.URLDefinition{Value: "www.an.Url"},
+					Group:           "group1",
+					Auth:            manifest.Auth{Token: manifest.AuthSecret{Name: "TokenTest"}},
+					Parameters:      map[string]interface{}{"team": "env-team", "prefix": "monaco", "tags": []interface{}{"a"}},
+					GroupParameters: map[string]interface{}{"team": "group-team", "prefix": "monaco"},
+				},
+				"env2": {
+					Name:            "env2",
+					URL:             manifest.URLDefinition{Value: "www.an.Url"},
+					Group:           "group1",
+					Auth:            manifest.Auth{Token: manifest.AuthSecret{Name: "TokenTest"}},
+					Parameters:      map[string]interface{}{"team": "group-team", "prefix": "monaco"},
+					GroupParameters: map[string]interface{}{"team": "group-team", "prefix": "monaco"},
+				},
+			},
+			wantResult: []persistence.Group{
+				{
+					Name:       "group1",
+					Parameters: map[string]interface{}{"team": "group-team", "prefix": "monaco"},
+					Environments: []persistence.Environment{
+						{
+							Name:       "env1",
+							URL:        persistence.TypedValue{Value: "www.an.Url"},
+							Auth:       persistence.Auth{Token: persistence.AuthSecret{Name: "TokenTest", Type: "environment"}},
+							Parameters: map[string]interface{}{"team": "env-team", "tags": []interface{}{"a"}},
+						},
+						{
+							Name: "env2",
+							URL:  persistence.TypedValue{Value: "www.an.Url"},
+							Auth: persistence.Auth{Token: persistence.AuthSecret{Name: "TokenTest", Type: "environment"}},
+						},
+					},
+				},
+			},
+		},
 		{
 			"returns empty groups for empty env definition",
 			map[string]manifest.EnvironmentDefinition{},
@@ -374,6 +416,19 @@ func Test_toWriteableEnvironmentGroups(t *testing.T) {
 	}
 }
 
+func Test_toWriteableRollout(t *testing.T) {
+	assert.Nil(t, toWriteableRollout(nil))
+
+	got := toWriteableRollout([]manifest.Stage{
+		{Name: "first", Groups: []string{"dev"}},
+		{Name: "second", Groups: []string{"staging", "prod"}, Wait: 90 * time.Minute, Confirm: true},
+	})
+	assert.Equal(t, &persistence.Rollout{Stages: []persistence.Stage{
+		{Name: "first", Groups: []string{"dev"}},
+		{Name: "second", Groups: []string{"staging", "prod"}, Wait: "1h30m0s", Confirm: true},
+	}}, got)
+}
+
 func Test_toWriteableUrl(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -811,3 +866,51 @@ environmentGroups:
 		})
 	}
 }
+
+func TestWrite_RoundTripsHooks(t *testing.T) {
+	t.Setenv("TOKEN_VAR", "dt0c01.token")
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, fs.MkdirAll("projects/p1", 0777))
+	assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`manifestVersion: "1.0"
+projects:
+- name: p1
+  path: projects/p1
+environmentGroups:
+- name: group1
+  hooks:
+    preDeploy:
+    - command: ["echo", "group"]
+  environments:
+  - name: env1
+    url:
+      value: https://a.dynatrace.environment
+    auth:
+      token:
+        name: TOKEN_VAR
+    hooks:
+      preDeploy:
+      - command: ["echo", "env1"]
+      postDeploy:
+      - url: https://hooks.example.com
+  - name: env2
+    url:
+      value: https://b.dynatrace.environment
+    auth:
+      token:
+        name: TOKEN_VAR
+`), 0644))
+
+	loaded, errs := loader.Load(&loader.Context{Fs: fs, ManifestPath: "manifest.yaml"})
+	assert.Empty(t, errs)
+
+	assert.NoError(t, Write(&Context{Fs: fs, ManifestPath: "written/manifest.yaml"}, loaded))
+	assert.NoError(t, fs.MkdirAll("written/projects/p1", 0777))
+
+	reloaded, errs := loader.Load(&loader.Context{Fs: fs, ManifestPath: "written/manifest.yaml"})
+	assert.Empty(t, errs)
+	assert.Equal(t, loaded.Environments, reloaded.Environments, "group hooks must not be duplicated on the environments")
+	assert.Len(t, reloaded.Environments["env1"].Hooks.PreDeploy, 2)
+	assert.Len(t, reloaded.Environments["env2"].Hooks.PreDeploy, 1)
+	assert.Len(t, reloaded.Environments["env2"].GroupHooks.PreDeploy, 1, "group hooks must be written on the group")
+}
@@ -0,0 +1,162 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package notification sends a summary of a deployment or deletion to chat or generic webhooks.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/webhook"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
+	"text/template"
+	"time"
+)
+
+// Format of the payload sent to a webhook
+type Format string
+
+const (
+	// Slack payloads are Slack incoming webhook messages
+	Slack Format = "slack"
+	// Teams payloads are Microsoft Teams incoming webhook message cards
+	Teams Format = "teams"
+	// Generic payloads are the Summary as JSON, or the rendered template of the webhook
+	Generic Format = "generic"
+)
+
+// timeout is the maximum duration of sending a notification to a single webhook
+const timeout = 30 * time.Second
+
+// Webhook is an endpoint notifications are sent to
+type Webhook struct {
+	// URL the notification is posted to
+	URL string
+	// Format of the payload
+	Format Format
+	// Template is rendered with the Summary. For Slack and Teams webhooks, it replaces the text of the message, for
+	// Generic webhooks it replaces the whole payload. It is nil if the default payload is sent.
+	Template *template.Template
+	// Headers are sent with the notification request. Values loaded from environment variables are resolved when the
+	// notification is sent.
+	Headers map[string]hook.HeaderValue
+}
+
+// NewWebhook returns a Webhook sending payloads of the given format to the URL. If the format is empty, Generic is used.
+// The template is optional, see Webhook.Template.
+func NewWebhook(url string, format string, tmpl string, headers map[string]hook.HeaderValue) (Webhook, error) {
+	if url == "" {
+		return Webhook{}, errors.New("no `url` configured")
+	}
+
+	w := Webhook{URL: url, Format: Format(format), Headers: headers}
+	switch w.Format {
+	case "":
+		w.Format = Generic
+	case Slack, Teams, Generic:
+	default:
+		return Webhook{}, fmt.Errorf("unknown format %q, must be one of %q, %q or %q", format, Slack, Teams, Generic)
+	}
+
+	if tmpl != "" {
+		t, err := template.New("notification").Funcs(template.FuncMap{"json": toJSON}).Option("missingkey=error").Parse(tmpl)
+		if err != nil {
+			return Webhook{}, fmt.Errorf("invalid template: %w", err)
+		}
+		w.Template = t
+	}
+	return w, nil
+}
+
+// toJSON is available in templates as 'json', to embed values in JSON payloads
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// Send sends the Summary to all webhooks. Failing to notify a webhook does not stop notifying the others, and all
+// errors are returned joined.
+func Send(ctx context.Context, webhooks []Webhook, s Summary) error {
+	var errs []error
+	for _, w := range webhooks {
+		if err := w.Send(ctx, s); err != nil {
+			log.WithFields(field.Error(err)).Warn("Failed to send notification to %s webhook: %v", w.Format, err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Send sends the Summary to the webhook. It fails unless the webhook responds with a 2xx status code.
+func (w Webhook) Send(ctx context.Context, s Summary) error {
+	body, err := w.payload(s)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return webhook.Post(ctx, w.URL, w.Headers, body)
+}
+
+// payload returns the body sent to the webhook for the Summary
+func (w Webhook) payload(s Summary) ([]byte, error) {
+	if w.Format == Generic {
+		if w.Template == nil {
+			return json.Marshal(s)
+		}
+		return w.render(s)
+	}
+
+	text := []byte(s.Text())
+	if w.Template != nil {
+		var err error
+		if text, err = w.render(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if w.Format == Slack {
+		return json.Marshal(map[string]string{"text": string(text)})
+	}
+
+	color := "2EB886"
+	if !s.Succeeded {
+		color = "D00000"
+	}
+	return json.Marshal(map[string]string{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    s.Title(),
+		"themeColor": color,
+		"title":      s.Title(),
+		"text":       string(text),
+	})
+}
+
+func (w Webhook) render(s Summary) ([]byte, error) {
+	var b bytes.Buffer
+	if err := w.Template.Execute(&b, s); err != nil {
+		return nil, fmt.Errorf("failed to render notification template: %w", err)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,157 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/report"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/notification"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var givenSummary = notification.Summary{
+	Operation: "deploy",
+	Succeeded: false,
+	Environments: []notification.Environment{
+		{Name: "dev", Succeeded: true, Counts: map[report.Status]int{report.Deployed: 2}},
+		{Name: "prod", Errors: 1, Counts: map[report.Status]int{report.Deployed: 1, report.Failed: 1}},
+	},
+}
+
+func TestNewWebhook_Errors(t *testing.T) {
+	_, err := notification.NewWebhook("", "slack", "", nil)
+	assert.ErrorContains(t, err, "no `url` configured")
+
+	_, err = notification.NewWebhook("https://example.com", "mail", "", nil)
+	assert.ErrorContains(t, err, `unknown format "mail"`)
+
+	_, err = notification.NewWebhook("https://example.com", "", "{{ .Operation", nil)
+	assert.ErrorContains(t, err, "invalid template")
+}
+
+func TestWebhook_Send(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		template string
+		expected string
+	}{
+		{
+			name:     "slack",
+			format:   "slack",
+			expected: `{"text": "monaco deploy failed\n- dev: succeeded (2 deployed)\n- prod: failed with 1 error(s) (1 deployed, 1 failed)"}`,
+		},
+		{
+			name:     "slack with template",
+			format:   "slack",
+			template: `{{ .Title }}: {{ range .Environments }}{{ .Name }} {{ end }}`,
+			expected: `{"text": "monaco deploy failed: dev prod "}`,
+		},
+		{
+			name:   "teams",
+			format: "teams",
+			expected: `{
+				"@type": "MessageCard",
+				"@context": "https://schema.org/extensions",
+				"summary": "monaco deploy failed",
+				"themeColor": "D00000",
+				"title": "monaco deploy failed",
+				"text": "monaco deploy failed\n- dev: succeeded (2 deployed)\n- prod: failed with 1 error(s) (1 deployed, 1 failed)"
+			}`,
+		},
+		{
+			name: "generic",
+			expected: `{
+				"operation": "deploy",
+				"dryRun": false,
+				"succeeded": false,
+				"environments": [
+					{"name": "dev", "succeeded": true, "errors": 0, "counts": {"deployed": 2}},
+					{"name": "prod", "succeeded": false, "errors": 1, "counts": {"deployed": 1, "failed": 1}}
+				]
+			}`,
+		},
+		{
+			name:     "generic with template",
+			format:   "generic",
+			template: `{"message": {{ json .Title }}, "failed": {{ not .Succeeded }}}`,
+			expected: `{"message": "monaco deploy failed", "failed": true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, "value", r.Header.Get("X-Custom"))
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, tt.expected, string(body))
+			}))
+			defer server.Close()
+
+			w, err := notification.NewWebhook(server.URL, tt.format, tt.template, map[string]hook.HeaderValue{"X-Custom": {Value: "value"}})
+			assert.NoError(t, err)
+			assert.NoError(t, w.Send(context.TODO(), givenSummary))
+		})
+	}
+}
+
+func TestWebhook_SendHeaderFromEnvironment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer notification-token", r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	w, err := notification.NewWebhook(server.URL, "", "", map[string]hook.HeaderValue{"Authorization": {Type: hook.TypeEnvironment, Value: "NOTIFICATION_TEST_TOKEN"}})
+	assert.NoError(t, err)
+	assert.ErrorContains(t, w.Send(context.TODO(), givenSummary), `failed to resolve header "Authorization": environment variable "NOTIFICATION_TEST_TOKEN" could not be found`)
+
+	t.Setenv("NOTIFICATION_TEST_TOKEN", "Bearer notification-token")
+	assert.NoError(t, w.Send(context.TODO(), givenSummary))
+	assert.Equal(t, secret.Mask, secret.MaskValues("Bearer notification-token"), "header values loaded from the environment must be masked")
+}
+
+func TestSend_NotifiesAllWebhooks(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var s notification.Summary
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&s))
+		received = append(received, r.URL.Path)
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	failing, _ := notification.NewWebhook(server.URL+"/failing", "", "", nil)
+	working, _ := notification.NewWebhook(server.URL+"/working", "", "", nil)
+
+	err := notification.Send(context.TODO(), []notification.Webhook{failing, working}, givenSummary)
+	assert.ErrorContains(t, err, "webhook responded with HTTP 500")
+	assert.Equal(t, []string{"/failing", "/working"}, received)
+}
@@ -0,0 +1,152 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notification
+
+import (
+	"fmt"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/report"
+	"slices"
+	"strings"
+)
+
+// Summary describes the result of a deployment or deletion
+type Summary struct {
+	// Operation is the monaco command that was run, e.g. "deploy"
+	Operation string `json:"operation"`
+	// DryRun states that nothing was changed on the environments
+	DryRun bool `json:"dryRun"`
+	// Succeeded states that the operation succeeded for all environments
+	Succeeded bool `json:"succeeded"`
+	// Environments are the results per environment, sorted by name
+	Environments []Environment `json:"environments"`
+}
+
+// Environment is the result of a deployment or deletion for a single environment
+type Environment struct {
+	// Name of the environment
+	Name string `json:"name"`
+	// Succeeded states that the operation succeeded for the environment
+	Succeeded bool `json:"succeeded"`
+	// NotDeployed states that the deployment to the environment never started, e.g. as an earlier environment or
+	// rollout stage failed or as the deployment was canceled. It is only known if a report is given.
+	NotDeployed bool `json:"notDeployed,omitempty"`
+	// Errors is the number of errors that occurred for the environment
+	Errors int `json:"errors"`
+	// Counts is the number of configs per deployment status. It is empty for deletions.
+	Counts map[report.Status]int `json:"counts,omitempty"`
+}
+
+// statusOrder is the order in which counts are listed in texts
+var statusOrder = []report.Status{report.Deployed, report.Unchanged, report.Failed, report.ParentFailed, report.Skipped, report.Canceled}
+
+// NewSummary returns the Summary of running the operation on the given environments. The errors per environment are
+// taken from all deployErrors.EnvironmentDeploymentErrors err contains. If err contains no errors of single
+// environments, all environments failed. The report is optional and adds the number of configs per status, and whether
+// the deployment to an environment started at all.
+func NewSummary(operation string, dryRun bool, environments []string, err error, r *report.Report) Summary {
+	envErrs := environmentErrors(err)
+
+	s := Summary{Operation: operation, DryRun: dryRun, Succeeded: err == nil}
+	environments = slices.Clone(environments)
+	slices.Sort(environments)
+	for _, name := range environments {
+		e := Environment{Name: name, Errors: len(envErrs[name])}
+		e.Succeeded = e.Errors == 0 && (err == nil || len(envErrs) > 0)
+
+		if r != nil {
+			e.Counts = make(map[report.Status]int)
+			for _, status := range statusOrder {
+				if c := r.Count(name, status); c > 0 {
+					e.Counts[status] = c
+				}
+			}
+			if e.Counts[report.Failed] > 0 {
+				e.Succeeded = false
+			}
+			if !r.Started(name) {
+				e.NotDeployed = true
+				e.Succeeded = false
+			}
+		}
+
+		s.Environments = append(s.Environments, e)
+	}
+	return s
+}
+
+// environmentErrors collects the errors of all deployErrors.EnvironmentDeploymentErrors in the tree of err
+func environmentErrors(err error) deployErrors.EnvironmentDeploymentErrors {
+	errs := make(deployErrors.EnvironmentDeploymentErrors)
+
+	var collect func(error)
+	collect = func(err error) {
+		switch e := err.(type) {
+		case deployErrors.EnvironmentDeploymentErrors:
+			for env, err := range e {
+				errs.Append(env, err...)
+			}
+		case interface{ Unwrap() []error }:
+			for _, err := range e.Unwrap() {
+				collect(err)
+			}
+		case interface{ Unwrap() error }:
+			collect(e.Unwrap())
+		}
+	}
+	collect(err)
+
+	return errs
+}
+
+// Title returns a short description of the Summary, e.g. "monaco deploy failed"
+func (s Summary) Title() string {
+	result := "succeeded"
+	if !s.Succeeded {
+		result = "failed"
+	}
+	if s.DryRun {
+		return fmt.Sprintf("monaco %s (dry-run) %s", s.Operation, result)
+	}
+	return fmt.Sprintf("monaco %s %s", s.Operation, result)
+}
+
+// Text returns the Title, followed by a line describing the result of each environment
+func (s Summary) Text() string {
+	lines := []string{s.Title()}
+	for _, e := range s.Environments {
+		line := fmt.Sprintf("- %s: succeeded", e.Name)
+		if e.NotDeployed && e.Errors == 0 {
+			line = fmt.Sprintf("- %s: not deployed", e.Name)
+		} else if !e.Succeeded {
+			line = fmt.Sprintf("- %s: failed with %d error(s)", e.Name, e.Errors)
+		}
+
+		var counts []string
+		for _, status := range statusOrder {
+			if c := e.Counts[status]; c > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", c, status))
+			}
+		}
+		if len(counts) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(counts, ", "))
+		}
+
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
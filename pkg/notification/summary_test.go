@@ -0,0 +1,90 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notification_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/report"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/notification"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewSummary(t *testing.T) {
+	t.Run("successful operation", func(t *testing.T) {
+		s := notification.NewSummary("delete", true, []string{"prod", "dev"}, nil, nil)
+		assert.Equal(t, notification.Summary{
+			Operation: "delete",
+			DryRun:    true,
+			Succeeded: true,
+			Environments: []notification.Environment{
+				{Name: "dev", Succeeded: true},
+				{Name: "prod", Succeeded: true},
+			},
+		}, s)
+		assert.Equal(t, "monaco delete (dry-run) succeeded\n- dev: succeeded\n- prod: succeeded", s.Text())
+	})
+
+	t.Run("errors of environments are collected from wrapped and joined errors", func(t *testing.T) {
+		err := errors.Join(
+			fmt.Errorf("stage 1 failed: %w", deployErrors.EnvironmentDeploymentErrors{"dev": {errors.New("a"), errors.New("b")}}),
+			fmt.Errorf("stage 2 failed: %w", deployErrors.EnvironmentDeploymentErrors{"prod": {errors.New("c")}}),
+		)
+
+		r := report.New()
+		r.Start("dev")
+		r.Start("prod")
+		r.Start("test")
+		r.Add("test", report.Record{Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}, Status: report.Deployed})
+		r.Add("test", report.Record{Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "b"}, Status: report.Unchanged})
+
+		s := notification.NewSummary("deploy", false, []string{"prod", "dev", "test"}, err, r)
+		assert.Equal(t, []notification.Environment{
+			{Name: "dev", Errors: 2, Counts: map[report.Status]int{}},
+			{Name: "prod", Errors: 1, Counts: map[report.Status]int{}},
+			{Name: "test", Succeeded: true, Counts: map[report.Status]int{report.Deployed: 1, report.Unchanged: 1}},
+		}, s.Environments)
+		assert.False(t, s.Succeeded)
+		assert.Equal(t, "monaco deploy failed\n- dev: failed with 2 error(s)\n- prod: failed with 1 error(s)\n- test: succeeded (1 deployed, 1 unchanged)", s.Text())
+	})
+
+	t.Run("environments that were never deployed are not deployed", func(t *testing.T) {
+		err := deployErrors.EnvironmentDeploymentErrors{"dev": {errors.New("a")}}
+
+		r := report.New()
+		r.Start("dev")
+		r.Add("prod", report.Record{Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}, Status: report.Canceled})
+
+		s := notification.NewSummary("deploy", false, []string{"dev", "prod", "test"}, err, r)
+		assert.Equal(t, []notification.Environment{
+			{Name: "dev", Errors: 1, Counts: map[report.Status]int{}},
+			{Name: "prod", NotDeployed: true, Counts: map[report.Status]int{report.Canceled: 1}},
+			{Name: "test", NotDeployed: true, Counts: map[report.Status]int{}},
+		}, s.Environments)
+		assert.Equal(t, "monaco deploy failed\n- dev: failed with 1 error(s)\n- prod: not deployed (1 canceled)\n- test: not deployed", s.Text())
+	})
+
+	t.Run("all environments failed if no environment errors are known", func(t *testing.T) {
+		s := notification.NewSummary("deploy", false, []string{"dev"}, errors.New("canceled"), nil)
+		assert.Equal(t, []notification.Environment{{Name: "dev"}}, s.Environments)
+	})
+}
@@ -17,6 +17,7 @@
 package persistence
 
 import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"reflect"
 )
 
@@ -36,6 +37,18 @@ type ConfigDefinition struct {
 	Template       string                     `yaml:"template,omitempty" json:"template,omitempty" jsonschema:"required,description=The filepath to the JSON template used for this configuration"`
 	Skip           ConfigParameter            `yaml:"skip,omitempty" json:"skip,omitempty" jsonschema:"description=Defines whether this config should be skipped when deploying."`
 	OriginObjectId string                     `yaml:"originObjectId,omitempty" json:"originObjectId,omitempty" jsonschema:"description=description=The identifier of the Dynatrace object this config originated from - this is filled when downloading, but can also be set to tie a config to a specific object."`
+	CreateOnly     *bool                      `yaml:"createOnly,omitempty" json:"createOnly,omitempty" jsonschema:"description=Defines whether the object of this config is only created, but never updated once it exists."`
+	IgnoreChanges  []string                   `yaml:"ignoreChanges,omitempty" json:"ignoreChanges,omitempty" jsonschema:"description=Paths of JSON fields whose values are kept as they are on the environment when updating an existing object, e.g. 'dashboardMetadata.owner'. Path segments are separated by dots, numeric segments index arrays."`
+	PreventDestroy *bool                      `yaml:"preventDestroy,omitempty" json:"preventDestroy,omitempty" jsonschema:"description=Defines whether the object of this config must never be deleted by 'monaco delete' or 'monaco purge'."`
+	Hooks          *hook.Definitions          `yaml:"hooks,omitempty" json:"hooks,omitempty" jsonschema:"description=Hooks run before and after this config is deployed."`
+	DependsOn      []ConfigDependency         `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty" jsonschema:"description=Configs that must be deployed before this config, although it does not reference any of their properties."`
+}
+
+// ConfigDependency is a config another config depends on
+type ConfigDependency struct {
+	Project  string `yaml:"project,omitempty" json:"project,omitempty" jsonschema:"description=The project of the config. Defaults to the project of the depending config."`
+	Type     string `yaml:"configType" json:"configType" jsonschema:"required,description=The type of the config, e.g. 'management-zone' or 'builtin:alerting.profile'."`
+	ConfigId string `yaml:"configId" json:"configId" jsonschema:"required,description=The identifier of the config."`
 }
 
 type TopLevelConfigDefinition struct {
@@ -46,6 +59,8 @@ type TopLevelConfigDefinition struct {
 	GroupOverrides []GroupOverride `yaml:"groupOverrides,omitempty" json:"groupOverrides,omitempty" jsonschema:"description=GroupOverrides overwrite specific parts of the Config when deploying it to any environment in a given group."`
 	// EnvironmentOverrides overwrite specific parts of the Config when deploying it to a given environment
 	EnvironmentOverrides []EnvironmentOverride `yaml:"environmentOverrides,omitempty" json:"environmentOverrides,omitempty" jsonschema:"description=EnvironmentOverrides overwrite specific parts of the Config when deploying it to a given environment."`
+	// ForEach generates one config per item, either from a list of items or from the path of a values file containing the list
+	ForEach interface{} `yaml:"forEach,omitempty" json:"forEach,omitempty" jsonschema:"oneof_type=array;string,description=Generates one config per item of this list, or of the list in the YAML or JSON values file at this path. The id, the name and all parameters given as plain strings are rendered as templates with the fields '.item' and '.index', e.g. 'alert-{{ .item.team }}'. The item is available to the JSON template as the parameter 'item'."`
 }
 
 type TopLevelDefinition struct {
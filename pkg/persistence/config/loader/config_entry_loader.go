@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
@@ -29,6 +30,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strconv"
+	"strings"
 )
 
 // parseConfigEntry parses a single config entry
@@ -139,6 +141,26 @@ func applyOverrides(base *persistence.ConfigDefinition, override persistence.Con
 		base.OriginObjectId = override.OriginObjectId
 	}
 
+	if override.CreateOnly != nil {
+		base.CreateOnly = override.CreateOnly
+	}
+
+	if override.IgnoreChanges != nil {
+		base.IgnoreChanges = override.IgnoreChanges
+	}
+
+	if override.PreventDestroy != nil {
+		base.PreventDestroy = override.PreventDestroy
+	}
+
+	if override.Hooks != nil {
+		base.Hooks = override.Hooks
+	}
+
+	if override.DependsOn != nil {
+		base.DependsOn = override.DependsOn
+	}
+
 	for name, param := range override.Parameters {
 		base.Parameters[name] = param
 	}
@@ -160,7 +182,8 @@ func getConfigFromDefinition(
 		}
 	}
 
-	tmpl, err := template.NewFileTemplate(fs, filepath.Join(context.Folder, definition.Template))
+	tmpl, err := template.NewFileTemplate(fs, filepath.Join(context.Folder, definition.Template),
+		template.WithPartials(filepath.Join(context.LoaderContext.Path, template.PartialsFolder)))
 
 	var errs []error
 
@@ -187,6 +210,23 @@ func getConfigFromDefinition(
 		}
 	}
 
+	lifecycle, err := parseLifecycle(context, environment, configId, definition)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	var hooks hook.Hooks
+	if definition.Hooks != nil {
+		if hooks, err = hook.Parse(*definition.Hooks); err != nil {
+			errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, fmt.Sprintf("invalid `hooks`: %s", err)))
+		}
+	}
+
+	dependsOn, err := parseDependsOn(context, environment, configId, definition.DependsOn)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
 	t, err := getType(configType)
 	if err != nil {
 		return config.Config{}, []error{fmt.Errorf("failed to parse type of config %q: %w", configId, err)}
@@ -234,9 +274,61 @@ func getConfigFromDefinition(
 		Parameters:     parameters,
 		Skip:           skipConfig,
 		OriginObjectId: definition.OriginObjectId,
+		Lifecycle:      lifecycle,
+		Hooks:          hooks,
+		DependsOn:      dependsOn,
 	}, nil
 }
 
+// parseDependsOn returns the coordinates of the configs the config depends on. Dependencies without a project are in the
+// project of the config.
+func parseDependsOn(context *singleConfigEntryLoadContext, environment manifest.EnvironmentDefinition, configId string, dependencies []persistence.ConfigDependency) ([]coordinate.Coordinate, error) {
+	if len(dependencies) == 0 {
+		return nil, nil
+	}
+
+	self := coordinate.Coordinate{Project: context.ProjectId, Type: context.Type, ConfigId: configId}
+	coordinates := make([]coordinate.Coordinate, 0, len(dependencies))
+	for _, d := range dependencies {
+		if d.Type == "" || d.ConfigId == "" {
+			return nil, newDetailedDefinitionParserError(configId, context, environment, "invalid `dependsOn` - `configType` and `configId` are required")
+		}
+
+		c := coordinate.Coordinate{Project: d.Project, Type: d.Type, ConfigId: d.ConfigId}
+		if c.Project == "" {
+			c.Project = context.ProjectId
+		}
+		if c == self {
+			return nil, newDetailedDefinitionParserError(configId, context, environment, "invalid `dependsOn` - a config can not depend on itself")
+		}
+		if !slices.Contains(coordinates, c) {
+			coordinates = append(coordinates, c)
+		}
+	}
+	return coordinates, nil
+}
+
+// parseLifecycle returns the lifecycle options of the config definition
+func parseLifecycle(context *singleConfigEntryLoadContext, environment manifest.EnvironmentDefinition, configId string, definition persistence.ConfigDefinition) (config.Lifecycle, error) {
+	l := config.Lifecycle{
+		CreateOnly:     definition.CreateOnly != nil && *definition.CreateOnly,
+		IgnoreChanges:  definition.IgnoreChanges,
+		PreventDestroy: definition.PreventDestroy != nil && *definition.PreventDestroy,
+	}
+
+	if l.CreateOnly && len(l.IgnoreChanges) > 0 {
+		return config.Lifecycle{}, newDetailedDefinitionParserError(configId, context, environment, "`createOnly` and `ignoreChanges` can not be combined, as objects of create-only configs are never updated")
+	}
+
+	for _, path := range l.IgnoreChanges {
+		if path == "" || slices.Contains(strings.Split(path, "."), "") {
+			return config.Lifecycle{}, newDetailedDefinitionParserError(configId, context, environment, fmt.Sprintf("invalid path %q in `ignoreChanges` - paths must consist of non-empty segments separated by dots", path))
+		}
+	}
+
+	return l, nil
+}
+
 func getType(typeDef persistence.TypeDefinition) (config.Type, error) {
 	switch {
 	case typeDef.IsSettings():
@@ -41,6 +41,7 @@ type LoaderContext struct {
 // configFileLoaderContext is a context for each config-file
 type configFileLoaderContext struct {
 	*LoaderContext
+	Fs     afero.Fs
 	Folder string
 	Path   string
 }
@@ -61,6 +62,10 @@ func LoadConfig(fs afero.Fs, context *LoaderContext, filePath string) ([]config.
 
 	var content map[string]any
 	if err := yaml.Unmarshal(data, &content); err != nil {
+		if isValuesFile(data) {
+			log.WithFields(field.F("file", filePath)).Debug("File %q contains a list and appears to be a `forEach` values file, skipping loading", filePath)
+			return []config.Config{}, nil
+		}
 		return nil, []error{newLoadError(filePath, err)}
 	}
 
@@ -86,6 +91,7 @@ func LoadConfig(fs afero.Fs, context *LoaderContext, filePath string) ([]config.
 
 	configLoaderContext := &configFileLoaderContext{
 		LoaderContext: context,
+		Fs:            fs,
 		Folder:        filepath.Dir(filePath),
 		Path:          filePath,
 	}
@@ -93,16 +99,24 @@ func LoadConfig(fs afero.Fs, context *LoaderContext, filePath string) ([]config.
 	var errs []error
 	var configs []config.Config
 
-	for _, cgf := range definedConfigEntries {
-
-		result, definitionErrors := parseConfigEntry(fs, configLoaderContext, cgf.Id, cgf)
+	for _, definition := range definedConfigEntries {
 
-		if len(definitionErrors) > 0 {
-			errs = append(errs, definitionErrors...)
+		expanded, err := expandForEach(fs, configLoaderContext.Folder, definition)
+		if err != nil {
+			errs = append(errs, newLoadError(filePath, err))
 			continue
 		}
 
-		configs = append(configs, result...)
+		for _, cgf := range expanded {
+			result, definitionErrors := parseConfigEntry(fs, configLoaderContext, cgf.Id, cgf)
+
+			if len(definitionErrors) > 0 {
+				errs = append(errs, definitionErrors...)
+				continue
+			}
+
+			configs = append(configs, result...)
+		}
 	}
 
 	if errs != nil {
@@ -112,6 +126,12 @@ func LoadConfig(fs afero.Fs, context *LoaderContext, filePath string) ([]config.
 	return configs, nil
 }
 
+// isValuesFile returns whether the given file content is a YAML list, like the values files of `forEach`
+func isValuesFile(data []byte) bool {
+	var items []any
+	return yaml.Unmarshal(data, &items) == nil && items != nil
+}
+
 func parseFile(data []byte) ([]persistence.TopLevelConfigDefinition, error) {
 
 	definition := persistence.TopLevelDefinition{}
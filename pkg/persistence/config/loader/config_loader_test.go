@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
@@ -35,6 +36,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
+	"time"
 )
 
 func Test_parseConfigs(t *testing.T) {
@@ -912,6 +914,188 @@ configs:
 				},
 			},
 		},
+		{
+			name:             "loads lifecycle options with overrides",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+    createOnly: true
+    preventDestroy: true
+  type: bucket
+  environmentOverrides:
+  - environment: env name
+    override:
+      createOnly: false
+      ignoreChanges: [retentionDays, 'records.0.name']
+`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "bucket",
+						ConfigId: "profile-id",
+					},
+					Type:        config.BucketType{},
+					Template:    template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters:  config.Parameters{},
+					Skip:        false,
+					Environment: "env name",
+					Group:       "default",
+					Lifecycle: config.Lifecycle{
+						IgnoreChanges:  []string{"retentionDays", "records.0.name"},
+						PreventDestroy: true,
+					},
+				},
+			},
+		},
+		{
+			name:             "loads hooks with overrides",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+    hooks:
+      preDeploy: [{command: [./check.sh]}]
+  type: bucket
+  environmentOverrides:
+  - environment: env name
+    override:
+      hooks:
+        postDeploy: [{url: 'https://example.com/hook', timeout: 10s}]
+`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "bucket",
+						ConfigId: "profile-id",
+					},
+					Type:        config.BucketType{},
+					Template:    template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters:  config.Parameters{},
+					Skip:        false,
+					Environment: "env name",
+					Group:       "default",
+					Hooks: hook.Hooks{
+						PostDeploy: []hook.Hook{{URL: "https://example.com/hook", Timeout: 10 * time.Second}},
+					},
+				},
+			},
+		},
+		{
+			name:             "reports error for invalid hooks",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+    hooks:
+      postDeploy: [{command: [./check.sh], url: 'https://example.com/hook'}]
+  type: bucket
+`,
+			wantErrorsContain: []string{"invalid `hooks`: invalid post-deploy hook on index `0`: exactly one of `command` and `url` must be set"},
+		},
+		{
+			name:             "loads dependsOn",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+    dependsOn:
+    - {configType: management-zone, configId: zone}
+    - {project: other, configType: builtin:alerting.profile, configId: profile}
+    - {project: project, configType: management-zone, configId: zone}
+  type: bucket
+`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "bucket",
+						ConfigId: "profile-id",
+					},
+					Type:        config.BucketType{},
+					Template:    template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters:  config.Parameters{},
+					Skip:        false,
+					Environment: "env name",
+					Group:       "default",
+					DependsOn: []coordinate.Coordinate{
+						{Project: "project", Type: "management-zone", ConfigId: "zone"},
+						{Project: "other", Type: "builtin:alerting.profile", ConfigId: "profile"},
+					},
+				},
+			},
+		},
+		{
+			name:             "reports error for dependsOn without configId",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+    dependsOn: [{configType: management-zone}]
+  type: bucket
+`,
+			wantErrorsContain: []string{"invalid `dependsOn` - `configType` and `configId` are required"},
+		},
+		{
+			name:             "reports error for config depending on itself",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+    dependsOn: [{configType: bucket, configId: profile-id}]
+  type: bucket
+`,
+			wantErrorsContain: []string{"a config can not depend on itself"},
+		},
+		{
+			name:             "reports error for create-only config with ignored changes",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+    createOnly: true
+    ignoreChanges: [retentionDays]
+  type: bucket
+`,
+			wantErrorsContain: []string{"`createOnly` and `ignoreChanges` can not be combined"},
+		},
+		{
+			name:             "reports error for invalid path of ignored changes",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+    ignoreChanges: ['records..name']
+  type: bucket
+`,
+			wantErrorsContain: []string{`invalid path "records..name" in` + " `ignoreChanges`"},
+		},
 		{
 			name:             "Bucket written as api config",
 			filePathArgument: "test-file.yaml",
@@ -1080,3 +1264,223 @@ func makeCompoundParam(t *testing.T, refs []parameter.ParameterReference) *compo
 	assert.NoError(t, err)
 	return compoundParam
 }
+
+func TestLoadConfig_ManifestParameters(t *testing.T) {
+	loaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      "some-dir/",
+		Environments: []manifest.EnvironmentDefinition{
+			{Name: "dev", Group: "default", Parameters: map[string]interface{}{"team": "dev-team"}},
+			{Name: "prod", Group: "default", Parameters: map[string]interface{}{"team": "prod-team"}},
+		},
+		KnownApis:       map[string]struct{}{"some-api": {}},
+		ParametersSerDe: config.DefaultParameterParsers,
+	}
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "profile.json", []byte("{}"), 0644))
+	assert.NoError(t, afero.WriteFile(fs, "config.yaml", []byte(`
+configs:
+- id: profile
+  config:
+    name:
+      type: manifest
+      name: team
+    template: profile.json
+  type:
+    api: some-api
+`), 0644))
+
+	configs, errs := LoadConfig(fs, loaderContext, "config.yaml")
+	assert.Empty(t, errs)
+	assert.Len(t, configs, 2)
+
+	for _, c := range configs {
+		name, err := c.Parameters["name"].ResolveValue(parameter.ResolveContext{})
+		assert.NoError(t, err)
+		assert.Equal(t, c.Environment+"-team", name)
+	}
+
+	loaderContext.Environments = []manifest.EnvironmentDefinition{{Name: "other", Group: "default"}}
+	_, errs = LoadConfig(fs, loaderContext, "config.yaml")
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "manifest does not define parameter `team` for the environment")
+}
+
+func TestLoadConfig_ForEach(t *testing.T) {
+	loaderContext := &LoaderContext{
+		ProjectId:       "project",
+		Path:            "some-dir/",
+		Environments:    []manifest.EnvironmentDefinition{{Name: "dev", Group: "default"}},
+		KnownApis:       map[string]struct{}{"some-api": {}},
+		ParametersSerDe: config.DefaultParameterParsers,
+	}
+
+	givenFs := func(t *testing.T, content string) afero.Fs {
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, afero.WriteFile(fs, "project/profile.json", []byte(`{"team": "{{ .item.team }}", "recipient": "{{ .recipient }}"}`), 0644))
+		assert.NoError(t, afero.WriteFile(fs, "project/values/teams.yaml", []byte("- {team: a, recipient: a@example.com}\n- {team: b, recipient: b@example.com}\n"), 0644))
+		assert.NoError(t, afero.WriteFile(fs, "project/config.yaml", []byte(content), 0644))
+		return fs
+	}
+
+	assertExpanded := func(t *testing.T, configs []config.Config) {
+		assert.Len(t, configs, 2)
+		for i, team := range []string{"a", "b"} {
+			c := configs[i]
+			assert.Equal(t, coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "alert-" + team}, c.Coordinate)
+			assert.Equal(t, value.New(fmt.Sprintf("Alert %s (%d)", team, i)), c.Parameters[config.NameParameter])
+			assert.Equal(t, value.New(team+"@example.com"), c.Parameters["recipient"])
+			assert.Equal(t, value.New(map[string]any{"team": team, "recipient": team + "@example.com"}), c.Parameters[ForEachItemParameter])
+
+			props, errs := c.ResolveParameterValues(nil)
+			assert.Empty(t, errs)
+			rendered, err := c.Render(props)
+			assert.NoError(t, err)
+			assert.JSONEq(t, fmt.Sprintf(`{"team": "%s", "recipient": "%s@example.com"}`, team, team), rendered)
+		}
+	}
+
+	t.Run("list of items", func(t *testing.T) {
+		fs := givenFs(t, `
+configs:
+- id: 'alert-{{ .item.team }}'
+  forEach:
+  - {team: a, recipient: a@example.com}
+  - {team: b, recipient: b@example.com}
+  config:
+    name: 'Alert {{ .item.team }} ({{ .index }})'
+    template: profile.json
+    parameters:
+      recipient: '{{ .item.recipient }}'
+  type:
+    api: some-api
+`)
+		configs, errs := LoadConfig(fs, loaderContext, "project/config.yaml")
+		assert.Empty(t, errs)
+		assertExpanded(t, configs)
+	})
+
+	t.Run("values file", func(t *testing.T) {
+		fs := givenFs(t, `
+configs:
+- id: 'alert-{{ .item.team }}'
+  forEach: values/teams.yaml
+  config:
+    name: 'Alert {{ .item.team }} ({{ .index }})'
+    template: profile.json
+  type:
+    api: some-api
+  environmentOverrides:
+  - environment: dev
+    override:
+      parameters:
+        recipient: '{{ .item.recipient }}'
+`)
+		configs, errs := LoadConfig(fs, loaderContext, "project/config.yaml")
+		assert.Empty(t, errs)
+		assertExpanded(t, configs)
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "duplicate config IDs",
+			content: `
+configs:
+- id: 'alert'
+  forEach: [a, b]
+  config: {name: alert, template: profile.json}
+  type: {api: some-api}
+`,
+			wantErr: "`forEach` items 0 and 1 of config \"alert\" generate the same config ID \"alert\"",
+		},
+		{
+			name: "missing field",
+			content: `
+configs:
+- id: 'alert-{{ .item.missing }}'
+  forEach: [{team: a}]
+  config: {name: alert, template: profile.json}
+  type: {api: some-api}
+`,
+			wantErr: "failed to expand `forEach` item 0 of config",
+		},
+		{
+			name: "missing values file",
+			content: `
+configs:
+- id: 'alert-{{ .item }}'
+  forEach: values/missing.yaml
+  config: {name: alert, template: profile.json}
+  type: {api: some-api}
+`,
+			wantErr: "failed to read values file",
+		},
+		{
+			name: "reserved parameter",
+			content: `
+configs:
+- id: 'alert-{{ .item }}'
+  forEach: [a]
+  config: {name: alert, template: profile.json, parameters: {item: x}}
+  type: {api: some-api}
+`,
+			wantErr: "parameter `item` is reserved for the item",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := LoadConfig(givenFs(t, tt.content), loaderContext, "project/config.yaml")
+			assert.Len(t, errs, 1)
+			assert.ErrorContains(t, errs[0], tt.wantErr)
+		})
+	}
+}
+
+func TestLoadConfig_SkipsValuesFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "values.yaml", []byte("- {team: a}\n- {team: b}\n"), 0644))
+
+	configs, errs := LoadConfig(fs, &LoaderContext{ProjectId: "project"}, "values.yaml")
+	assert.Empty(t, errs)
+	assert.Empty(t, configs)
+}
+
+func TestLoadConfig_Partials(t *testing.T) {
+	loaderContext := &LoaderContext{
+		ProjectId:       "project",
+		Path:            "project",
+		Environments:    []manifest.EnvironmentDefinition{{Name: "dev", Group: "default"}},
+		KnownApis:       map[string]struct{}{"some-api": {}},
+		ParametersSerDe: config.DefaultParameterParsers,
+	}
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "project/_partials/recipient.json", []byte(`{"recipient": "{{ .recipient }}"}`), 0644))
+	assert.NoError(t, afero.WriteFile(fs, "project/alerting/profile.json", []byte(`{"name": "{{ .name }}", "target": {{ include "recipient" }}}`), 0644))
+	assert.NoError(t, afero.WriteFile(fs, "project/alerting/config.yaml", []byte(`
+configs:
+- id: profile
+  config:
+    name: profile
+    template: profile.json
+    parameters:
+      recipient: team@example.com
+  type:
+    api: some-api
+`), 0644))
+
+	configs, errs := LoadConfig(fs, loaderContext, "project/alerting/config.yaml")
+	assert.Empty(t, errs)
+	assert.Len(t, configs, 1)
+
+	props, errs := configs[0].ResolveParameterValues(nil)
+	assert.Empty(t, errs)
+	rendered, err := configs[0].Render(props)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": "profile", "target": {"recipient": "team@example.com"}}`, rendered)
+}
@@ -0,0 +1,199 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/internal/persistence"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+	"path/filepath"
+	"text/template"
+)
+
+// ForEachItemParameter is the name of the parameter holding the item a config was generated for by `forEach`
+const ForEachItemParameter = "item"
+
+// expandForEach returns one config definition for each item of the `forEach` of the given definition, or the definition
+// itself if it has no `forEach`.
+// The ID, the name, and all parameters defined as plain strings of the generated definitions are rendered as templates
+// with the fields `.item` and `.index`. The item is also available to the JSON template as the parameter `item`.
+func expandForEach(fs afero.Fs, folder string, definition persistence.TopLevelConfigDefinition) ([]persistence.TopLevelConfigDefinition, error) {
+	if definition.ForEach == nil {
+		return []persistence.TopLevelConfigDefinition{definition}, nil
+	}
+
+	items, err := forEachItems(fs, folder, definition.ForEach)
+	if err != nil {
+		return nil, fmt.Errorf("invalid `forEach` of config %q: %w", definition.Id, err)
+	}
+
+	if _, found := definition.Config.Parameters[ForEachItemParameter]; found {
+		return nil, fmt.Errorf("invalid `forEach` of config %q: parameter `%s` is reserved for the item", definition.Id, ForEachItemParameter)
+	}
+
+	ids := make(map[string]int, len(items))
+	result := make([]persistence.TopLevelConfigDefinition, 0, len(items))
+	for i, item := range items {
+		data := map[string]any{"item": item, "index": i}
+
+		expanded, err := renderDefinition(definition, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand `forEach` item %d of config %q: %w", i, definition.Id, err)
+		}
+
+		if expanded.Id == "" {
+			return nil, fmt.Errorf("failed to expand `forEach` item %d of config %q: generated config ID is empty", i, definition.Id)
+		}
+		if other, found := ids[expanded.Id]; found {
+			return nil, fmt.Errorf("`forEach` items %d and %d of config %q generate the same config ID %q", other, i, definition.Id, expanded.Id)
+		}
+		ids[expanded.Id] = i
+
+		result = append(result, expanded)
+	}
+	return result, nil
+}
+
+// forEachItems returns the items of a `forEach`, which is either a list of items, or the path of a YAML or JSON file
+// containing the list relative to the folder of the config file
+func forEachItems(fs afero.Fs, folder string, forEach any) ([]any, error) {
+	switch v := forEach.(type) {
+	case []any:
+		return normalizeItems(v), nil
+	case string:
+		data, err := afero.ReadFile(fs, filepath.Join(folder, filepath.FromSlash(v)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file: %w", err)
+		}
+
+		var items []any
+		if err := yaml.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("values file %q must contain a list: %w", v, err)
+		}
+		return normalizeItems(items), nil
+	default:
+		return nil, fmt.Errorf("must be a list of items or the path of a values file")
+	}
+}
+
+// normalizeItems converts all maps of the given items to maps with string keys, so that they can be used like any other
+// parameter value
+func normalizeItems(items []any) []any {
+	normalized := make([]any, len(items))
+	for i, item := range items {
+		normalized[i] = normalizeValue(item)
+	}
+	return normalized
+}
+
+func normalizeValue(v any) any {
+	switch v := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[fmt.Sprint(k)] = normalizeValue(val)
+		}
+		return m
+	case []any:
+		return normalizeItems(v)
+	default:
+		return v
+	}
+}
+
+// renderDefinition returns a copy of the given definition for a single `forEach` item
+func renderDefinition(definition persistence.TopLevelConfigDefinition, data map[string]any) (persistence.TopLevelConfigDefinition, error) {
+	var err error
+	expanded := definition
+	expanded.ForEach = nil
+
+	if expanded.Id, err = render("id", definition.Id, data); err != nil {
+		return persistence.TopLevelConfigDefinition{}, err
+	}
+
+	if expanded.Config, err = renderConfigDefinition(definition.Config, data); err != nil {
+		return persistence.TopLevelConfigDefinition{}, err
+	}
+	if expanded.Config.Parameters == nil {
+		expanded.Config.Parameters = make(map[string]persistence.ConfigParameter)
+	}
+	expanded.Config.Parameters[ForEachItemParameter] = map[any]any{"type": "value", "value": data["item"]}
+
+	expanded.GroupOverrides = make([]persistence.GroupOverride, len(definition.GroupOverrides))
+	for i, o := range definition.GroupOverrides {
+		expanded.GroupOverrides[i] = o
+		if expanded.GroupOverrides[i].Override, err = renderConfigDefinition(o.Override, data); err != nil {
+			return persistence.TopLevelConfigDefinition{}, err
+		}
+	}
+
+	expanded.EnvironmentOverrides = make([]persistence.EnvironmentOverride, len(definition.EnvironmentOverrides))
+	for i, o := range definition.EnvironmentOverrides {
+		expanded.EnvironmentOverrides[i] = o
+		if expanded.EnvironmentOverrides[i].Override, err = renderConfigDefinition(o.Override, data); err != nil {
+			return persistence.TopLevelConfigDefinition{}, err
+		}
+	}
+
+	return expanded, nil
+}
+
+// renderConfigDefinition returns a copy of the given definition, with its name and all parameters defined as plain
+// strings rendered
+func renderConfigDefinition(definition persistence.ConfigDefinition, data map[string]any) (persistence.ConfigDefinition, error) {
+	expanded := definition
+
+	if name, ok := definition.Name.(string); ok {
+		rendered, err := render("name", name, data)
+		if err != nil {
+			return persistence.ConfigDefinition{}, err
+		}
+		expanded.Name = rendered
+	}
+
+	if definition.Parameters == nil {
+		return expanded, nil
+	}
+
+	expanded.Parameters = make(map[string]persistence.ConfigParameter, len(definition.Parameters))
+	for k, v := range definition.Parameters {
+		if s, ok := v.(string); ok {
+			rendered, err := render(fmt.Sprintf("parameter %q", k), s, data)
+			if err != nil {
+				return persistence.ConfigDefinition{}, err
+			}
+			v = rendered
+		}
+		expanded.Parameters[k] = v
+	}
+	return expanded, nil
+}
+
+func render(name string, text string, data map[string]any) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template in %s: %w", name, err)
+	}
+
+	out := bytes.Buffer{}
+	if err := t.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return out.String(), nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	manifestParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/manifest"
 	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
@@ -34,6 +35,7 @@ var allowedScopeParameterTypes = []string{
 	refParam.ReferenceParameterType,
 	valueParam.ValueParameterType,
 	envParam.EnvironmentVariableParameterType,
+	manifestParam.ManifestParameterType,
 }
 
 // isSupportedParamTypeForSkip check is 'skip' section of configuration supports specified param type
@@ -43,6 +45,8 @@ func isSupportedParamTypeForSkip(p parameter.Parameter) bool {
 		return true
 	case envParam.EnvironmentVariableParameterType:
 		return true
+	case manifestParam.ManifestParameterType:
+		return true
 	default:
 		return false
 	}
@@ -129,8 +133,11 @@ func parseParameter(context *singleConfigEntryLoadContext, environment manifest.
 				Type:     context.Type,
 				ConfigId: configId,
 			},
-			ParameterName: name,
-			Value:         maps.ToStringMap(val),
+			ParameterName:         name,
+			Value:                 maps.ToStringMap(val),
+			Folder:                context.Folder,
+			EnvironmentParameters: environment.Parameters,
+			Fs:                    context.Fs,
 		})
 	}
 
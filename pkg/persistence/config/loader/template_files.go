@@ -0,0 +1,76 @@
+/*
+ * @license
+ * Copyright 2024 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+	"path/filepath"
+)
+
+// templateReferences holds the template paths of a config file, including the ones of group and environment overrides
+type templateReferences struct {
+	Configs []struct {
+		Config               templateReference  `yaml:"config"`
+		GroupOverrides       []templateOverride `yaml:"groupOverrides"`
+		EnvironmentOverrides []templateOverride `yaml:"environmentOverrides"`
+	} `yaml:"configs"`
+}
+
+type templateOverride struct {
+	Override templateReference `yaml:"override"`
+}
+
+type templateReference struct {
+	Template string `yaml:"template"`
+}
+
+// YamlTemplateFiles returns the cleaned paths of all YAML files that are used as templates by the given config files.
+// As YAML templates are stored next to config files, they need to be excluded when loading configs.
+// Config files that can not be read or parsed are ignored, as they are reported when loading them.
+func YamlTemplateFiles(fs afero.Fs, configFiles []string) map[string]struct{} {
+	templates := make(map[string]struct{})
+
+	for _, file := range configFiles {
+		data, err := afero.ReadFile(fs, file)
+		if err != nil {
+			continue
+		}
+
+		var refs templateReferences
+		if err := yaml.Unmarshal(data, &refs); err != nil {
+			continue
+		}
+
+		add := func(ref templateReference) {
+			if ref.Template != "" && files.IsYamlFileExtension(ref.Template) {
+				templates[filepath.Join(filepath.Dir(file), filepath.FromSlash(ref.Template))] = struct{}{}
+			}
+		}
+		for _, c := range refs.Configs {
+			add(c.Config)
+			for _, o := range c.GroupOverrides {
+				add(o.Override)
+			}
+			for _, o := range c.EnvironmentOverrides {
+				add(o.Override)
+			}
+		}
+	}
+	return templates
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	configError "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
@@ -256,6 +257,14 @@ func toTopLevelConfigDefinition(context *serializerContext, configs []config.Con
 		})
 	}
 
+	// lifecycle options and hooks are written to the base config if all configs share them, and as environment
+	// overrides otherwise
+	if sharesDeploymentOptions(configs) {
+		setDeploymentOptions(&config, configs[0])
+	} else {
+		environmentOverrideConfigs = withDeploymentOptionOverrides(environmentOverrideConfigs, configs)
+	}
+
 	// We need to extract the configType from the original configs.
 	// Since they all should have the same configType (they have all the same coordinate), we can take any one.
 	ct, err := extractConfigType(context, configs[0])
@@ -272,6 +281,56 @@ func toTopLevelConfigDefinition(context *serializerContext, configs []config.Con
 	}, templates, nil
 }
 
+// sharesDeploymentOptions returns whether all configs have the same lifecycle options, hooks and dependencies
+func sharesDeploymentOptions(configs []config.Config) bool {
+	for _, c := range configs[1:] {
+		if !reflect.DeepEqual(c.Lifecycle, configs[0].Lifecycle) || !reflect.DeepEqual(c.Hooks, configs[0].Hooks) || !slices.Equal(c.DependsOn, configs[0].DependsOn) {
+			return false
+		}
+	}
+	return true
+}
+
+// withDeploymentOptionOverrides sets the lifecycle options, hooks and dependencies of each config on the override of its
+// environment.
+// Overrides are added for environments that do not have one yet.
+func withDeploymentOptionOverrides(overrides []persistence.EnvironmentOverride, configs []config.Config) []persistence.EnvironmentOverride {
+	for _, c := range configs {
+		if reflect.DeepEqual(c.Lifecycle, config.Lifecycle{}) && c.Hooks.IsEmpty() && len(c.DependsOn) == 0 {
+			continue
+		}
+
+		i := slices.IndexFunc(overrides, func(o persistence.EnvironmentOverride) bool { return o.Environment == c.Environment })
+		if i < 0 {
+			overrides = append(overrides, persistence.EnvironmentOverride{Environment: c.Environment})
+			i = len(overrides) - 1
+		}
+		setDeploymentOptions(&overrides[i].Override, c)
+	}
+	return overrides
+}
+
+func setDeploymentOptions(definition *persistence.ConfigDefinition, c config.Config) {
+	if c.Lifecycle.CreateOnly {
+		definition.CreateOnly = &c.Lifecycle.CreateOnly
+	}
+	definition.IgnoreChanges = c.Lifecycle.IgnoreChanges
+	if c.Lifecycle.PreventDestroy {
+		definition.PreventDestroy = &c.Lifecycle.PreventDestroy
+	}
+	if !c.Hooks.IsEmpty() {
+		hooks := hook.ToDefinitions(c.Hooks)
+		definition.Hooks = &hooks
+	}
+	for _, d := range c.DependsOn {
+		dependency := persistence.ConfigDependency{Type: d.Type, ConfigId: d.ConfigId}
+		if d.Project != c.Coordinate.Project {
+			dependency.Project = d.Project
+		}
+		definition.DependsOn = append(definition.DependsOn, dependency)
+	}
+}
+
 func extractConfigType(context *serializerContext, cfg config.Config) (persistence.TypeDefinition, error) {
 
 	switch t := cfg.Type.(type) {
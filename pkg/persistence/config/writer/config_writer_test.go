@@ -24,6 +24,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/hook"
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
 	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
@@ -32,6 +33,7 @@ import (
 	"gopkg.in/yaml.v2"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
@@ -773,6 +775,65 @@ func TestWriteConfigs(t *testing.T) {
 				"project/alerting-profile/config.yaml",
 			},
 		},
+		{
+			name: "Lifecycle options, hooks and dependencies are written",
+			configs: []config.Config{
+				{
+					Template: template.NewInMemoryTemplateWithPath("project/alerting-profile/a.json", ""),
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "alerting-profile",
+						ConfigId: "configId",
+					},
+					Type: config.ClassicApiType{
+						Api: "alerting-profile",
+					},
+					Parameters: map[string]parameter.Parameter{
+						config.NameParameter: &value.ValueParameter{Value: "name"},
+					},
+					Lifecycle: config.Lifecycle{
+						IgnoreChanges:  []string{"rules"},
+						PreventDestroy: true,
+					},
+					Hooks: hook.Hooks{
+						PostDeploy: []hook.Hook{{Command: []string{"./check.sh"}, Timeout: time.Minute}},
+					},
+					DependsOn: []coordinate.Coordinate{
+						{Project: "project", Type: "management-zone", ConfigId: "zone"},
+						{Project: "other", Type: "management-zone", ConfigId: "zone"},
+					},
+				},
+			},
+			expectedConfigs: map[string]persistence.TopLevelDefinition{
+				"alerting-profile": {
+					Configs: []persistence.TopLevelConfigDefinition{
+						{
+							Id: "configId",
+							Config: persistence.ConfigDefinition{
+								Name:           "name",
+								Template:       "a.json",
+								Skip:           false,
+								IgnoreChanges:  []string{"rules"},
+								PreventDestroy: &[]bool{true}[0],
+								Hooks: &hook.Definitions{
+									PostDeploy: []hook.Definition{{Command: []string{"./check.sh"}, Timeout: "1m0s"}},
+								},
+								DependsOn: []persistence.ConfigDependency{
+									{Type: "management-zone", ConfigId: "zone"},
+									{Project: "other", Type: "management-zone", ConfigId: "zone"},
+								},
+							},
+							Type: persistence.TypeDefinition{
+								Api: "alerting-profile",
+							},
+						},
+					},
+				},
+			},
+			expectedTemplatePaths: []string{
+				"project/alerting-profile/a.json",
+			},
+		},
 		{
 			name: "Settings 2.0 schema write sanitizes names",
 			configs: []config.Config{
@@ -25,10 +25,13 @@ import (
 	configErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/persistence/config/loader"
 	"github.com/spf13/afero"
+	"path/filepath"
 	"slices"
+	"strings"
 )
 
 type ProjectLoaderContext struct {
@@ -68,6 +71,28 @@ func newDuplicateConfigIdentifierError(c config.Config) DuplicateConfigIdentifie
 	}
 }
 
+// UnknownDependencyError occurs if a config depends on a config that does not exist
+type UnknownDependencyError struct {
+	// Location (coordinate) of the config.Config that defines the dependency
+	Location coordinate.Coordinate `json:"location"`
+	// EnvironmentDetails of the environment for which the config was loaded
+	EnvironmentDetails configErrors.EnvironmentDetails `json:"environmentDetails"`
+	// Dependency is the coordinate of the config that does not exist
+	Dependency coordinate.Coordinate `json:"dependency"`
+}
+
+func (e UnknownDependencyError) Coordinates() coordinate.Coordinate {
+	return e.Location
+}
+
+func (e UnknownDependencyError) LocationDetails() configErrors.EnvironmentDetails {
+	return e.EnvironmentDetails
+}
+
+func (e UnknownDependencyError) Error() string {
+	return fmt.Sprintf("`dependsOn` references unknown config `%s`", e.Dependency)
+}
+
 func LoadProjects(fs afero.Fs, context ProjectLoaderContext) ([]Project, []error) {
 	environments := toEnvironmentSlice(context.Manifest.Environments)
 	projects := make([]Project, 0)
@@ -99,9 +124,43 @@ func LoadProjects(fs afero.Fs, context ProjectLoaderContext) ([]Project, []error
 		return nil, errors
 	}
 
+	if errors = findUnknownDependencies(projects); errors != nil {
+		return nil, errors
+	}
+
 	return projects, nil
 }
 
+// findUnknownDependencies returns an error for each config that depends on a config that does not exist in the same
+// environment
+func findUnknownDependencies(projects []Project) []error {
+	known := make(map[string]map[coordinate.Coordinate]struct{})
+	for _, p := range projects {
+		p.ForEveryConfigDo(func(c config.Config) {
+			if known[c.Environment] == nil {
+				known[c.Environment] = make(map[coordinate.Coordinate]struct{})
+			}
+			known[c.Environment][c.Coordinate] = struct{}{}
+		})
+	}
+
+	var errs []error
+	for _, p := range projects {
+		p.ForEveryConfigDo(func(c config.Config) {
+			for _, d := range c.DependsOn {
+				if _, found := known[c.Environment][d]; !found {
+					errs = append(errs, UnknownDependencyError{
+						Location:           c.Coordinate,
+						EnvironmentDetails: configErrors.EnvironmentDetails{Group: c.Group, Environment: c.Environment},
+						Dependency:         d,
+					})
+				}
+			}
+		})
+	}
+	return errs
+}
+
 func toEnvironmentSlice(environments map[string]manifest.EnvironmentDefinition) []manifest.EnvironmentDefinition {
 	var result []manifest.EnvironmentDefinition
 
@@ -186,6 +245,10 @@ func loadConfigsOfProject(fs afero.Fs, loadingContext ProjectLoaderContext, proj
 		return nil, []error{fmt.Errorf("failed to walk files: %w", err)}
 	}
 
+	// partial templates and YAML templates are stored in the project as well, but are not config files
+	partialsPath := filepath.Join(projectDefinition.Path, template.PartialsFolder)
+	templateFiles := loader.YamlTemplateFiles(fs, configFiles)
+
 	var configs []config.Config
 	var errs []error
 
@@ -198,6 +261,15 @@ func loadConfigsOfProject(fs afero.Fs, loadingContext ProjectLoaderContext, proj
 	}
 
 	for _, file := range configFiles {
+		if isInFolder(file, partialsPath) {
+			log.WithFields(field.F("file", file)).Debug("Skipping %s, as it is a partial template", file)
+			continue
+		}
+		if _, isTemplate := templateFiles[filepath.Clean(file)]; isTemplate {
+			log.WithFields(field.F("file", file)).Debug("Skipping %s, as it is a template", file)
+			continue
+		}
+
 		log.WithFields(field.F("file", file)).Debug("Loading configuration file %s", file)
 		loadedConfigs, configErrs := loader.LoadConfig(fs, ctx, file)
 
@@ -208,6 +280,12 @@ func loadConfigsOfProject(fs afero.Fs, loadingContext ProjectLoaderContext, proj
 	return configs, errs
 }
 
+// isInFolder returns whether the given file is located in the given folder or any of its sub-folders
+func isInFolder(file string, folder string) bool {
+	rel, err := filepath.Rel(folder, file)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 func findDuplicatedConfigIdentifiers(configs []config.Config) []config.Config {
 
 	coordinates := make(map[string]struct{})
@@ -132,6 +132,37 @@ func TestLoadProjects_LoadsSimpleProject(t *testing.T) {
 	assert.Equal(t, len(a), 1, "Expected a one config to be loaded for alerting-profile")
 }
 
+func TestLoadProjects_DoesNotLoadPartialsAsConfigs(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "project/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Test Dashboard\n    template: board.json\n  type:\n    api: dashboard"), 0644)
+	_ = afero.WriteFile(testFs, "project/dashboard/board.json", []byte("{{ template \"tile\" . }}"), 0644)
+	_ = afero.WriteFile(testFs, "project/_partials/tile.yaml", []byte("configs: not a config"), 0644)
+	_ = afero.WriteFile(testFs, "project/_partials/nested/chart.yaml", []byte("configs: not a config"), 0644)
+
+	context := getSimpleProjectLoaderContext([]string{"project"})
+
+	got, gotErrs := LoadProjects(testFs, context)
+
+	assert.Equal(t, len(gotErrs), 0, "Expected to load project without error")
+	assert.Equal(t, len(got), 1, "Expected a single loaded project")
+	assert.Equal(t, len(got[0].Configs["env"]["dashboard"]), 1, "Expected only the dashboard config to be loaded")
+}
+
+func TestLoadProjects_DoesNotLoadYamlTemplatesAsConfigs(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "project/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Test Dashboard\n    template: board-template.yaml\n  type:\n    api: dashboard\n  environmentOverrides:\n  - environment: env\n    override:\n      template: templates/env.yml"), 0644)
+	_ = afero.WriteFile(testFs, "project/dashboard/board-template.yaml", []byte("name: \"{{ .name }}\""), 0644)
+	_ = afero.WriteFile(testFs, "project/dashboard/templates/env.yml", []byte("name: \"{{ .name }}\""), 0644)
+
+	context := getSimpleProjectLoaderContext([]string{"project"})
+
+	got, gotErrs := LoadProjects(testFs, context)
+
+	assert.Equal(t, len(gotErrs), 0, "Expected to load project without error")
+	assert.Equal(t, len(got), 1, "Expected a single loaded project")
+	assert.Equal(t, len(got[0].Configs["env"]["dashboard"]), 1, "Expected only the dashboard config to be loaded")
+}
+
 func TestLoadProjects_LoadsSimpleProjectInFoldersNotMatchingApiName(t *testing.T) {
 	testFs := afero.NewMemMapFs()
 	_ = afero.WriteFile(testFs, "project/alerting-profile/profile.yaml", []byte("configs:\n- id: profile\n  config:\n    name: Test Profile\n    template: profile.json\n  type:\n    api: alerting-profile"), 0644)
@@ -514,6 +545,30 @@ func TestLoadProjects_ReturnsErrOnOverlappingCoordinate_InSameFile(t *testing.T)
 	assert.Equal(t, len(gotErrs), 1, "Expected to fail on overlapping coordinates")
 }
 
+func TestLoadProjects_ReturnsErrOnUnknownDependency(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "project/alerting-profile/profile.yaml", []byte(`configs:
+- id: profile
+  config:
+    name: Test Profile
+    template: profile.json
+    dependsOn:
+    - {configType: dashboard, configId: board}
+    - {configType: dashboard, configId: missing}
+  type:
+    api: alerting-profile`), 0644)
+	_ = afero.WriteFile(testFs, "project/alerting-profile/profile.json", []byte("{}"), 0644)
+	_ = afero.WriteFile(testFs, "project/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Test Dashboard\n    template: board.json\n  type:\n    api: dashboard"), 0644)
+	_ = afero.WriteFile(testFs, "project/dashboard/board.json", []byte("{}"), 0644)
+
+	context := getSimpleProjectLoaderContext([]string{"project"})
+
+	_, gotErrs := LoadProjects(testFs, context)
+
+	assert.Equal(t, len(gotErrs), 1, "Expected to fail on the unknown dependency only")
+	assert.ErrorContains(t, gotErrs[0], "`dependsOn` references unknown config `project:dashboard:missing`")
+}
+
 func Test_loadProject_returnsErrorIfProjectPathDoesNotExist(t *testing.T) {
 	fs := afero.NewMemMapFs()
 	ctx := ProjectLoaderContext{}